@@ -13,6 +13,7 @@ import (
 	"github.com/dimas-pramantya/money-management/internal/configs"
 	"github.com/dimas-pramantya/money-management/internal/database/connection"
 	"github.com/dimas-pramantya/money-management/internal/database/migration"
+	"github.com/dimas-pramantya/money-management/internal/scheduler"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/gin-gonic/gin"
@@ -24,6 +25,7 @@ func main() {
 	connection.Initiator()
 	migration.Initiator(connection.DBConnections)
 	defer connection.DBConnections.Close()
+	scheduler.Initiator(connection.DBConnections)
 
 	r := gin.Default()
 	r.Use(GlobalExceptionHandler())
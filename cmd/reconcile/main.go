@@ -0,0 +1,70 @@
+// Command reconcile recomputes every user's balance from their transaction history and reports
+// mismatches. Run with -fix to correct them inside a single database transaction.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dimas-pramantya/money-management/internal/configs"
+	"github.com/dimas-pramantya/money-management/internal/database/connection"
+	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
+	"github.com/dimas-pramantya/money-management/internal/service"
+	"github.com/google/uuid"
+)
+
+func main() {
+	fix := flag.Bool("fix", false, "correct mismatched balances")
+	user := flag.String("user", "", "only reconcile this user id")
+	flag.Parse()
+
+	var userID *uuid.UUID
+	if *user != "" {
+		parsed, err := uuid.Parse(*user)
+		if err != nil {
+			fmt.Println("Invalid user id:", err)
+			os.Exit(2)
+		}
+		userID = &parsed
+	}
+
+	configs.Initiator()
+	connection.Initiator()
+	db := connection.DBConnections
+	defer db.Close()
+
+	reconciliationUC := service.NewReconciliationService(
+		pgrepository.NewReconciliationPgRepository(db),
+		pgrepository.NewUserPgRepository(db),
+		pgrepository.NewAccountPgRepository(db),
+		pgrepository.NewLedgerPgRepository(db),
+		db,
+	)
+
+	report, err := reconciliationUC.Run(userID, *fix)
+	if err != nil {
+		fmt.Println("Reconciliation failed:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Checked %d users and %d accounts, %d mismatches\n", report.CheckedUsers, report.CheckedAccounts, len(report.Mismatches))
+	for _, m := range report.Mismatches {
+		if m.AccountID != nil {
+			fmt.Printf("  user %s account %d (%s): stored %d, ledger %d, expected %d\n",
+				m.UserID, *m.AccountID, *m.AccountName, m.StoredBalance.Amount, m.LedgerBalance.Amount, m.ExpectedBalance.Amount)
+		} else {
+			fmt.Printf("  user %s: stored %d, expected %d\n", m.UserID, m.StoredBalance.Amount, m.ExpectedBalance.Amount)
+		}
+	}
+
+	if len(report.Mismatches) == 0 {
+		return
+	}
+	if report.Fixed {
+		fmt.Println("All mismatches fixed")
+		return
+	}
+	fmt.Println("Run with -fix to correct them")
+	os.Exit(1)
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "github.com/dimas-pramantya/money-management/internal/money"
+
+type AccountDto struct {
+	ID          int         `json:"id"`
+	UserID      string      `json:"user_id"`
+	Name        string      `json:"name"`
+	AccountType string      `json:"account_type"`
+	Currency    string      `json:"currency"`
+	Balance     money.Money `json:"balance"`
+	CreatedAt   string      `json:"created_at"`
+	UpdatedAt   *string     `json:"updated_at"`
+	CreatedBy   string      `json:"created_by"`
+	UpdatedBy   *string     `json:"updated_by"`
+}
+
+type CreateAccountDto struct {
+	Name        string `json:"name" binding:"required"`
+	AccountType string `json:"account_type" binding:"required,oneof=cash bank e_wallet credit_card"`
+	// Currency defaults to the user's base currency.
+	Currency       string      `json:"currency" binding:"omitempty,len=3,alpha,uppercase"`
+	InitialBalance money.Money `json:"initial_balance"`
+}
+
+type UpdateAccountDto struct {
+	Name        string `json:"name" binding:"required"`
+	AccountType string `json:"account_type" binding:"required,oneof=cash bank e_wallet credit_card"`
+	// Currency can only change while the account has no transactions and no balance.
+	Currency string `json:"currency" binding:"omitempty,len=3,alpha,uppercase"`
+}
@@ -14,4 +14,13 @@ type PaginationResponse[T any] struct {
 	Records       []T    `json:"records"`
 	NextPage      *int   `json:"nextPage"`
 	PreviousPage  *int   `json:"previousPage"`
-}
\ No newline at end of file
+}
+
+// CursorResponse is a page of a cursor-paginated listing. NextCursor is nil on the last page and
+// TotalRecords is only counted on request.
+type CursorResponse[T any] struct {
+	Limit         int     `json:"limit"`
+	Records       []T     `json:"records"`
+	NextCursor    *string `json:"nextCursor"`
+	TotalRecords  *int    `json:"totalRecords"`
+}
@@ -0,0 +1,76 @@
+package dto
+
+import "github.com/dimas-pramantya/money-management/internal/money"
+
+// BudgetDto reports a budget for one period. Budgeted is what has been allocated to the period;
+// Amount is only its target, and ToFund what still has to be allocated, on top of RolloverIn, to
+// reach it.
+type BudgetDto struct {
+	ID           int          `json:"id"`
+	CategoryID   int          `json:"category_id"`
+	Category     string       `json:"category"`
+	CategoryPath string       `json:"category_path"`
+	Amount       money.Money  `json:"amount"`
+	Period       string       `json:"period"`
+	StartDate    string       `json:"start_date"`
+	EndDate      *string      `json:"end_date"`
+	Rollover     string       `json:"rollover"`
+	RolloverCap  *money.Money `json:"rollover_cap"`
+	PeriodStart  string       `json:"period_start"`
+	PeriodEnd    string       `json:"period_end"`
+	Allocated    money.Money  `json:"allocated"`
+	Budgeted     money.Money  `json:"budgeted"`
+	ToFund       money.Money  `json:"to_fund"`
+	RolloverIn   money.Money  `json:"rollover_in"`
+	Spent        money.Money  `json:"spent"`
+	Remaining    money.Money  `json:"remaining"`
+	Percentage   float64      `json:"percentage"`
+	UserID       string       `json:"user_id"`
+	CreatedAt    string       `json:"created_at"`
+	UpdatedAt    *string      `json:"updated_at"`
+	CreatedBy    string       `json:"created_by"`
+	UpdatedBy    *string      `json:"updated_by"`
+}
+
+type GetBudgetParams struct {
+	Date *string `form:"date"`
+}
+
+type CreateBudgetDto struct {
+	CategoryID  int          `json:"category_id" binding:"required"`
+	Amount      money.Money  `json:"amount"`
+	Period      string       `json:"period" binding:"required,oneof=weekly monthly yearly"`
+	StartDate   string       `json:"start_date" binding:"required"`
+	EndDate     *string      `json:"end_date"`
+	Rollover    string       `json:"rollover" binding:"omitempty,oneof=none full capped"`
+	RolloverCap *money.Money `json:"rollover_cap"`
+}
+
+type UpdateBudgetDto struct {
+	CategoryID  int          `json:"category_id" binding:"required"`
+	Amount      money.Money  `json:"amount"`
+	Period      string       `json:"period" binding:"required,oneof=weekly monthly yearly"`
+	StartDate   string       `json:"start_date" binding:"required"`
+	EndDate     *string      `json:"end_date"`
+	Rollover    string       `json:"rollover" binding:"omitempty,oneof=none full capped"`
+	RolloverCap *money.Money `json:"rollover_cap"`
+}
+
+type EnvelopeSummaryDto struct {
+	Date       string      `json:"date"`
+	Income     money.Money `json:"income"`
+	Assigned   money.Money `json:"assigned"`
+	Released   money.Money `json:"released"`
+	Overspent  money.Money `json:"overspent"`
+	Unassigned money.Money `json:"unassigned"`
+	Envelopes  []BudgetDto `json:"envelopes"`
+}
+
+// MoveAllocationDto moves money between envelopes for the period containing Date. A nil
+// FromBudgetID takes it from the unassigned pool, a nil ToBudgetID returns it there.
+type MoveAllocationDto struct {
+	FromBudgetID *int        `json:"from_budget_id"`
+	ToBudgetID   *int        `json:"to_budget_id"`
+	Amount       money.Money `json:"amount"`
+	Date         string      `json:"date" binding:"required"`
+}
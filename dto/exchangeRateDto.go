@@ -0,0 +1,43 @@
+package dto
+
+type ExchangeRateDto struct {
+	ID            int     `json:"id"`
+	FromCurrency  string  `json:"from_currency"`
+	ToCurrency    string  `json:"to_currency"`
+	Rate          string  `json:"rate"`
+	EffectiveDate string  `json:"effective_date"`
+	UserID        string  `json:"user_id"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedAt     *string `json:"updated_at"`
+	CreatedBy     string  `json:"created_by"`
+	UpdatedBy     *string `json:"updated_by"`
+}
+
+type GetExchangeRateParams struct {
+	Currency *string `form:"currency" binding:"omitempty,len=3,alpha,uppercase"`
+}
+
+// CreateExchangeRateDto records a rate; a rate already recorded for the pair on that date is replaced.
+// Rate is a decimal string such as "15850.25" so no precision is lost on the way in.
+type CreateExchangeRateDto struct {
+	FromCurrency  string `json:"from_currency" binding:"required,len=3,alpha,uppercase"`
+	ToCurrency    string `json:"to_currency" binding:"required,len=3,alpha,uppercase"`
+	Rate          string `json:"rate" binding:"required"`
+	EffectiveDate string `json:"effective_date" binding:"required"`
+}
+
+type UpdateExchangeRateDto struct {
+	FromCurrency  string `json:"from_currency" binding:"required,len=3,alpha,uppercase"`
+	ToCurrency    string `json:"to_currency" binding:"required,len=3,alpha,uppercase"`
+	Rate          string `json:"rate" binding:"required"`
+	EffectiveDate string `json:"effective_date" binding:"required"`
+}
+
+type ExchangeRateImportErrorDto struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+type ExchangeRateImportDto struct {
+	Imported int `json:"imported"`
+}
@@ -0,0 +1,93 @@
+package dto
+
+import "github.com/dimas-pramantya/money-management/internal/money"
+
+type ImportProfileDto struct {
+	ID                int     `json:"id"`
+	Name              string  `json:"name"`
+	Delimiter         string  `json:"delimiter"`
+	HasHeader         bool    `json:"has_header"`
+	DateColumn        string  `json:"date_column"`
+	DateFormat        string  `json:"date_format"`
+	AmountColumn      string  `json:"amount_column"`
+	DecimalSeparator  string  `json:"decimal_separator"`
+	TypeColumn        *string `json:"type_column"`
+	IncomeValue       *string `json:"income_value"`
+	ExpenseValue      *string `json:"expense_value"`
+	NoteColumn        *string `json:"note_column"`
+	CategoryColumn    *string `json:"category_column"`
+	DefaultCategoryID *int    `json:"default_category_id"`
+	UserID            string  `json:"user_id"`
+	CreatedAt         string  `json:"created_at"`
+	UpdatedAt         *string `json:"updated_at"`
+	CreatedBy         string  `json:"created_by"`
+	UpdatedBy         *string `json:"updated_by"`
+}
+
+type CreateImportProfileDto struct {
+	Name              string  `json:"name" binding:"required"`
+	Delimiter         string  `json:"delimiter" binding:"omitempty,len=1"`
+	HasHeader         *bool   `json:"has_header"`
+	DateColumn        string  `json:"date_column" binding:"required"`
+	DateFormat        string  `json:"date_format"`
+	AmountColumn      string  `json:"amount_column" binding:"required"`
+	DecimalSeparator  string  `json:"decimal_separator" binding:"omitempty,oneof=. ,"`
+	TypeColumn        *string `json:"type_column"`
+	IncomeValue       *string `json:"income_value"`
+	ExpenseValue      *string `json:"expense_value"`
+	NoteColumn        *string `json:"note_column"`
+	CategoryColumn    *string `json:"category_column"`
+	DefaultCategoryID *int    `json:"default_category_id"`
+}
+
+type UpdateImportProfileDto struct {
+	Name              string  `json:"name" binding:"required"`
+	Delimiter         string  `json:"delimiter" binding:"omitempty,len=1"`
+	HasHeader         *bool   `json:"has_header"`
+	DateColumn        string  `json:"date_column" binding:"required"`
+	DateFormat        string  `json:"date_format"`
+	AmountColumn      string  `json:"amount_column" binding:"required"`
+	DecimalSeparator  string  `json:"decimal_separator" binding:"omitempty,oneof=. ,"`
+	TypeColumn        *string `json:"type_column"`
+	IncomeValue       *string `json:"income_value"`
+	ExpenseValue      *string `json:"expense_value"`
+	NoteColumn        *string `json:"note_column"`
+	CategoryColumn    *string `json:"category_column"`
+	DefaultCategoryID *int    `json:"default_category_id"`
+}
+
+// ImportTransactionsDto holds the form fields sent along with an import file. Format defaults to
+// the file extension. Without Commit the import only previews; with SkipInvalid a commit records
+// the valid rows even if others fail. CategoryID is used for OFX and QIF entries without a known
+// category, before the profile's default category.
+type ImportTransactionsDto struct {
+	Format      string `form:"format" binding:"omitempty,oneof=csv ofx qif"`
+	ProfileID   *int   `form:"profile_id"`
+	AccountID   int    `form:"account_id" binding:"required"`
+	CategoryID  *int   `form:"category_id"`
+	Commit      bool   `form:"commit"`
+	SkipInvalid bool   `form:"skip_invalid"`
+}
+
+type ImportRowDto struct {
+	Line            int         `json:"line"`
+	TransactionDate string      `json:"transaction_date"`
+	TransactionType string      `json:"transaction_type"`
+	Amount          money.Money `json:"amount"`
+	CategoryID      int         `json:"category_id"`
+	Note            *string     `json:"note"`
+	ExternalID      *string     `json:"external_id"`
+	Skipped         bool        `json:"skipped"`
+	Error           *string     `json:"error"`
+	TransactionID   *int        `json:"transaction_id"`
+}
+
+type ImportReportDto struct {
+	TotalRows   int            `json:"total_rows"`
+	ValidRows   int            `json:"valid_rows"`
+	InvalidRows int            `json:"invalid_rows"`
+	SkippedRows int            `json:"skipped_rows"`
+	Committed   bool           `json:"committed"`
+	Imported    int            `json:"imported"`
+	Rows        []ImportRowDto `json:"rows"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "github.com/dimas-pramantya/money-management/internal/money"
+
+type LedgerEntryDto struct {
+	ID            int64       `json:"id"`
+	JournalID     string      `json:"journal_id"`
+	AccountID     *int        `json:"account_id"`
+	LedgerAccount string      `json:"ledger_account"`
+	EntryType     string      `json:"entry_type"`
+	Amount        money.Money `json:"amount"`
+	TransactionID *int        `json:"transaction_id"`
+	TransferID    *int        `json:"transfer_id"`
+	Description   *string     `json:"description"`
+	CreatedAt     string      `json:"created_at"`
+	CreatedBy     string      `json:"created_by"`
+}
+
+type GetLedgerParams struct {
+	Limit int `form:"limit"`
+	Page  int `form:"page"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/dimas-pramantya/money-management/internal/money"
+
+type BalanceMismatchDto struct {
+	UserID          string       `json:"user_id"`
+	AccountID       *int         `json:"account_id"`
+	AccountName     *string      `json:"account_name"`
+	StoredBalance   money.Money  `json:"stored_balance"`
+	LedgerBalance   *money.Money `json:"ledger_balance"`
+	ExpectedBalance money.Money  `json:"expected_balance"`
+}
+
+type ReconciliationReportDto struct {
+	CheckedUsers    int                  `json:"checked_users"`
+	CheckedAccounts int                  `json:"checked_accounts"`
+	Mismatches      []BalanceMismatchDto `json:"mismatches"`
+	Fixed           bool                 `json:"fixed"`
+}
+
+type ReconcileParams struct {
+	UserID *string `form:"user_id"`
+}
@@ -0,0 +1,49 @@
+package dto
+
+import "github.com/dimas-pramantya/money-management/internal/money"
+
+type RecurringTransactionDto struct {
+	ID              int         `json:"id"`
+	AccountID       int         `json:"account_id"`
+	CategoryID      int         `json:"category_id"`
+	TransactionType string      `json:"transaction_type"`
+	Amount          money.Money `json:"amount"`
+	Notes           *string     `json:"note"`
+	Frequency       string      `json:"frequency"`
+	IntervalCount   int         `json:"interval_count"`
+	DayOfMonth      *int        `json:"day_of_month"`
+	StartDate       string      `json:"start_date"`
+	EndDate         *string     `json:"end_date"`
+	NextRunDate     *string     `json:"next_run_date"`
+	UserID          string      `json:"user_id"`
+	CreatedAt       string      `json:"created_at"`
+	UpdatedAt       *string     `json:"updated_at"`
+	CreatedBy       string      `json:"created_by"`
+	UpdatedBy       *string     `json:"updated_by"`
+}
+
+type CreateRecurringTransactionDto struct {
+	AccountID       int         `json:"account_id" binding:"required"`
+	CategoryID      int         `json:"category_id" binding:"required"`
+	TransactionType string      `json:"transaction_type" binding:"required"`
+	Amount          money.Money `json:"amount"`
+	Note            *string     `json:"note"`
+	Frequency       string      `json:"frequency" binding:"required,oneof=daily weekly monthly yearly"`
+	IntervalCount   int         `json:"interval_count" binding:"omitempty,min=1"`
+	DayOfMonth      *int        `json:"day_of_month" binding:"omitempty,min=1,max=31"`
+	StartDate       string      `json:"start_date" binding:"required"`
+	EndDate         *string     `json:"end_date"`
+}
+
+type UpdateRecurringTransactionDto struct {
+	AccountID       int         `json:"account_id" binding:"required"`
+	CategoryID      int         `json:"category_id" binding:"required"`
+	TransactionType string      `json:"transaction_type" binding:"required"`
+	Amount          money.Money `json:"amount"`
+	Note            *string     `json:"note"`
+	Frequency       string      `json:"frequency" binding:"required,oneof=daily weekly monthly yearly"`
+	IntervalCount   int         `json:"interval_count" binding:"omitempty,min=1"`
+	DayOfMonth      *int        `json:"day_of_month" binding:"omitempty,min=1,max=31"`
+	StartDate       string      `json:"start_date" binding:"required"`
+	EndDate         *string     `json:"end_date"`
+}
@@ -0,0 +1,72 @@
+package dto
+
+import "github.com/dimas-pramantya/money-management/internal/money"
+
+type GetReportSummaryParams struct {
+	StartDate string `form:"start_date" binding:"required"`
+	EndDate   string `form:"end_date" binding:"required"`
+	Bucket    string `form:"bucket" binding:"omitempty,oneof=day week month year"`
+	AccountID *int   `form:"account_id"`
+}
+
+type GetReportByTagParams struct {
+	StartDate string `form:"start_date" binding:"required"`
+	EndDate   string `form:"end_date" binding:"required"`
+	AccountID *int   `form:"account_id"`
+}
+
+type ReportBucketDto struct {
+	PeriodStart string      `json:"period_start"`
+	Income      money.Money `json:"income"`
+	Expense     money.Money `json:"expense"`
+	Net         money.Money `json:"net"`
+}
+
+// ReportCategoryDto totals a category together with every category below it; Children break the
+// totals down, and whatever they leave over was booked on the category itself.
+type ReportCategoryDto struct {
+	CategoryID int                 `json:"category_id"`
+	Category   string              `json:"category"`
+	Income     money.Money         `json:"income"`
+	Expense    money.Money         `json:"expense"`
+	Net        money.Money         `json:"net"`
+	Children   []ReportCategoryDto `json:"children"`
+}
+
+type ReportSummaryDto struct {
+	StartDate    string              `json:"start_date"`
+	EndDate      string              `json:"end_date"`
+	Bucket       string              `json:"bucket"`
+	Currency     string              `json:"currency"`
+	TotalIncome  money.Money         `json:"total_income"`
+	TotalExpense money.Money         `json:"total_expense"`
+	Net          money.Money         `json:"net"`
+	Buckets      []ReportBucketDto   `json:"buckets"`
+	Categories   []ReportCategoryDto `json:"categories"`
+}
+
+type ReportTagDto struct {
+	TagID            int         `json:"tag_id"`
+	Tag              string      `json:"tag"`
+	Income           money.Money `json:"income"`
+	Expense          money.Money `json:"expense"`
+	Net              money.Money `json:"net"`
+	TransactionCount int         `json:"transaction_count"`
+}
+
+// ReportTagSummaryDto totals income and expense per tag. A transaction with several tags counts
+// under each of them, so the tags do not add up to the overall totals.
+type ReportTagSummaryDto struct {
+	StartDate string         `json:"start_date"`
+	EndDate   string         `json:"end_date"`
+	Currency  string         `json:"currency"`
+	Tags      []ReportTagDto `json:"tags"`
+}
+
+// MissingExchangeRateDto is reported when a summary cannot convert some transactions: a rate from
+// Currency to BaseCurrency is needed on or before Date.
+type MissingExchangeRateDto struct {
+	Currency     string `json:"currency"`
+	BaseCurrency string `json:"base_currency"`
+	Date         string `json:"date"`
+}
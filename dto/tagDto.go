@@ -0,0 +1,25 @@
+package dto
+
+type TagDto struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	UserID    string  `json:"user_id"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt *string `json:"updated_at"`
+	CreatedBy string  `json:"created_by"`
+	UpdatedBy *string `json:"updated_by"`
+}
+
+type CreateTagDto struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type UpdateTagDto struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// TransactionTagDto is a tag as listed on a transaction.
+type TransactionTagDto struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
@@ -1,20 +1,55 @@
 package dto
 
 type TransactionCategoryDto struct {
-	ID        int     `json:"id"`
-	Name      string  `json:"name"`
-	UserID    string  `json:"user_id"`
-	CreatedAt string  `json:"created_at"`
-	UpdatedAt *string `json:"updated_at"`
-	CreatedBy string  `json:"created_by"`
-	UpdatedBy *string `json:"updated_by"`
+	ID         int     `json:"id"`
+	Name       string  `json:"name"`
+	UserID     string  `json:"user_id"`
+	ParentID   *int    `json:"parent_id"`
+	Kind       string  `json:"kind"`
+	Path       string  `json:"path"`
+	ArchivedAt *string `json:"archived_at"`
+	CreatedAt  string  `json:"created_at"`
+	UpdatedAt  *string `json:"updated_at"`
+	CreatedBy  string  `json:"created_by"`
+	UpdatedBy  *string `json:"updated_by"`
 }
 
+// GetTransactionCategoryParams filters category listings; archived categories are left out unless
+// IncludeArchived is set.
+type GetTransactionCategoryParams struct {
+	IncludeArchived bool `form:"include_archived"`
+}
+
+// TransactionCategoryTreeDto is a category with the categories filed under it.
+type TransactionCategoryTreeDto struct {
+	TransactionCategoryDto
+	Children []TransactionCategoryTreeDto `json:"children"`
+}
+
+// CreateTransactionCategoryDto creates a category; Kind limits it to income or expense transactions
+// and defaults to both.
 type CreateTransactionCategoryDto struct {
-	Name   string `json:"name" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	ParentID *int   `json:"parent_id"`
+	Kind     string `json:"kind" binding:"omitempty,oneof=income expense both"`
 }
 
+// UpdateTransactionCategoryDto renames a category and moves it under ParentID, or to the top level
+// when MoveToRoot is set. The parent and Kind are kept when left out.
 type UpdateTransactionCategoryDto struct {
-	Name  string `json:"name" binding:"required"`
+	Name       string  `json:"name" binding:"required"`
+	ParentID   *int    `json:"parent_id" binding:"excluded_with=MoveToRoot"`
+	MoveToRoot bool    `json:"move_to_root"`
+	Kind       *string `json:"kind" binding:"omitempty,oneof=income expense both"`
 }
 
+// DeleteTransactionCategoryDto names the category that takes over the entries of the deleted
+// category and its descendants. Without it, deleting a category that is still in use is refused.
+type DeleteTransactionCategoryDto struct {
+	ReassignTo *int `form:"reassign_to"`
+}
+
+// MergeTransactionCategoryDto names the category another one is merged into.
+type MergeTransactionCategoryDto struct {
+	TargetID int `json:"target_id" binding:"required"`
+}
@@ -1,47 +1,130 @@
 package dto
 
+import "github.com/dimas-pramantya/money-management/internal/money"
+
 type TransactionDto struct {
 	ID         int     `json:"id"`
-	Ammount     int64 `json:"amount"`
-	CategoryID int     `json:"category_id"`
-	Category   string  `json:"category"`
-	SubCategoryID *int  `json:"sub_category_id"`
-	SubCategory   *string `json:"sub_category"`
+	Amount     money.Money `json:"amount"`
+	Currency    string  `json:"currency"`
+	AccountID   int     `json:"account_id"`
+	Account     string  `json:"account"`
+	CategoryID *int    `json:"category_id"`
+	Category   *string `json:"category"`
+	// CategoryPath names the category from its root down, e.g. "Food:Groceries".
+	CategoryPath *string `json:"category_path"`
 	TransactionDate string `json:"transaction_date"`
 	TransactionType string `json:"transaction_type"`
 	Notes        *string `json:"note"`
+	TransferID   *int    `json:"transfer_id"`
+	TransferDirection *string `json:"transfer_direction"`
+	RecurringTransactionID *int `json:"recurring_transaction_id"`
+	Tags        []TransactionTagDto `json:"tags"`
+	Splits      []TransactionSplitDto `json:"splits"`
+	// Rank and Snippet are only set when searching: how well the transaction matches the query and
+	// the matching text with the matched words wrapped in <b></b>.
+	Rank        *float64 `json:"rank,omitempty"`
+	Snippet     *string  `json:"snippet,omitempty"`
 	UserID      string  `json:"user_id"`
 	CreatedAt   string  `json:"created_at"`
 	UpdatedAt   *string `json:"updated_at"`
 	CreatedBy   string  `json:"created_by"`
 	UpdatedBy   *string `json:"updated_by"`
+	// DeletedAt is only set on transactions in the trash.
+	DeletedAt   *string `json:"deleted_at,omitempty"`
+}
+
+type TransactionSplitDto struct {
+	CategoryID    int     `json:"category_id"`
+	Category      string  `json:"category"`
+	CategoryPath  string  `json:"category_path"`
+	Amount        money.Money   `json:"amount"`
+	Notes         *string `json:"note"`
+}
+
+// GetDeletedTransactionParams pages through the trash.
+type GetDeletedTransactionParams struct {
+	Limit int `form:"limit"`
+	Page  int `form:"page"`
 }
 
 type GetTransactionParams struct {
+	AccountID     *int    `form:"account_id"`
+	// CategoryID keeps transactions in the category or in any category below it.
 	CategoryID    *int    `form:"category_id"`
+	// CategoryIDs keeps transactions in any of the categories, along with CategoryID.
+	CategoryIDs   []int   `form:"category_ids"`
+	// ExcludeCategoryIDs drops transactions in any of the categories or below them, including split
+	// ones with a split there.
+	ExcludeCategoryIDs []int `form:"exclude_category_ids"`
 	UserId 	 *string 	  `form:"user_id"`
-	SubCategoryID *int    `form:"sub_category_id"`
 	StartDate     *string `form:"start_date"`
 	EndDate       *string `form:"end_date"`
 	TransactionType *string `form:"transaction_type"`
+	// MinAmount and MaxAmount bound the amount, inclusive, in minor units of the transaction's currency.
+	MinAmount     *int64  `form:"min_amount" binding:"omitempty,min=0"`
+	MaxAmount     *int64  `form:"max_amount" binding:"omitempty,min=0"`
+	// NoteContains keeps transactions whose note contains the text, ignoring case.
+	NoteContains  *string `form:"note_contains"`
+	CreatedFrom   *string `form:"created_from" binding:"omitempty,datetime=2006-01-02"`
+	CreatedTo     *string `form:"created_to" binding:"omitempty,datetime=2006-01-02"`
+	UpdatedFrom   *string `form:"updated_from" binding:"omitempty,datetime=2006-01-02"`
+	UpdatedTo     *string `form:"updated_to" binding:"omitempty,datetime=2006-01-02"`
+	// Sort is a comma-separated list of field:asc|desc, see domain.ParseTransactionSort. Ties are
+	// always broken by id.
+	Sort          *string `form:"sort"`
+	// Query searches notes and category names, e.g. `coffee -starbucks` or `"grab food"`; results
+	// are ordered by relevance.
+	Query         *string `form:"q"`
+	// TagIDs keeps transactions carrying any of the tags, or all of them when TagMatch is "all".
+	TagIDs        []int   `form:"tag_ids"`
+	TagMatch      *string `form:"tag_match" binding:"omitempty,oneof=any all"`
 	Limit         int    `form:"limit"`
 	Page          int    `form:"page"`
+	// Cursor switches to cursor pagination: empty for the first page, then the NextCursor of the
+	// previous one. Page is ignored and the total only counted when WithCount is set.
+	Cursor        *string `form:"cursor"`
+	WithCount     bool    `form:"with_count"`
 }
 
 type CreateTransactionDto struct {
-	Amount          int64 `json:"amount" binding:"required"`
-	CategoryID      int   `json:"category_id" binding:"required"`
-	SubCategoryID   *int  `json:"sub_category_id"`
+	Amount          money.Money `json:"amount"`
+	AccountID       int   `json:"account_id" binding:"required"`
+	// CategoryID is required unless the transaction is split.
+	CategoryID      int   `json:"category_id"`
 	TransactionDate string `json:"transaction_date" binding:"required"`
 	TransactionType string `json:"transaction_type" binding:"required"`
 	Note            *string `json:"note"`
+	// Currency is optional and must match the account's currency when given.
+	Currency        *string `json:"currency"`
+	TagIDs          []int   `json:"tag_ids"`
+	// Splits spread the amount over several categories; their amounts must add up to Amount.
+	Splits          []CreateTransactionSplitDto `json:"splits"`
+
+	// Set by the recurring scheduler only; never bound from a request.
+	RecurringTransactionID *int    `json:"-"`
+	RecurringDate          *string `json:"-"`
+	// Set by statement imports only: the bank's id for the entry, e.g. the OFX FITID.
+	ExternalID             *string `json:"-"`
 }
 
 type UpdateTransactionDto struct {
-	Amount          int64 `json:"amount" binding:"required"`
-	CategoryID      int   `json:"category_id" binding:"required"`
-	SubCategoryID   *int  `json:"sub_category_id"`
+	Amount          money.Money `json:"amount"`
+	AccountID       int   `json:"account_id" binding:"required"`
+	// CategoryID is required unless the transaction is split.
+	CategoryID      int   `json:"category_id"`
 	TransactionDate string `json:"transaction_date" binding:"required"`
 	TransactionType string `json:"transaction_type" binding:"required"`
 	Note            *string `json:"note"`
+	// Currency is optional and must match the account's currency when given.
+	Currency        *string `json:"currency"`
+	// TagIDs replaces the transaction's tags; when omitted they are left as they are.
+	TagIDs          *[]int  `json:"tag_ids"`
+	// Splits replaces the transaction's splits; when omitted the transaction is no longer split.
+	Splits          []CreateTransactionSplitDto `json:"splits"`
+}
+
+type CreateTransactionSplitDto struct {
+	CategoryID    int     `json:"category_id" binding:"required"`
+	Amount        money.Money  `json:"amount"`
+	Note          *string `json:"note"`
 }
@@ -0,0 +1,49 @@
+package dto
+
+// TransactionFilterDto is what a saved view stores: the filter and sort query parameters of
+// GET /transactions, under the same names, plus Period for a date range relative to the day the
+// view runs.
+type TransactionFilterDto struct {
+	AccountID          *int  `json:"account_id"`
+	CategoryID         *int  `json:"category_id"`
+	CategoryIDs        []int `json:"category_ids"`
+	ExcludeCategoryIDs []int `json:"exclude_category_ids"`
+	// Period is this_week, this_month, last_month, this_year or last_30_days; StartDate and
+	// EndDate take precedence over it.
+	Period          *string `json:"period" binding:"omitempty,oneof=this_week this_month last_month this_year last_30_days"`
+	StartDate       *string `json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate         *string `json:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	TransactionType *string `json:"transaction_type" binding:"omitempty,oneof=income expense transfer"`
+	MinAmount       *int64  `json:"min_amount" binding:"omitempty,min=0"`
+	MaxAmount       *int64  `json:"max_amount" binding:"omitempty,min=0"`
+	NoteContains    *string `json:"note_contains"`
+	CreatedFrom     *string `json:"created_from" binding:"omitempty,datetime=2006-01-02"`
+	CreatedTo       *string `json:"created_to" binding:"omitempty,datetime=2006-01-02"`
+	UpdatedFrom     *string `json:"updated_from" binding:"omitempty,datetime=2006-01-02"`
+	UpdatedTo       *string `json:"updated_to" binding:"omitempty,datetime=2006-01-02"`
+	Query           *string `json:"q"`
+	TagIDs          []int   `json:"tag_ids"`
+	TagMatch        *string `json:"tag_match" binding:"omitempty,oneof=any all"`
+	Sort            *string `json:"sort"`
+}
+
+type TransactionViewDto struct {
+	ID        int                  `json:"id"`
+	Name      string               `json:"name"`
+	Filter    TransactionFilterDto `json:"filter"`
+	UserID    string               `json:"user_id"`
+	CreatedAt string               `json:"created_at"`
+	UpdatedAt *string              `json:"updated_at"`
+	CreatedBy string               `json:"created_by"`
+	UpdatedBy *string              `json:"updated_by"`
+}
+
+type CreateTransactionViewDto struct {
+	Name   string               `json:"name" binding:"required,max=100"`
+	Filter TransactionFilterDto `json:"filter"`
+}
+
+type UpdateTransactionViewDto struct {
+	Name   string               `json:"name" binding:"required,max=100"`
+	Filter TransactionFilterDto `json:"filter"`
+}
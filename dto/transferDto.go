@@ -0,0 +1,50 @@
+package dto
+
+import "github.com/dimas-pramantya/money-management/internal/money"
+
+type TransferDto struct {
+	ID               int         `json:"id"`
+	FromAccountID    int         `json:"from_account_id"`
+	ToAccountID      int         `json:"to_account_id"`
+	Amount           money.Money `json:"amount"`
+	Fee              money.Money `json:"fee"`
+	FeeCategoryID    *int        `json:"fee_category_id"`
+	TransferDate     string      `json:"transfer_date"`
+	Notes            *string     `json:"note"`
+	OutTransactionID int         `json:"out_transaction_id"`
+	InTransactionID  int         `json:"in_transaction_id"`
+	FeeTransactionID *int        `json:"fee_transaction_id"`
+	UserID           string      `json:"user_id"`
+	CreatedAt        string      `json:"created_at"`
+	UpdatedAt        *string     `json:"updated_at"`
+	CreatedBy        string      `json:"created_by"`
+	UpdatedBy        *string     `json:"updated_by"`
+	// DeletedAt is only set on transfers in the trash.
+	DeletedAt *string `json:"deleted_at,omitempty"`
+}
+
+// GetDeletedTransferParams pages through the transfers in the trash.
+type GetDeletedTransferParams struct {
+	Limit int `form:"limit"`
+	Page  int `form:"page"`
+}
+
+type CreateTransferDto struct {
+	FromAccountID int         `json:"from_account_id" binding:"required"`
+	ToAccountID   int         `json:"to_account_id" binding:"required"`
+	Amount        money.Money `json:"amount"`
+	Fee           money.Money `json:"fee"`
+	FeeCategoryID *int        `json:"fee_category_id"`
+	TransferDate  string      `json:"transfer_date" binding:"required"`
+	Note          *string     `json:"note"`
+}
+
+type UpdateTransferDto struct {
+	FromAccountID int         `json:"from_account_id" binding:"required"`
+	ToAccountID   int         `json:"to_account_id" binding:"required"`
+	Amount        money.Money `json:"amount"`
+	Fee           money.Money `json:"fee"`
+	FeeCategoryID *int        `json:"fee_category_id"`
+	TransferDate  string      `json:"transfer_date" binding:"required"`
+	Note          *string     `json:"note"`
+}
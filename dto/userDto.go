@@ -1,9 +1,15 @@
 package dto
 
+import "github.com/dimas-pramantya/money-management/internal/money"
+
 type RegisterDto struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`	
 	Email   string `json:"email" binding:"required,email"`
+	// BaseCurrency defaults to IDR.
+	BaseCurrency string `json:"base_currency" binding:"omitempty,len=3,alpha,uppercase"`
+	// Locale, e.g. "id" or "en-US", picks the language of the default categories.
+	Locale string `json:"locale" binding:"omitempty,max=35"`
 }
 
 type LoginDto struct {
@@ -15,7 +21,14 @@ type ResUserDto struct {
 	ID       string    `json:"id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
-	Balance  int64 `json:"balance"`
+	// Balance is the sum of the accounts held in BaseCurrency.
+	Balance  money.Money `json:"balance"`
+	BaseCurrency string `json:"base_currency"`
+	// TotalBalance is the sum of the accounts converted into BaseCurrency at the latest rates.
+	// Accounts in UnconvertedCurrencies have no rate yet and are left out of it.
+	TotalBalance money.Money `json:"total_balance"`
+	UnconvertedCurrencies []string `json:"unconverted_currencies,omitempty"`
+	Accounts []AccountDto `json:"accounts,omitempty"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt *string `json:"updated_at"`
 	CreatedBy string `json:"created_by"`
@@ -37,6 +50,13 @@ type ReqUpdateUserPasswordDto struct {
 	NewPassword string `json:"new_password" binding:"required"`
 }
 
+// ReqUpdateUserBalanceDto sets the balance of one account; the difference is recorded as an adjustment entry in the ledger.
 type ReqUpdateUserBalanceDto struct {
-	Balance int64 `json:"balance" binding:"required"`
-}
\ No newline at end of file
+	AccountID int `json:"account_id" binding:"required"`
+	Balance *money.Money `json:"balance" binding:"required"`
+	Note *string `json:"note"`
+}
+// ReqUpdateBaseCurrencyDto changes the currency reports and the profile total are converted into.
+type ReqUpdateBaseCurrencyDto struct {
+	BaseCurrency string `json:"base_currency" binding:"required,len=3,alpha,uppercase"`
+}
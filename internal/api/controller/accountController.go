@@ -0,0 +1,252 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AccountController struct {
+	AccountUC domain.AccountUseCase
+	validator *validation.Validator
+}
+
+func NewAccountController(accountUC domain.AccountUseCase, validator *validation.Validator) *AccountController {
+	return &AccountController{
+		AccountUC: accountUC,
+		validator: validator,
+	}
+}
+
+// CreateAccount godoc
+// @Summary     Create Account
+// @Description Create a new cash, bank, e-wallet or credit card account
+// @Tags        account
+// @Param       request body dto.CreateAccountDto true "Create Account Payload"
+// @Produce     json
+// @Success     201 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /accounts [POST]
+func (uc *AccountController) CreateAccount(ctx *gin.Context) {
+	var req dto.CreateAccountDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	account, err := uc.AccountUC.Create(req, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, dto.BaseResponse{
+		Message: "Account created successfully",
+		Data:    account,
+		Code:    201,
+	})
+}
+
+// UpdateAccount godoc
+// @Summary     Update Account
+// @Description Rename an account or change its type
+// @Tags        account
+// @Param       id   path int true "Account ID"
+// @Param       request body dto.UpdateAccountDto true "Update Account Payload"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /accounts/{id} [PUT]
+func (uc *AccountController) UpdateAccount(ctx *gin.Context) {
+	var req dto.UpdateAccountDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	account, err := uc.AccountUC.Update(req, idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Account updated successfully",
+		Data:    account,
+		Code:    200,
+	})
+}
+
+// DeleteAccount godoc
+// @Summary     Delete Account
+// @Description Delete an empty account with no transactions
+// @Tags        account
+// @Param       id path int true "Account ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /accounts/{id} [DELETE]
+func (uc *AccountController) DeleteAccount(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = uc.AccountUC.Delete(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Account deleted successfully",
+		Data:    nil,
+		Code:    200,
+	})
+}
+
+// GetAccountByID godoc
+// @Summary     Get Account by ID
+// @Description Get an account by ID
+// @Tags        account
+// @Param       id path int true "Account ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /accounts/{id} [GET]
+func (uc *AccountController) GetAccountByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	account, err := uc.AccountUC.FindByID(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Account retrieved successfully",
+		Data:    account,
+		Code:    200,
+	})
+}
+
+// GetAccountsByUserID godoc
+// @Summary     Get Accounts
+// @Description Get all accounts of the authenticated user
+// @Tags        account
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /accounts [GET]
+func (uc *AccountController) GetAccountsByUserID(ctx *gin.Context) {
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	accounts, err := uc.AccountUC.FindByUserID(userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Accounts retrieved successfully",
+		Data:    accounts,
+		Code:    200,
+	})
+}
+
+// GetAccountLedger godoc
+// @Summary     Get Account Ledger
+// @Description Get the ledger entries that explain an account balance, newest first
+// @Tags        account
+// @Param       id path int true "Account ID"
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Number of items per page"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /accounts/{id}/ledger [GET]
+func (uc *AccountController) GetAccountLedger(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	var params dto.GetLedgerParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	entries, err := uc.AccountUC.FindLedgerEntries(idInt, userUUID, params)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Ledger entries retrieved successfully",
+		Data:    entries,
+		Code:    200,
+	})
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminController struct {
+	ReconciliationUC domain.ReconciliationUseCase
+}
+
+func NewAdminController(reconciliationUC domain.ReconciliationUseCase) *AdminController {
+	return &AdminController{
+		ReconciliationUC: reconciliationUC,
+	}
+}
+
+// GetReconciliation godoc
+// @Summary     Check Balances
+// @Description Recompute balances from transaction history and report mismatches without changing anything
+// @Tags        admin
+// @Param       X-Admin-Key header string true "Admin API key"
+// @Param       user_id     query  string false "Only check this user"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     401 {object} domain.CustomError
+// @Router      /admin/reconcile [GET]
+func (uc *AdminController) GetReconciliation(ctx *gin.Context) {
+	uc.reconcile(ctx, false)
+}
+
+// FixReconciliation godoc
+// @Summary     Fix Balances
+// @Description Recompute balances from transaction history and correct every mismatch in a single transaction
+// @Tags        admin
+// @Param       X-Admin-Key header string true "Admin API key"
+// @Param       user_id     query  string false "Only fix this user"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     401 {object} domain.CustomError
+// @Router      /admin/reconcile [POST]
+func (uc *AdminController) FixReconciliation(ctx *gin.Context) {
+	uc.reconcile(ctx, true)
+}
+
+func (uc *AdminController) reconcile(ctx *gin.Context, fix bool) {
+	var params dto.ReconcileParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
+
+	var userID *uuid.UUID
+	if params.UserID != nil {
+		parsed, err := uuid.Parse(*params.UserID)
+		if err != nil {
+			ctx.Error(domain.BadRequestError("Invalid user_id", err.Error()))
+			return
+		}
+		userID = &parsed
+	}
+
+	report, err := uc.ReconciliationUC.Run(userID, fix)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	message := "Balances checked successfully"
+	if fix {
+		message = "Balances reconciled successfully"
+	}
+	ctx.JSON(200, dto.BaseResponse{
+		Message: message,
+		Data:    report,
+		Code:    200,
+	})
+}
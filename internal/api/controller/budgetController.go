@@ -0,0 +1,293 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BudgetController struct {
+	BudgetUC  domain.BudgetUseCase
+	validator *validation.Validator
+}
+
+func NewBudgetController(budgetUC domain.BudgetUseCase, validator *validation.Validator) *BudgetController {
+	return &BudgetController{
+		BudgetUC:  budgetUC,
+		validator: validator,
+	}
+}
+
+// CreateBudget godoc
+// @Summary     Create Budget
+// @Description Create a weekly, monthly or yearly budget for a category, including the categories below it. The amount is the target of each period, which is funded by moving allocations in from the unassigned income pool
+// @Tags        budget
+// @Param       request body dto.CreateBudgetDto true "Create Budget Payload"
+// @Produce     json
+// @Success     201 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /budgets [POST]
+func (uc *BudgetController) CreateBudget(ctx *gin.Context) {
+	var req dto.CreateBudgetDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	budget, err := uc.BudgetUC.Create(req, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, dto.BaseResponse{
+		Message: "Budget created successfully",
+		Data:    budget,
+		Code:    201,
+	})
+}
+
+// UpdateBudget godoc
+// @Summary     Update Budget
+// @Description Update the limit, category or period of a budget
+// @Tags        budget
+// @Param       id   path int true "Budget ID"
+// @Param       request body dto.UpdateBudgetDto true "Update Budget Payload"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /budgets/{id} [PUT]
+func (uc *BudgetController) UpdateBudget(ctx *gin.Context) {
+	var req dto.UpdateBudgetDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	budget, err := uc.BudgetUC.Update(req, idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Budget updated successfully",
+		Data:    budget,
+		Code:    200,
+	})
+}
+
+// DeleteBudget godoc
+// @Summary     Delete Budget
+// @Description Delete a budget
+// @Tags        budget
+// @Param       id path int true "Budget ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /budgets/{id} [DELETE]
+func (uc *BudgetController) DeleteBudget(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = uc.BudgetUC.Delete(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Budget deleted successfully",
+		Data:    nil,
+		Code:    200,
+	})
+}
+
+// GetBudgetByID godoc
+// @Summary     Get Budget by ID
+// @Description Get a budget with spent, remaining and percentage for the period containing date (default today)
+// @Tags        budget
+// @Param       id   path  int    true  "Budget ID"
+// @Param       date query string false "Any day of the period to report (YYYY-MM-DD)"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /budgets/{id} [GET]
+func (uc *BudgetController) GetBudgetByID(ctx *gin.Context) {
+	var params dto.GetBudgetParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	budget, err := uc.BudgetUC.FindByID(idInt, userUUID, params)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Budget retrieved successfully",
+		Data:    budget,
+		Code:    200,
+	})
+}
+
+// GetBudgetsByUserID godoc
+// @Summary     Get Budgets
+// @Description Get all budgets of the authenticated user with spent, remaining and percentage for the period containing date (default today)
+// @Tags        budget
+// @Param       date query string false "Any day of the period to report (YYYY-MM-DD)"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /budgets [GET]
+func (uc *BudgetController) GetBudgetsByUserID(ctx *gin.Context) {
+	var params dto.GetBudgetParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	budgets, err := uc.BudgetUC.FindByUserID(userUUID, params)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Budgets retrieved successfully",
+		Data:    budgets,
+		Code:    200,
+	})
+}
+
+// GetEnvelopes godoc
+// @Summary     Get Envelopes
+// @Description Get every budget as an envelope for the period containing date (default today), together with the unassigned income pool
+// @Tags        budget
+// @Param       date query string false "Any day of the period to report (YYYY-MM-DD)"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /budgets/envelopes [GET]
+func (uc *BudgetController) GetEnvelopes(ctx *gin.Context) {
+	var params dto.GetBudgetParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	envelopes, err := uc.BudgetUC.FindEnvelopes(userUUID, params)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Envelopes retrieved successfully",
+		Data:    envelopes,
+		Code:    200,
+	})
+}
+
+// MoveAllocation godoc
+// @Summary     Move Allocation
+// @Description Move money between two envelopes, or between an envelope and the unassigned income pool, for the period containing date
+// @Tags        budget
+// @Param       request body dto.MoveAllocationDto true "Move Allocation Payload"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /budgets/allocations [POST]
+func (uc *BudgetController) MoveAllocation(ctx *gin.Context) {
+	var req dto.MoveAllocationDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	envelopes, err := uc.BudgetUC.MoveAllocation(req, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Allocation moved successfully",
+		Data:    envelopes,
+		Code:    200,
+	})
+}
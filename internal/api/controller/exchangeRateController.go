@@ -0,0 +1,257 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ExchangeRateController struct {
+	ExchangeRateUC domain.ExchangeRateUseCase
+	validator      *validation.Validator
+}
+
+func NewExchangeRateController(exchangeRateUC domain.ExchangeRateUseCase, validator *validation.Validator) *ExchangeRateController {
+	return &ExchangeRateController{
+		ExchangeRateUC: exchangeRateUC,
+		validator:      validator,
+	}
+}
+
+// CreateExchangeRate godoc
+// @Summary     Create Exchange Rate
+// @Description Record how many units of to_currency one unit of from_currency is worth from effective_date on. A rate already recorded for the pair on that date is replaced.
+// @Tags        exchange-rate
+// @Param       request body dto.CreateExchangeRateDto true "Create Exchange Rate Payload"
+// @Produce     json
+// @Success     201 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /exchange-rates [POST]
+func (uc *ExchangeRateController) CreateExchangeRate(ctx *gin.Context) {
+	var req dto.CreateExchangeRateDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	rate, err := uc.ExchangeRateUC.Create(req, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, dto.BaseResponse{
+		Message: "Exchange rate saved successfully",
+		Data:    rate,
+		Code:    201,
+	})
+}
+
+// ImportExchangeRates godoc
+// @Summary     Import Exchange Rates
+// @Description Record every rate of a CSV file with effective_date, from_currency, to_currency and rate columns. Nothing is recorded if any row is invalid.
+// @Tags        exchange-rate
+// @Accept      multipart/form-data
+// @Param       file formData file true "CSV file"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /exchange-rates/import [POST]
+func (uc *ExchangeRateController) ImportExchangeRates(ctx *gin.Context) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.Error(domain.BadRequestError("file is required", err.Error()))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Failed to read file", err.Error()))
+		return
+	}
+	defer file.Close()
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	result, err := uc.ExchangeRateUC.ImportCSV(file, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Exchange rates imported successfully",
+		Data:    result,
+		Code:    200,
+	})
+}
+
+// UpdateExchangeRate godoc
+// @Summary     Update Exchange Rate
+// @Description Update the pair, rate or effective date of an exchange rate
+// @Tags        exchange-rate
+// @Param       id   path int true "Exchange Rate ID"
+// @Param       request body dto.UpdateExchangeRateDto true "Update Exchange Rate Payload"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /exchange-rates/{id} [PUT]
+func (uc *ExchangeRateController) UpdateExchangeRate(ctx *gin.Context) {
+	var req dto.UpdateExchangeRateDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	rate, err := uc.ExchangeRateUC.Update(req, idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Exchange rate updated successfully",
+		Data:    rate,
+		Code:    200,
+	})
+}
+
+// DeleteExchangeRate godoc
+// @Summary     Delete Exchange Rate
+// @Description Delete an exchange rate
+// @Tags        exchange-rate
+// @Param       id path int true "Exchange Rate ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /exchange-rates/{id} [DELETE]
+func (uc *ExchangeRateController) DeleteExchangeRate(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = uc.ExchangeRateUC.Delete(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Exchange rate deleted successfully",
+		Data:    nil,
+		Code:    200,
+	})
+}
+
+// GetExchangeRateByID godoc
+// @Summary     Get Exchange Rate by ID
+// @Description Get an exchange rate of the authenticated user
+// @Tags        exchange-rate
+// @Param       id path int true "Exchange Rate ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /exchange-rates/{id} [GET]
+func (uc *ExchangeRateController) GetExchangeRateByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	rate, err := uc.ExchangeRateUC.FindByID(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Exchange rate retrieved successfully",
+		Data:    rate,
+		Code:    200,
+	})
+}
+
+// GetExchangeRatesByUserID godoc
+// @Summary     Get Exchange Rates
+// @Description Get the exchange rates of the authenticated user, newest first per currency pair
+// @Tags        exchange-rate
+// @Param       currency query string false "Only pairs involving this currency"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /exchange-rates [GET]
+func (uc *ExchangeRateController) GetExchangeRatesByUserID(ctx *gin.Context) {
+	var params dto.GetExchangeRateParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	rates, err := uc.ExchangeRateUC.FindByUserID(params, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Exchange rates retrieved successfully",
+		Data:    rates,
+		Code:    200,
+	})
+}
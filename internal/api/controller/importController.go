@@ -0,0 +1,290 @@
+package controller
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ImportController struct {
+	ImportUC  domain.ImportUseCase
+	validator *validation.Validator
+}
+
+func NewImportController(importUC domain.ImportUseCase, validator *validation.Validator) *ImportController {
+	return &ImportController{
+		ImportUC:  importUC,
+		validator: validator,
+	}
+}
+
+// CreateImportProfile godoc
+// @Summary     Create Import Profile
+// @Description Save how a bank lays out its CSV statements: which columns hold the date, amount, type, note and category
+// @Tags        import
+// @Param       request body dto.CreateImportProfileDto true "Create Import Profile Payload"
+// @Produce     json
+// @Success     201 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /import-profiles [POST]
+func (uc *ImportController) CreateImportProfile(ctx *gin.Context) {
+	var req dto.CreateImportProfileDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	profile, err := uc.ImportUC.CreateProfile(req, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, dto.BaseResponse{
+		Message: "Import profile created successfully",
+		Data:    profile,
+		Code:    201,
+	})
+}
+
+// UpdateImportProfile godoc
+// @Summary     Update Import Profile
+// @Description Update a CSV column mapping profile
+// @Tags        import
+// @Param       id   path int true "Import profile ID"
+// @Param       request body dto.UpdateImportProfileDto true "Update Import Profile Payload"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /import-profiles/{id} [PUT]
+func (uc *ImportController) UpdateImportProfile(ctx *gin.Context) {
+	var req dto.UpdateImportProfileDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	profile, err := uc.ImportUC.UpdateProfile(req, idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Import profile updated successfully",
+		Data:    profile,
+		Code:    200,
+	})
+}
+
+// DeleteImportProfile godoc
+// @Summary     Delete Import Profile
+// @Description Delete a CSV column mapping profile
+// @Tags        import
+// @Param       id path int true "Import profile ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /import-profiles/{id} [DELETE]
+func (uc *ImportController) DeleteImportProfile(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = uc.ImportUC.DeleteProfile(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Import profile deleted successfully",
+		Data:    nil,
+		Code:    200,
+	})
+}
+
+// GetImportProfileByID godoc
+// @Summary     Get Import Profile by ID
+// @Description Get a CSV column mapping profile by ID
+// @Tags        import
+// @Param       id path int true "Import profile ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /import-profiles/{id} [GET]
+func (uc *ImportController) GetImportProfileByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	profile, err := uc.ImportUC.FindProfileByID(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Import profile retrieved successfully",
+		Data:    profile,
+		Code:    200,
+	})
+}
+
+// GetImportProfilesByUserID godoc
+// @Summary     Get Import Profiles
+// @Description Get all CSV column mapping profiles of the authenticated user
+// @Tags        import
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /import-profiles [GET]
+func (uc *ImportController) GetImportProfilesByUserID(ctx *gin.Context) {
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	profiles, err := uc.ImportUC.FindProfilesByUserID(userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Import profiles retrieved successfully",
+		Data:    profiles,
+		Code:    200,
+	})
+}
+
+// ImportTransactions godoc
+// @Summary     Import Transactions
+// @Description Import a CSV, OFX or QIF bank statement into an account. CSV files need a mapping profile. OFX entries whose FITID was already imported into the account are skipped. Without commit=true only a preview is returned; every row is checked with the same rules as creating a transaction. A commit records all rows in a single transaction, or only the valid ones with skip_invalid=true.
+// @Tags        import
+// @Accept      multipart/form-data
+// @Param       file         formData file true  "Statement file"
+// @Param       format       formData string false "csv, ofx or qif; defaults to the file extension"
+// @Param       profile_id   formData int  false "Import profile ID, required for CSV"
+// @Param       account_id   formData int  true  "Account to import into"
+// @Param       category_id  formData int  false "Category for OFX and QIF entries without a known category"
+// @Param       commit       formData bool false "Record the rows instead of previewing them"
+// @Param       skip_invalid formData bool false "Commit the valid rows even if some rows are invalid"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transactions/import [POST]
+func (uc *ImportController) ImportTransactions(ctx *gin.Context) {
+	var req dto.ImportTransactionsDto
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid request", err.Error()))
+		return
+	}
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.Error(domain.BadRequestError("file is required", err.Error()))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Failed to read file", err.Error()))
+		return
+	}
+	defer file.Close()
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var report *dto.ImportReportDto
+	switch importFormat(req.Format, fileHeader.Filename) {
+	case "ofx":
+		report, err = uc.ImportUC.ImportOFX(file, req, userUUID)
+	case "qif":
+		report, err = uc.ImportUC.ImportQIF(file, req, userUUID)
+	default:
+		report, err = uc.ImportUC.ImportCSV(file, req, userUUID)
+	}
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	message := "Import previewed successfully"
+	if report.Committed {
+		message = "Transactions imported successfully"
+	}
+	ctx.JSON(200, dto.BaseResponse{
+		Message: message,
+		Data:    report,
+		Code:    200,
+	})
+}
+
+// importFormat returns the requested format, or guesses it from the file extension.
+func importFormat(format string, filename string) string {
+	if format != "" {
+		return format
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".ofx", ".qfx":
+		return "ofx"
+	case ".qif":
+		return "qif"
+	}
+	return "csv"
+}
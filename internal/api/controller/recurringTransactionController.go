@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RecurringTransactionController struct {
+	RecurringTransactionUC domain.RecurringTransactionUseCase
+	validator              *validation.Validator
+}
+
+func NewRecurringTransactionController(recurringTransactionUC domain.RecurringTransactionUseCase, validator *validation.Validator) *RecurringTransactionController {
+	return &RecurringTransactionController{
+		RecurringTransactionUC: recurringTransactionUC,
+		validator:              validator,
+	}
+}
+
+// CreateRecurringTransaction godoc
+// @Summary     Create Recurring Transaction
+// @Description Create a rule that posts a transaction automatically on every occurrence (daily, weekly, monthly on a day, yearly, every N units)
+// @Tags        recurring-transaction
+// @Param       request body dto.CreateRecurringTransactionDto true "Create Recurring Transaction Payload"
+// @Produce     json
+// @Success     201 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /recurring-transactions [POST]
+func (uc *RecurringTransactionController) CreateRecurringTransaction(ctx *gin.Context) {
+	var req dto.CreateRecurringTransactionDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	recurring, err := uc.RecurringTransactionUC.Create(req, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, dto.BaseResponse{
+		Message: "Recurring transaction created successfully",
+		Data:    recurring,
+		Code:    201,
+	})
+}
+
+// UpdateRecurringTransaction godoc
+// @Summary     Update Recurring Transaction
+// @Description Update the template and schedule of a recurring transaction; occurrences already posted are kept
+// @Tags        recurring-transaction
+// @Param       id   path int true "Recurring transaction ID"
+// @Param       request body dto.UpdateRecurringTransactionDto true "Update Recurring Transaction Payload"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /recurring-transactions/{id} [PUT]
+func (uc *RecurringTransactionController) UpdateRecurringTransaction(ctx *gin.Context) {
+	var req dto.UpdateRecurringTransactionDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	recurring, err := uc.RecurringTransactionUC.Update(req, idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Recurring transaction updated successfully",
+		Data:    recurring,
+		Code:    200,
+	})
+}
+
+// DeleteRecurringTransaction godoc
+// @Summary     Delete Recurring Transaction
+// @Description Stop a recurring transaction; transactions it already posted are kept
+// @Tags        recurring-transaction
+// @Param       id path int true "Recurring transaction ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /recurring-transactions/{id} [DELETE]
+func (uc *RecurringTransactionController) DeleteRecurringTransaction(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = uc.RecurringTransactionUC.Delete(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Recurring transaction deleted successfully",
+		Data:    nil,
+		Code:    200,
+	})
+}
+
+// GetRecurringTransactionByID godoc
+// @Summary     Get Recurring Transaction by ID
+// @Description Get a recurring transaction by ID
+// @Tags        recurring-transaction
+// @Param       id path int true "Recurring transaction ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /recurring-transactions/{id} [GET]
+func (uc *RecurringTransactionController) GetRecurringTransactionByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	recurring, err := uc.RecurringTransactionUC.FindByID(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Recurring transaction retrieved successfully",
+		Data:    recurring,
+		Code:    200,
+	})
+}
+
+// GetRecurringTransactionsByUserID godoc
+// @Summary     Get Recurring Transactions
+// @Description Get all recurring transactions of the authenticated user
+// @Tags        recurring-transaction
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /recurring-transactions [GET]
+func (uc *RecurringTransactionController) GetRecurringTransactionsByUserID(ctx *gin.Context) {
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	recurrings, err := uc.RecurringTransactionUC.FindByUserID(userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Recurring transactions retrieved successfully",
+		Data:    recurrings,
+		Code:    200,
+	})
+}
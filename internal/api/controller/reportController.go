@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReportController struct {
+	ReportUC domain.ReportUseCase
+}
+
+func NewReportController(reportUC domain.ReportUseCase) *ReportController {
+	return &ReportController{
+		ReportUC: reportUC,
+	}
+}
+
+// GetSummary godoc
+// @Summary     Income vs Expense Summary
+// @Description Total income, expense and net over a date range, per day, week, month or year bucket and per category, rolled up through the category tree. Transfers are not counted.
+// @Tags        report
+// @Param       start_date query string true  "Start date (YYYY-MM-DD)"
+// @Param       end_date   query string true  "End date (YYYY-MM-DD)"
+// @Param       bucket     query string false "day, week, month (default) or year"
+// @Param       account_id query int    false "Only count transactions of this account"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /reports/summary [GET]
+func (uc *ReportController) GetSummary(ctx *gin.Context) {
+	var params dto.GetReportSummaryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	summary, err := uc.ReportUC.Summary(params, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Summary retrieved successfully",
+		Data:    summary,
+		Code:    200,
+	})
+}
+
+// GetByTag godoc
+// @Summary     Income vs Expense by Tag
+// @Description Total income, expense and net per tag over a date range. A transaction with several tags counts under each of them. Transfers are not counted.
+// @Tags        report
+// @Param       start_date query string true  "Start date (YYYY-MM-DD)"
+// @Param       end_date   query string true  "End date (YYYY-MM-DD)"
+// @Param       account_id query int    false "Only count transactions of this account"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /reports/tags [GET]
+func (uc *ReportController) GetByTag(ctx *gin.Context) {
+	var params dto.GetReportByTagParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	report, err := uc.ReportUC.ByTag(params, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Tag report retrieved successfully",
+		Data:    report,
+		Code:    200,
+	})
+}
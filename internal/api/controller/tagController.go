@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TagController struct {
+	TagUC     domain.TagUseCase
+	validator *validation.Validator
+}
+
+func NewTagController(tagUC domain.TagUseCase, validator *validation.Validator) *TagController {
+	return &TagController{
+		TagUC:     tagUC,
+		validator: validator,
+	}
+}
+
+// CreateTag godoc
+// @Summary     Create Tag
+// @Description Create a tag. Names are unique per user regardless of case.
+// @Tags        tag
+// @Param       request body dto.CreateTagDto true "Create Tag Payload"
+// @Produce     json
+// @Success     201 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /tags [POST]
+func (uc *TagController) CreateTag(ctx *gin.Context) {
+	var req dto.CreateTagDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	tag, err := uc.TagUC.Create(req, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, dto.BaseResponse{
+		Message: "Tag created successfully",
+		Data:    tag,
+		Code:    201,
+	})
+}
+
+// UpdateTag godoc
+// @Summary     Update Tag
+// @Description Rename a tag
+// @Tags        tag
+// @Param       id   path int true "Tag ID"
+// @Param       request body dto.UpdateTagDto true "Update Tag Payload"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /tags/{id} [PUT]
+func (uc *TagController) UpdateTag(ctx *gin.Context) {
+	var req dto.UpdateTagDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	tag, err := uc.TagUC.Update(req, idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Tag updated successfully",
+		Data:    tag,
+		Code:    200,
+	})
+}
+
+// DeleteTag godoc
+// @Summary     Delete Tag
+// @Description Delete a tag and remove it from every transaction carrying it
+// @Tags        tag
+// @Param       id path int true "Tag ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /tags/{id} [DELETE]
+func (uc *TagController) DeleteTag(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = uc.TagUC.Delete(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Tag deleted successfully",
+		Data:    nil,
+		Code:    200,
+	})
+}
+
+// GetTagByID godoc
+// @Summary     Get Tag by ID
+// @Description Get a tag of the authenticated user
+// @Tags        tag
+// @Param       id path int true "Tag ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /tags/{id} [GET]
+func (uc *TagController) GetTagByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	tag, err := uc.TagUC.FindByID(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Tag retrieved successfully",
+		Data:    tag,
+		Code:    200,
+	})
+}
+
+// GetTagsByUserID godoc
+// @Summary     Get Tags
+// @Description Get the tags of the authenticated user, ordered by name
+// @Tags        tag
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /tags [GET]
+func (uc *TagController) GetTagsByUserID(ctx *gin.Context) {
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	tags, err := uc.TagUC.FindByUserID(userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Tags retrieved successfully",
+		Data:    tags,
+		Code:    200,
+	})
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"fmt"
+	"strconv"
 
 	"github.com/dimas-pramantya/money-management/dto"
 	"github.com/dimas-pramantya/money-management/internal/domain"
@@ -10,14 +12,16 @@ import (
 )
 
 type TransactionController struct {
-	TransactionUseCase domain.TransactionUseCase
-	validator          *validation.Validator
+	TransactionUseCase     domain.TransactionUseCase
+	TransactionViewUseCase domain.TransactionViewUseCase
+	validator              *validation.Validator
 }
 
-func NewTransactionController(transactionUseCase domain.TransactionUseCase, validator *validation.Validator) *TransactionController {
+func NewTransactionController(transactionUseCase domain.TransactionUseCase, transactionViewUseCase domain.TransactionViewUseCase, validator *validation.Validator) *TransactionController {
 	return &TransactionController{
-		TransactionUseCase: transactionUseCase,
-		validator:          validator,
+		TransactionUseCase:     transactionUseCase,
+		TransactionViewUseCase: transactionViewUseCase,
+		validator:              validator,
 	}
 }
 
@@ -61,36 +65,62 @@ func (uc *TransactionController) CreateTransaction(ctx *gin.Context) {
 
 // GetTransactionPaginated godoc
 // @Summary     Get Transaction Paginated
-// @Description Get transactions with pagination
+// @Description Get transactions with pagination. Passing cursor (empty for the first page) switches from page numbers to cursor pagination on (transaction_date, id), which returns nextCursor instead of page numbers and only counts the total with with_count=true.
 // @Tags        transaction
 // @Param       page query int false "Page number"
 // @Param       limit query int false "Number of items per page"
-// @Param       category_id query int false "Category ID"
-// @Param       user_id query string false "User ID"
-// @Param       sub_category_id query int false "Sub Category ID"
+// @Param       view query int false "Saved transaction view to run; other query parameters override its filter"
+// @Param       cursor query string false "Cursor from the previous page's nextCursor; empty for the first page"
+// @Param       with_count query bool false "Count the total records in cursor mode"
+// @Param       account_id query int false "Account ID"
+// @Param       category_id query int false "Category ID; categories below it are included"
+// @Param       category_ids query []int false "Category IDs, repeated; keeps transactions in any of them" collectionFormat(multi)
+// @Param       exclude_category_ids query []int false "Category IDs, repeated; drops transactions in any of them" collectionFormat(multi)
 // @Param       start_date query string false "Start date (YYYY-MM-DD)"
 // @Param       end_date query string false "End date (YYYY-MM-DD)"
-// @Param       transaction_type query string false "Transaction type (income/expense)"
+// @Param       transaction_type query string false "Transaction type (income/expense/transfer)"
+// @Param       min_amount query int false "Minimum amount in minor units, inclusive"
+// @Param       max_amount query int false "Maximum amount in minor units, inclusive"
+// @Param       note_contains query string false "Text the note contains, ignoring case"
+// @Param       created_from query string false "Created on or after (YYYY-MM-DD)"
+// @Param       created_to query string false "Created on or before (YYYY-MM-DD)"
+// @Param       updated_from query string false "Updated on or after (YYYY-MM-DD)"
+// @Param       updated_to query string false "Updated on or before (YYYY-MM-DD)"
+// @Param       q query string false "Search notes and category names; supports quoted phrases, OR and -word. Results are ordered by relevance and carry a snippet"
+// @Param       tag_ids query []int false "Tag IDs, repeated (tag_ids=1&tag_ids=2)" collectionFormat(multi)
+// @Param       tag_match query string false "any (default) keeps transactions with one of the tags, all those with every tag"
+// @Param       sort query string false "Comma-separated field:asc|desc; fields are transaction_date (default), amount, created_at, updated_at, account, category and relevance (default when searching)"
 // @Produce     json
 // @Success     200 {object} dto.BaseResponse
 // @Failure     400 {object} domain.CustomError
 // @Security    BearerAuth
 // @Router      /transactions [GET]
 func (uc *TransactionController) GetTransactionPaginated(ctx *gin.Context) {
-	var req = dto.GetTransactionParams{
-		CategoryID:   nil,
-		UserId:	 nil,
-		SubCategoryID: nil,
-		StartDate:  nil,
-		EndDate:    nil,
-		TransactionType: nil,
-		Limit:	1,
-		Page: 	10,
+	req, err := uc.bindTransactionParams(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Page <= 0 {
+		req.Page = 1
 	}
 
-	userIDStr := ctx.MustGet("user_id").(string)
-
-	req.UserId = &userIDStr
+	if req.Cursor != nil {
+		transactions, err := uc.TransactionUseCase.FindByCursor(req)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.JSON(200, dto.BaseResponse{
+			Message: "Transactions retrieved successfully",
+			Data:    transactions,
+			Code:    200,
+		})
+		return
+	}
 
 	transactions, err := uc.TransactionUseCase.FindByFilter(req)
 	if err != nil {
@@ -103,4 +133,296 @@ func (uc *TransactionController) GetTransactionPaginated(ctx *gin.Context) {
 		Data:    transactions,
 		Code:    200,
 	})
-}
\ No newline at end of file
+}
+
+// ExportTransactions godoc
+// @Summary     Export Transactions
+// @Description Download every transaction matching the same filters as GET /transactions, ignoring paging. The ledger, hledger and beancount formats also declare the user's accounts and categories (as Expenses:Food:Groceries style accounts) and include opening balances and adjustments. Amounts are written in the currency of their account.
+// @Tags        transaction
+// @Param       format query string true "Export format (csv, ledger, hledger or beancount)"
+// @Param       view query int false "Saved transaction view to run; other query parameters override its filter"
+// @Param       account_id query int false "Account ID"
+// @Param       category_id query int false "Category ID; categories below it are included"
+// @Param       category_ids query []int false "Category IDs, repeated; keeps transactions in any of them" collectionFormat(multi)
+// @Param       exclude_category_ids query []int false "Category IDs, repeated; drops transactions in any of them" collectionFormat(multi)
+// @Param       start_date query string false "Start date (YYYY-MM-DD)"
+// @Param       end_date query string false "End date (YYYY-MM-DD)"
+// @Param       transaction_type query string false "Transaction type (income/expense/transfer)"
+// @Param       min_amount query int false "Minimum amount in minor units, inclusive"
+// @Param       max_amount query int false "Maximum amount in minor units, inclusive"
+// @Param       note_contains query string false "Text the note contains, ignoring case"
+// @Param       created_from query string false "Created on or after (YYYY-MM-DD)"
+// @Param       created_to query string false "Created on or before (YYYY-MM-DD)"
+// @Param       updated_from query string false "Updated on or after (YYYY-MM-DD)"
+// @Param       updated_to query string false "Updated on or before (YYYY-MM-DD)"
+// @Param       q query string false "Search notes and category names; supports quoted phrases, OR and -word. Results are ordered by relevance and carry a snippet"
+// @Param       tag_ids query []int false "Tag IDs, repeated (tag_ids=1&tag_ids=2)" collectionFormat(multi)
+// @Param       tag_match query string false "any (default) keeps transactions with one of the tags, all those with every tag"
+// @Produce     text/csv
+// @Produce     text/plain
+// @Success     200 {file} file
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transactions/export [GET]
+func (uc *TransactionController) ExportTransactions(ctx *gin.Context) {
+	req, err := uc.bindTransactionParams(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	format := ctx.Query("format")
+	switch format {
+	case domain.ExportFormatCSV:
+		ctx.Header("Content-Type", "text/csv")
+		ctx.Header("Content-Disposition", `attachment; filename="transactions.csv"`)
+		ctx.Status(200)
+		err = uc.TransactionUseCase.ExportCSV(req, ctx.Writer)
+	case domain.ExportFormatLedger, domain.ExportFormatHledger, domain.ExportFormatBeancount:
+		extension := map[string]string{
+			domain.ExportFormatLedger:    "ledger",
+			domain.ExportFormatHledger:   "journal",
+			domain.ExportFormatBeancount: "beancount",
+		}[format]
+		ctx.Header("Content-Type", "text/plain; charset=utf-8")
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="transactions.%s"`, extension))
+		err = uc.TransactionUseCase.ExportPlainText(req, format, ctx.Writer)
+	default:
+		ctx.Error(domain.BadRequestError("Unsupported export format, expected csv, ledger, hledger or beancount", nil))
+		return
+	}
+	if err != nil {
+		if !ctx.Writer.Written() {
+			ctx.Error(err)
+			return
+		}
+		// The header is already sent, so the client can only notice the truncated body.
+		fmt.Println("Error exporting transactions:", err)
+		ctx.Abort()
+	}
+}
+
+// bindTransactionParams reads the transaction filter of a request. With view set it starts from
+// that saved view and only the parameters given in the query replace its own.
+func (uc *TransactionController) bindTransactionParams(ctx *gin.Context) (dto.GetTransactionParams, error) {
+	var req dto.GetTransactionParams
+	if view := ctx.Query("view"); view != "" {
+		viewID, err := strconv.Atoi(view)
+		if err != nil {
+			return req, domain.BadRequestError("Invalid view ID", err)
+		}
+		userUUID, err := uuid.Parse(ctx.MustGet("user_id").(string))
+		if err != nil {
+			return req, err
+		}
+		req, err = uc.TransactionViewUseCase.FindParams(viewID, userUUID)
+		if err != nil {
+			return req, err
+		}
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		return req, domain.BadRequestError("Invalid query parameters", err.Error())
+	}
+	if req.Sort != nil && *req.Sort != "" {
+		if _, err := domain.ParseTransactionSort(*req.Sort); err != nil {
+			return req, err
+		}
+	}
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		return req, domain.BadRequestError("min_amount cannot be greater than max_amount", nil)
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	req.UserId = &userIDStr
+	return req, nil
+}
+
+// GetTransactionByID godoc
+// @Summary     Get Transaction by ID
+// @Description Get a transaction owned by the authenticated user
+// @Tags        transaction
+// @Param       id path int true "Transaction ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transactions/{id} [GET]
+func (uc *TransactionController) GetTransactionByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	transaction, err := uc.TransactionUseCase.FindByID(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transaction retrieved successfully",
+		Data:    transaction,
+		Code:    200,
+	})
+}
+
+// UpdateTransaction godoc
+// @Summary     Update Transaction
+// @Description Update a transaction and correct the user balance
+// @Tags        transaction
+// @Param       id   path int true "Transaction ID"
+// @Param       request body dto.UpdateTransactionDto true "Update Transaction Payload"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transactions/{id} [PUT]
+func (uc *TransactionController) UpdateTransaction(ctx *gin.Context) {
+	var req dto.UpdateTransactionDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	transaction, err := uc.TransactionUseCase.Update(req, idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transaction updated successfully",
+		Data:    transaction,
+		Code:    200,
+	})
+}
+
+// DeleteTransaction godoc
+// @Summary     Delete Transaction
+// @Description Move a transaction to the trash and reverse its effect on the user balance
+// @Tags        transaction
+// @Param       id path int true "Transaction ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transactions/{id} [DELETE]
+func (uc *TransactionController) DeleteTransaction(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = uc.TransactionUseCase.Delete(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transaction deleted successfully",
+		Data:    nil,
+		Code:    200,
+	})
+}
+
+// GetDeletedTransactions godoc
+// @Summary     Get Deleted Transactions
+// @Description Get the transactions in the trash, most recently deleted first. They are purged for good after the retention period
+// @Tags        transaction
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Number of items per page"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transactions/trash [GET]
+func (uc *TransactionController) GetDeletedTransactions(ctx *gin.Context) {
+	var params dto.GetDeletedTransactionParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	transactions, err := uc.TransactionUseCase.FindDeleted(userUUID, params)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Deleted transactions retrieved successfully",
+		Data:    transactions,
+		Code:    200,
+	})
+}
+
+// RestoreTransaction godoc
+// @Summary     Restore Transaction
+// @Description Take a transaction out of the trash and apply it to the user balance again
+// @Tags        transaction
+// @Param       id path int true "Transaction ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transactions/{id}/restore [POST]
+func (uc *TransactionController) RestoreTransaction(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	transaction, err := uc.TransactionUseCase.Restore(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transaction restored successfully",
+		Data:    transaction,
+		Code:    200,
+	})
+}
@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TransactionViewController struct {
+	TransactionViewUC domain.TransactionViewUseCase
+	validator         *validation.Validator
+}
+
+func NewTransactionViewController(transactionViewUC domain.TransactionViewUseCase, validator *validation.Validator) *TransactionViewController {
+	return &TransactionViewController{
+		TransactionViewUC: transactionViewUC,
+		validator:         validator,
+	}
+}
+
+// CreateTransactionView godoc
+// @Summary     Create Transaction View
+// @Description Save a named transaction filter, run with GET /transactions?view={id}. Names are unique per user regardless of case.
+// @Tags        transaction-view
+// @Param       request body dto.CreateTransactionViewDto true "Create Transaction View Payload"
+// @Produce     json
+// @Success     201 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transaction-views [POST]
+func (uc *TransactionViewController) CreateTransactionView(ctx *gin.Context) {
+	var req dto.CreateTransactionViewDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	view, err := uc.TransactionViewUC.Create(req, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, dto.BaseResponse{
+		Message: "Transaction view created successfully",
+		Data:    view,
+		Code:    201,
+	})
+}
+
+// UpdateTransactionView godoc
+// @Summary     Update Transaction View
+// @Description Rename a transaction view or replace its filter
+// @Tags        transaction-view
+// @Param       id   path int true "Transaction View ID"
+// @Param       request body dto.UpdateTransactionViewDto true "Update Transaction View Payload"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transaction-views/{id} [PUT]
+func (uc *TransactionViewController) UpdateTransactionView(ctx *gin.Context) {
+	var req dto.UpdateTransactionViewDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	view, err := uc.TransactionViewUC.Update(req, idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transaction view updated successfully",
+		Data:    view,
+		Code:    200,
+	})
+}
+
+// DeleteTransactionView godoc
+// @Summary     Delete Transaction View
+// @Description Delete a transaction view
+// @Tags        transaction-view
+// @Param       id path int true "Transaction View ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transaction-views/{id} [DELETE]
+func (uc *TransactionViewController) DeleteTransactionView(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = uc.TransactionViewUC.Delete(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transaction view deleted successfully",
+		Data:    nil,
+		Code:    200,
+	})
+}
+
+// GetTransactionViewByID godoc
+// @Summary     Get Transaction View by ID
+// @Description Get a transaction view of the authenticated user
+// @Tags        transaction-view
+// @Param       id path int true "Transaction View ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transaction-views/{id} [GET]
+func (uc *TransactionViewController) GetTransactionViewByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	view, err := uc.TransactionViewUC.FindByID(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transaction view retrieved successfully",
+		Data:    view,
+		Code:    200,
+	})
+}
+
+// GetTransactionViewsByUserID godoc
+// @Summary     Get Transaction Views
+// @Description Get the transaction views of the authenticated user, ordered by name
+// @Tags        transaction-view
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transaction-views [GET]
+func (uc *TransactionViewController) GetTransactionViewsByUserID(ctx *gin.Context) {
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	views, err := uc.TransactionViewUC.FindByUserID(userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transaction views retrieved successfully",
+		Data:    views,
+		Code:    200,
+	})
+}
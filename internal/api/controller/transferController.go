@@ -0,0 +1,282 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TransferController struct {
+	TransferUC domain.TransferUseCase
+	validator  *validation.Validator
+}
+
+func NewTransferController(transferUC domain.TransferUseCase, validator *validation.Validator) *TransferController {
+	return &TransferController{
+		TransferUC: transferUC,
+		validator:  validator,
+	}
+}
+
+// CreateTransfer godoc
+// @Summary     Create Transfer
+// @Description Move money between two accounts, optionally charging a fee recorded as a linked expense
+// @Tags        transfer
+// @Param       request body dto.CreateTransferDto true "Create Transfer Payload"
+// @Produce     json
+// @Success     201 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transfers [POST]
+func (uc *TransferController) CreateTransfer(ctx *gin.Context) {
+	var req dto.CreateTransferDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	transfer, err := uc.TransferUC.Create(req, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, dto.BaseResponse{
+		Message: "Transfer created successfully",
+		Data:    transfer,
+		Code:    201,
+	})
+}
+
+// UpdateTransfer godoc
+// @Summary     Update Transfer
+// @Description Update both legs and the fee of a transfer
+// @Tags        transfer
+// @Param       id   path int true "Transfer ID"
+// @Param       request body dto.UpdateTransferDto true "Update Transfer Payload"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transfers/{id} [PUT]
+func (uc *TransferController) UpdateTransfer(ctx *gin.Context) {
+	var req dto.UpdateTransferDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	transfer, err := uc.TransferUC.Update(req, idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transfer updated successfully",
+		Data:    transfer,
+		Code:    200,
+	})
+}
+
+// DeleteTransfer godoc
+// @Summary     Delete Transfer
+// @Description Move a transfer with all of its legs to the trash and reverse the balances
+// @Tags        transfer
+// @Param       id path int true "Transfer ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transfers/{id} [DELETE]
+func (uc *TransferController) DeleteTransfer(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = uc.TransferUC.Delete(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transfer deleted successfully",
+		Data:    nil,
+		Code:    200,
+	})
+}
+
+// GetTransferByID godoc
+// @Summary     Get Transfer by ID
+// @Description Get a transfer by ID
+// @Tags        transfer
+// @Param       id path int true "Transfer ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transfers/{id} [GET]
+func (uc *TransferController) GetTransferByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	transfer, err := uc.TransferUC.FindByID(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transfer retrieved successfully",
+		Data:    transfer,
+		Code:    200,
+	})
+}
+
+// GetTransfersByUserID godoc
+// @Summary     Get Transfers
+// @Description Get all transfers of the authenticated user
+// @Tags        transfer
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transfers [GET]
+func (uc *TransferController) GetTransfersByUserID(ctx *gin.Context) {
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	transfers, err := uc.TransferUC.FindByUserID(userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transfers retrieved successfully",
+		Data:    transfers,
+		Code:    200,
+	})
+}
+
+// GetDeletedTransfers godoc
+// @Summary     Get Deleted Transfers
+// @Description Get the transfers in the trash, most recently deleted first. They are purged for good after the retention period
+// @Tags        transfer
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Number of items per page"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transfers/trash [GET]
+func (uc *TransferController) GetDeletedTransfers(ctx *gin.Context) {
+	var params dto.GetDeletedTransferParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	transfers, err := uc.TransferUC.FindDeleted(userUUID, params)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Deleted transfers retrieved successfully",
+		Data:    transfers,
+		Code:    200,
+	})
+}
+
+// RestoreTransfer godoc
+// @Summary     Restore Transfer
+// @Description Take a transfer with all of its legs out of the trash and apply it to the balances again
+// @Tags        transfer
+// @Param       id path int true "Transfer ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transfers/{id}/restore [POST]
+func (uc *TransferController) RestoreTransfer(ctx *gin.Context) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	transfer, err := uc.TransferUC.Restore(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transfer restored successfully",
+		Data:    transfer,
+		Code:    200,
+	})
+}
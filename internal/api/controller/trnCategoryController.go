@@ -24,7 +24,7 @@ func NewTransactionCategoryController(trnCategoryUC domain.TransactionCategoryUs
 
 // CreateTransactionCategory godoc
 // @Summary     Create Transaction Category
-// @Description Create a new transaction category
+// @Description Create a new transaction category; kind (income, expense or both) limits which transactions it takes and defaults to both
 // @Tags        category
 // @Param       request body dto.CreateTransactionCategoryDto true "Create Transaction Category Payload"
 // @Produce     json
@@ -61,7 +61,7 @@ func (uc *TransactionCategoryController) CreateTransactionCategory(ctx *gin.Cont
 
 // UpdateTransactionCategory godoc
 // @Summary     Update Transaction Category
-// @Description Update an existing transaction category
+// @Description Rename a transaction category and move it under parent_id, or to the top level with move_to_root. Without either it keeps its parent. A kind that would exclude transactions already filed under it is rejected
 // @Tags        category
 // @Param       id   path int true "Transaction Category ID"
 // @Param       request body dto.UpdateTransactionCategoryDto true "Update Transaction Category Payload"
@@ -105,9 +105,10 @@ func (uc *TransactionCategoryController) UpdateTransactionCategory(ctx *gin.Cont
 
 // DeleteTransactionCategory godoc
 // @Summary     Delete Transaction Category
-// @Description Delete a transaction category
+// @Description Delete a transaction category and the categories below it. While transactions or budgets are filed under any of them the delete is refused, unless reassign_to names a category to move them to. Moving budgets onto a category that already has one for the same period is refused
 // @Tags        category
 // @Param       id path int true "Transaction Category ID"
+// @Param       reassign_to query int false "Category that takes over the transactions"
 // @Produce     json
 // @Success     200 {object} dto.BaseResponse
 // @Failure     400 {object} domain.CustomError
@@ -120,6 +121,11 @@ func (uc *TransactionCategoryController) DeleteTransactionCategory(ctx *gin.Cont
 		ctx.Error(domain.BadRequestError("Invalid ID", err))
 		return
 	}
+	var req dto.DeleteTransactionCategoryDto
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
 	userIDStr := ctx.MustGet("user_id").(string)
 	userUUID, err := uuid.Parse(userIDStr)
 	if err != nil {
@@ -127,7 +133,7 @@ func (uc *TransactionCategoryController) DeleteTransactionCategory(ctx *gin.Cont
 		return
 	}
 
-	err = uc.TrnCategoryUC.Delete(idInt, userUUID)
+	err = uc.TrnCategoryUC.Delete(idInt, req, userUUID)
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -140,6 +146,105 @@ func (uc *TransactionCategoryController) DeleteTransactionCategory(ctx *gin.Cont
 	})
 }
 
+// MergeTransactionCategory godoc
+// @Summary     Merge Transaction Category
+// @Description Merge a transaction category into target_id: its transactions, budgets and child categories move to the target and the category is deleted. Refused when the target already has a budget for the same period
+// @Tags        category
+// @Param       id   path int true "Transaction Category ID"
+// @Param       request body dto.MergeTransactionCategoryDto true "Merge Transaction Category Payload"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transaction-categories/{id}/merge [POST]
+func (uc *TransactionCategoryController) MergeTransactionCategory(ctx *gin.Context) {
+	var req dto.MergeTransactionCategoryDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	category, err := uc.TrnCategoryUC.Merge(idInt, req, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transaction categories merged successfully",
+		Data:    category,
+		Code:    200,
+	})
+}
+
+// ArchiveTransactionCategory godoc
+// @Summary     Archive Transaction Category
+// @Description Hide a transaction category and the categories below it from listings, keeping their transactions
+// @Tags        category
+// @Param       id path int true "Transaction Category ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transaction-categories/{id}/archive [POST]
+func (uc *TransactionCategoryController) ArchiveTransactionCategory(ctx *gin.Context) {
+	uc.setArchived(ctx, uc.TrnCategoryUC.Archive, "Transaction category archived successfully")
+}
+
+// UnarchiveTransactionCategory godoc
+// @Summary     Unarchive Transaction Category
+// @Description List an archived transaction category and the categories below it again
+// @Tags        category
+// @Param       id path int true "Transaction Category ID"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transaction-categories/{id}/unarchive [POST]
+func (uc *TransactionCategoryController) UnarchiveTransactionCategory(ctx *gin.Context) {
+	uc.setArchived(ctx, uc.TrnCategoryUC.Unarchive, "Transaction category unarchived successfully")
+}
+
+func (uc *TransactionCategoryController) setArchived(ctx *gin.Context, set func(int, uuid.UUID) (*dto.TransactionCategoryDto, error), message string) {
+	id := ctx.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		ctx.Error(domain.BadRequestError("Invalid ID", err))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	category, err := set(idInt, userUUID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: message,
+		Data:    category,
+		Code:    200,
+	})
+}
+
 // GetTransactionCategoryByID godoc
 // @Summary     Get Transaction Category by ID
 // @Description Get a transaction category by ID
@@ -181,14 +286,20 @@ func (uc *TransactionCategoryController) GetTransactionCategoryByID(ctx *gin.Con
 
 // GetTransactionCategoriesByUserID godoc
 // @Summary     Get Transaction Categories by User ID
-// @Description Get all transaction categories for a user
+// @Description Get all transaction categories for a user; archived ones only with include_archived=true
 // @Tags        category
+// @Param       include_archived query bool false "Include archived categories"
 // @Produce     json
 // @Success     200 {object} dto.BaseResponse
 // @Failure     400 {object} domain.CustomError
 // @Security    BearerAuth
 // @Router      /transaction-categories [GET]
 func (uc *TransactionCategoryController) GetTransactionCategoriesByUserID(ctx *gin.Context) {
+	var params dto.GetTransactionCategoryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
 	userIDStr := ctx.MustGet("user_id").(string)
 	userUUID, err := uuid.Parse(userIDStr)
 	if err != nil {
@@ -196,7 +307,7 @@ func (uc *TransactionCategoryController) GetTransactionCategoriesByUserID(ctx *g
 		return
 	}
 
-	categories, err := uc.TrnCategoryUC.FindByUserID(userUUID)
+	categories, err := uc.TrnCategoryUC.FindByUserID(userUUID, params)
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -207,4 +318,39 @@ func (uc *TransactionCategoryController) GetTransactionCategoriesByUserID(ctx *g
 		Data:    categories,
 		Code:    200,
 	})
-}
\ No newline at end of file
+}
+// GetTransactionCategoryTree godoc
+// @Summary     Get Transaction Category Tree
+// @Description Get the user's root categories with their descendants nested under them; archived ones only with include_archived=true
+// @Tags        category
+// @Param       include_archived query bool false "Include archived categories"
+// @Produce     json
+// @Success     200 {object} dto.BaseResponse
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /transaction-categories/tree [GET]
+func (uc *TransactionCategoryController) GetTransactionCategoryTree(ctx *gin.Context) {
+	var params dto.GetTransactionCategoryParams
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(domain.BadRequestError("Invalid query parameters", err.Error()))
+		return
+	}
+	userIDStr := ctx.MustGet("user_id").(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	tree, err := uc.TrnCategoryUC.FindTree(userUUID, params)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.BaseResponse{
+		Message: "Transaction category tree retrieved successfully",
+		Data:    tree,
+		Code:    200,
+	})
+}
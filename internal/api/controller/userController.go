@@ -23,7 +23,7 @@ func NewUserController(userUC domain.UserUsecase, validator *validation.Validato
 
 // Register godoc
 // @Summary     Register
-// @Description Register new user
+// @Description Register new user with the default categories, named for the optional locale
 // @Tags        auth
 // @Param       request body dto.RegisterDto true "Register Payload"
 // @Produce     json
@@ -115,7 +115,7 @@ func (uc *UserController) GetUserProfile(ctx *gin.Context) {
 
 // UpdateUserBalance godoc
 // @Summary     Update User Balance
-// @Description Update user balance
+// @Description Set the balance of one of the user's accounts; the difference is recorded as an adjustment ledger entry
 // @Tags        users
 // @Param       request body dto.ReqUpdateUserBalanceDto true "Update User Balance Payload"
 // @Produce     json
@@ -150,4 +150,42 @@ func (uc *UserController) UpdateUserBalance(ctx *gin.Context) {
 		Data:    user,
 		Code:    http.StatusOK,
 	})
-}
\ No newline at end of file
+}
+// UpdateBaseCurrency godoc
+// @Summary     Update Base Currency
+// @Description Set the currency reports and the profile total are converted into
+// @Tags        users
+// @Param       request body dto.ReqUpdateBaseCurrencyDto true "Update Base Currency Payload"
+// @Produce     json
+// @Success     200 {object} dto.ResUserDto
+// @Failure     400 {object} domain.CustomError
+// @Security    BearerAuth
+// @Router      /users/base-currency [patch]
+func (uc *UserController) UpdateBaseCurrency(ctx *gin.Context) {
+	userID, _ := ctx.Get("user_id")
+	if userID == nil {
+		err := domain.UnauthorizedError("Unauthorized", nil)
+		ctx.Error(err)
+		return
+	}
+
+	var req dto.ReqUpdateBaseCurrencyDto
+	err := uc.validator.ValidateRequest(ctx, &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userIDStr, _ := userID.(string)
+	user, err := uc.UserUC.UpdateBaseCurrency(userIDStr, req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.BaseResponse{
+		Message: "Base currency updated successfully",
+		Data:    user,
+		Code:    http.StatusOK,
+	})
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	. "github.com/dimas-pramantya/money-management/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// AdminMiddleware only lets through requests whose X-Admin-Key header matches ADMIN_API_KEY.
+// Admin routes stay closed when no key is configured.
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		adminKey := viper.GetString("ADMIN_API_KEY")
+		key := c.GetHeader("X-Admin-Key")
+		if adminKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(adminKey)) != 1 {
+			c.Error(UnauthorizedError("Unauthorized", nil))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,33 @@
+package router
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/api/controller"
+	"github.com/dimas-pramantya/money-management/internal/api/middleware"
+	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
+	"github.com/dimas-pramantya/money-management/internal/service"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+)
+
+func InitAccountRouter(rg *gin.RouterGroup, db *sql.DB, validator *validation.Validator) {
+	// Repositories
+	accountRepo := pgrepository.NewAccountPgRepository(db)
+	userRepo := pgrepository.NewUserPgRepository(db)
+	ledgerRepo := pgrepository.NewLedgerPgRepository(db)
+
+	// Usecases
+	accountUC := service.NewAccountService(accountRepo, userRepo, ledgerRepo, db)
+
+	// Controllers
+	accountCtrl := controller.NewAccountController(accountUC, validator)
+
+	// Routes
+	rg.POST("", middleware.JwtMiddleware(), accountCtrl.CreateAccount)
+	rg.GET("", middleware.JwtMiddleware(), accountCtrl.GetAccountsByUserID)
+	rg.GET("/:id", middleware.JwtMiddleware(), accountCtrl.GetAccountByID)
+	rg.PUT("/:id", middleware.JwtMiddleware(), accountCtrl.UpdateAccount)
+	rg.DELETE("/:id", middleware.JwtMiddleware(), accountCtrl.DeleteAccount)
+	rg.GET("/:id/ledger", middleware.JwtMiddleware(), accountCtrl.GetAccountLedger)
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/api/controller"
+	"github.com/dimas-pramantya/money-management/internal/api/middleware"
+	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
+	"github.com/dimas-pramantya/money-management/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+func InitAdminRouter(rg *gin.RouterGroup, db *sql.DB) {
+	// Repositories
+	reconciliationRepo := pgrepository.NewReconciliationPgRepository(db)
+	userRepo := pgrepository.NewUserPgRepository(db)
+	accountRepo := pgrepository.NewAccountPgRepository(db)
+	ledgerRepo := pgrepository.NewLedgerPgRepository(db)
+
+	// Usecases
+	reconciliationUC := service.NewReconciliationService(reconciliationRepo, userRepo, accountRepo, ledgerRepo, db)
+
+	// Controllers
+	adminCtrl := controller.NewAdminController(reconciliationUC)
+
+	// Routes
+	rg.GET("/reconcile", middleware.AdminMiddleware(), adminCtrl.GetReconciliation)
+	rg.POST("/reconcile", middleware.AdminMiddleware(), adminCtrl.FixReconciliation)
+}
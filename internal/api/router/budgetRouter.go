@@ -0,0 +1,35 @@
+package router
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/api/controller"
+	"github.com/dimas-pramantya/money-management/internal/api/middleware"
+	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
+	"github.com/dimas-pramantya/money-management/internal/service"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+)
+
+func InitBudgetRouter(rg *gin.RouterGroup, db *sql.DB, validator *validation.Validator) {
+	// Repositories
+	budgetRepo := pgrepository.NewBudgetPgRepository(db)
+	transactionCategoryRepo := pgrepository.NewTransactionCategoryPgRepository(db)
+	userRepo := pgrepository.NewUserPgRepository(db)
+	reportRepo := pgrepository.NewReportPgRepository(db)
+
+	// Usecases
+	budgetUC := service.NewBudgetService(budgetRepo, transactionCategoryRepo, userRepo, reportRepo, db)
+
+	// Controllers
+	budgetCtrl := controller.NewBudgetController(budgetUC, validator)
+
+	// Routes
+	rg.POST("", middleware.JwtMiddleware(), budgetCtrl.CreateBudget)
+	rg.GET("", middleware.JwtMiddleware(), budgetCtrl.GetBudgetsByUserID)
+	rg.GET("/envelopes", middleware.JwtMiddleware(), budgetCtrl.GetEnvelopes)
+	rg.POST("/allocations", middleware.JwtMiddleware(), budgetCtrl.MoveAllocation)
+	rg.GET("/:id", middleware.JwtMiddleware(), budgetCtrl.GetBudgetByID)
+	rg.PUT("/:id", middleware.JwtMiddleware(), budgetCtrl.UpdateBudget)
+	rg.DELETE("/:id", middleware.JwtMiddleware(), budgetCtrl.DeleteBudget)
+}
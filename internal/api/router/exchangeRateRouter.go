@@ -0,0 +1,31 @@
+package router
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/api/controller"
+	"github.com/dimas-pramantya/money-management/internal/api/middleware"
+	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
+	"github.com/dimas-pramantya/money-management/internal/service"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+)
+
+func InitExchangeRateRouter(rg *gin.RouterGroup, db *sql.DB, validator *validation.Validator) {
+	// Repositories
+	exchangeRateRepo := pgrepository.NewExchangeRatePgRepository(db)
+
+	// Usecases
+	exchangeRateUC := service.NewExchangeRateService(exchangeRateRepo, db)
+
+	// Controllers
+	exchangeRateCtrl := controller.NewExchangeRateController(exchangeRateUC, validator)
+
+	// Routes
+	rg.POST("", middleware.JwtMiddleware(), exchangeRateCtrl.CreateExchangeRate)
+	rg.POST("/import", middleware.JwtMiddleware(), exchangeRateCtrl.ImportExchangeRates)
+	rg.GET("", middleware.JwtMiddleware(), exchangeRateCtrl.GetExchangeRatesByUserID)
+	rg.GET("/:id", middleware.JwtMiddleware(), exchangeRateCtrl.GetExchangeRateByID)
+	rg.PUT("/:id", middleware.JwtMiddleware(), exchangeRateCtrl.UpdateExchangeRate)
+	rg.DELETE("/:id", middleware.JwtMiddleware(), exchangeRateCtrl.DeleteExchangeRate)
+}
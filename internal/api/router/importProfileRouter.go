@@ -0,0 +1,39 @@
+package router
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/api/controller"
+	"github.com/dimas-pramantya/money-management/internal/api/middleware"
+	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
+	"github.com/dimas-pramantya/money-management/internal/service"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+)
+
+func InitImportProfileRouter(rg *gin.RouterGroup, db *sql.DB, validator *validation.Validator) {
+	// Repositories
+	importProfileRepo := pgrepository.NewImportProfilePgRepository(db)
+	transactionCategoryRepo := pgrepository.NewTransactionCategoryPgRepository(db)
+	transactionRepo := pgrepository.NewTransactionRepo(db)
+	userRepo := pgrepository.NewUserPgRepository(db)
+	accountRepo := pgrepository.NewAccountPgRepository(db)
+	transferRepo := pgrepository.NewTransferPgRepository(db)
+	ledgerRepo := pgrepository.NewLedgerPgRepository(db)
+	tagRepo := pgrepository.NewTagPgRepository(db)
+
+	// Usecases
+	transferUC := service.NewTransferService(transferRepo, transactionRepo, transactionCategoryRepo, userRepo, accountRepo, ledgerRepo, db)
+	transactionUC := service.NewTransactionService(transactionRepo, transactionCategoryRepo, userRepo, accountRepo, tagRepo, transferUC, ledgerRepo, db)
+	importUC := service.NewImportService(importProfileRepo, transactionRepo, transactionCategoryRepo, accountRepo, transactionUC)
+
+	// Controllers
+	importCtrl := controller.NewImportController(importUC, validator)
+
+	// Routes
+	rg.POST("", middleware.JwtMiddleware(), importCtrl.CreateImportProfile)
+	rg.GET("", middleware.JwtMiddleware(), importCtrl.GetImportProfilesByUserID)
+	rg.GET("/:id", middleware.JwtMiddleware(), importCtrl.GetImportProfileByID)
+	rg.PUT("/:id", middleware.JwtMiddleware(), importCtrl.UpdateImportProfile)
+	rg.DELETE("/:id", middleware.JwtMiddleware(), importCtrl.DeleteImportProfile)
+}
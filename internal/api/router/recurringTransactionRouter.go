@@ -0,0 +1,39 @@
+package router
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/api/controller"
+	"github.com/dimas-pramantya/money-management/internal/api/middleware"
+	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
+	"github.com/dimas-pramantya/money-management/internal/service"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+)
+
+func InitRecurringTransactionRouter(rg *gin.RouterGroup, db *sql.DB, validator *validation.Validator) {
+	// Repositories
+	recurringTransactionRepo := pgrepository.NewRecurringTransactionPgRepository(db)
+	transactionCategoryRepo := pgrepository.NewTransactionCategoryPgRepository(db)
+	transactionRepo := pgrepository.NewTransactionRepo(db)
+	userRepo := pgrepository.NewUserPgRepository(db)
+	accountRepo := pgrepository.NewAccountPgRepository(db)
+	transferRepo := pgrepository.NewTransferPgRepository(db)
+	ledgerRepo := pgrepository.NewLedgerPgRepository(db)
+	tagRepo := pgrepository.NewTagPgRepository(db)
+
+	// Usecases
+	transferUC := service.NewTransferService(transferRepo, transactionRepo, transactionCategoryRepo, userRepo, accountRepo, ledgerRepo, db)
+	transactionUC := service.NewTransactionService(transactionRepo, transactionCategoryRepo, userRepo, accountRepo, tagRepo, transferUC, ledgerRepo, db)
+	recurringTransactionUC := service.NewRecurringTransactionService(recurringTransactionRepo, transactionRepo, transactionCategoryRepo, accountRepo, transactionUC)
+
+	// Controllers
+	recurringTransactionCtrl := controller.NewRecurringTransactionController(recurringTransactionUC, validator)
+
+	// Routes
+	rg.POST("", middleware.JwtMiddleware(), recurringTransactionCtrl.CreateRecurringTransaction)
+	rg.GET("", middleware.JwtMiddleware(), recurringTransactionCtrl.GetRecurringTransactionsByUserID)
+	rg.GET("/:id", middleware.JwtMiddleware(), recurringTransactionCtrl.GetRecurringTransactionByID)
+	rg.PUT("/:id", middleware.JwtMiddleware(), recurringTransactionCtrl.UpdateRecurringTransaction)
+	rg.DELETE("/:id", middleware.JwtMiddleware(), recurringTransactionCtrl.DeleteRecurringTransaction)
+}
@@ -0,0 +1,27 @@
+package router
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/api/controller"
+	"github.com/dimas-pramantya/money-management/internal/api/middleware"
+	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
+	"github.com/dimas-pramantya/money-management/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+func InitReportRouter(rg *gin.RouterGroup, db *sql.DB) {
+	// Repositories
+	reportRepo := pgrepository.NewReportPgRepository(db)
+	userRepo := pgrepository.NewUserPgRepository(db)
+
+	// Usecases
+	reportUC := service.NewReportService(reportRepo, userRepo)
+
+	// Controllers
+	reportCtrl := controller.NewReportController(reportUC)
+
+	// Routes
+	rg.GET("/summary", middleware.JwtMiddleware(), reportCtrl.GetSummary)
+	rg.GET("/tags", middleware.JwtMiddleware(), reportCtrl.GetByTag)
+}
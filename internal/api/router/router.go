@@ -17,6 +17,36 @@ func Init(r *gin.Engine, db *sql.DB) {
 	transactionCategoryRoute := api.Group("/transaction-categories")
 	InitCategoryRouter(transactionCategoryRoute, db, validator)
 
+	accountRoute := api.Group("/accounts")
+	InitAccountRouter(accountRoute, db, validator)
+
+	transferRoute := api.Group("/transfers")
+	InitTransferRouter(transferRoute, db, validator)
+
+	importProfileRoute := api.Group("/import-profiles")
+	InitImportProfileRouter(importProfileRoute, db, validator)
+
+	tagRoute := api.Group("/tags")
+	InitTagRouter(tagRoute, db, validator)
+
+	transactionViewRoute := api.Group("/transaction-views")
+	InitTransactionViewRouter(transactionViewRoute, db, validator)
+
 	transactionRoute := api.Group("/transactions")
 	InitTransactionRouter(transactionRoute, db, validator)
-}
\ No newline at end of file
+
+	recurringTransactionRoute := api.Group("/recurring-transactions")
+	InitRecurringTransactionRouter(recurringTransactionRoute, db, validator)
+
+	exchangeRateRoute := api.Group("/exchange-rates")
+	InitExchangeRateRouter(exchangeRateRoute, db, validator)
+
+	budgetRoute := api.Group("/budgets")
+	InitBudgetRouter(budgetRoute, db, validator)
+
+	reportRoute := api.Group("/reports")
+	InitReportRouter(reportRoute, db)
+
+	adminRoute := api.Group("/admin")
+	InitAdminRouter(adminRoute, db)
+}
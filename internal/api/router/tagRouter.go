@@ -0,0 +1,30 @@
+package router
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/api/controller"
+	"github.com/dimas-pramantya/money-management/internal/api/middleware"
+	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
+	"github.com/dimas-pramantya/money-management/internal/service"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+)
+
+func InitTagRouter(rg *gin.RouterGroup, db *sql.DB, validator *validation.Validator) {
+	// Repositories
+	tagRepo := pgrepository.NewTagPgRepository(db)
+
+	// Usecases
+	tagUC := service.NewTagService(tagRepo)
+
+	// Controllers
+	tagCtrl := controller.NewTagController(tagUC, validator)
+
+	// Routes
+	rg.POST("", middleware.JwtMiddleware(), tagCtrl.CreateTag)
+	rg.GET("", middleware.JwtMiddleware(), tagCtrl.GetTagsByUserID)
+	rg.GET("/:id", middleware.JwtMiddleware(), tagCtrl.GetTagByID)
+	rg.PUT("/:id", middleware.JwtMiddleware(), tagCtrl.UpdateTag)
+	rg.DELETE("/:id", middleware.JwtMiddleware(), tagCtrl.DeleteTag)
+}
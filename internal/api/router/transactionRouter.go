@@ -14,17 +14,33 @@ import (
 func InitTransactionRouter(rg *gin.RouterGroup, db *sql.DB, validator *validation.Validator) {
 	// Repositories
 	transactionCategoryRepo := pgrepository.NewTransactionCategoryPgRepository(db)
-	transactionSubCategoryRepo := pgrepository.NewTransactionSubCategoryPgRepository(db)
 	transactionRepo := pgrepository.NewTransactionRepo(db)
 	userRepo := pgrepository.NewUserPgRepository(db)
+	accountRepo := pgrepository.NewAccountPgRepository(db)
+	transferRepo := pgrepository.NewTransferPgRepository(db)
+	ledgerRepo := pgrepository.NewLedgerPgRepository(db)
+	tagRepo := pgrepository.NewTagPgRepository(db)
+	importProfileRepo := pgrepository.NewImportProfilePgRepository(db)
+	transactionViewRepo := pgrepository.NewTransactionViewPgRepository(db)
 
 	// Usecases
-	transactionUseCase := service.NewTransactionService(transactionRepo, transactionCategoryRepo, transactionSubCategoryRepo, userRepo, db)
+	transferUseCase := service.NewTransferService(transferRepo, transactionRepo, transactionCategoryRepo, userRepo, accountRepo, ledgerRepo, db)
+	transactionUseCase := service.NewTransactionService(transactionRepo, transactionCategoryRepo, userRepo, accountRepo, tagRepo, transferUseCase, ledgerRepo, db)
+	importUseCase := service.NewImportService(importProfileRepo, transactionRepo, transactionCategoryRepo, accountRepo, transactionUseCase)
+	transactionViewUseCase := service.NewTransactionViewService(transactionViewRepo)
 
 	// Controllers
-	transactionController := controller.NewTransactionController(transactionUseCase, validator)
+	transactionController := controller.NewTransactionController(transactionUseCase, transactionViewUseCase, validator)
+	importController := controller.NewImportController(importUseCase, validator)
 
 	// Routes
 	rg.POST("", middleware.JwtMiddleware(), transactionController.CreateTransaction)
 	rg.GET("", middleware.JwtMiddleware(), transactionController.GetTransactionPaginated)
+	rg.GET("/export", middleware.JwtMiddleware(), transactionController.ExportTransactions)
+	rg.GET("/trash", middleware.JwtMiddleware(), transactionController.GetDeletedTransactions)
+	rg.POST("/import", middleware.JwtMiddleware(), importController.ImportTransactions)
+	rg.GET("/:id", middleware.JwtMiddleware(), transactionController.GetTransactionByID)
+	rg.PUT("/:id", middleware.JwtMiddleware(), transactionController.UpdateTransaction)
+	rg.DELETE("/:id", middleware.JwtMiddleware(), transactionController.DeleteTransaction)
+	rg.POST("/:id/restore", middleware.JwtMiddleware(), transactionController.RestoreTransaction)
 }
\ No newline at end of file
@@ -0,0 +1,30 @@
+package router
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/api/controller"
+	"github.com/dimas-pramantya/money-management/internal/api/middleware"
+	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
+	"github.com/dimas-pramantya/money-management/internal/service"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+)
+
+func InitTransactionViewRouter(rg *gin.RouterGroup, db *sql.DB, validator *validation.Validator) {
+	// Repositories
+	transactionViewRepo := pgrepository.NewTransactionViewPgRepository(db)
+
+	// Usecases
+	transactionViewUC := service.NewTransactionViewService(transactionViewRepo)
+
+	// Controllers
+	transactionViewCtrl := controller.NewTransactionViewController(transactionViewUC, validator)
+
+	// Routes
+	rg.POST("", middleware.JwtMiddleware(), transactionViewCtrl.CreateTransactionView)
+	rg.GET("", middleware.JwtMiddleware(), transactionViewCtrl.GetTransactionViewsByUserID)
+	rg.GET("/:id", middleware.JwtMiddleware(), transactionViewCtrl.GetTransactionViewByID)
+	rg.PUT("/:id", middleware.JwtMiddleware(), transactionViewCtrl.UpdateTransactionView)
+	rg.DELETE("/:id", middleware.JwtMiddleware(), transactionViewCtrl.DeleteTransactionView)
+}
@@ -0,0 +1,37 @@
+package router
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/api/controller"
+	"github.com/dimas-pramantya/money-management/internal/api/middleware"
+	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
+	"github.com/dimas-pramantya/money-management/internal/service"
+	"github.com/dimas-pramantya/money-management/utils/validation"
+	"github.com/gin-gonic/gin"
+)
+
+func InitTransferRouter(rg *gin.RouterGroup, db *sql.DB, validator *validation.Validator) {
+	// Repositories
+	transferRepo := pgrepository.NewTransferPgRepository(db)
+	transactionRepo := pgrepository.NewTransactionRepo(db)
+	transactionCategoryRepo := pgrepository.NewTransactionCategoryPgRepository(db)
+	userRepo := pgrepository.NewUserPgRepository(db)
+	accountRepo := pgrepository.NewAccountPgRepository(db)
+	ledgerRepo := pgrepository.NewLedgerPgRepository(db)
+
+	// Usecases
+	transferUC := service.NewTransferService(transferRepo, transactionRepo, transactionCategoryRepo, userRepo, accountRepo, ledgerRepo, db)
+
+	// Controllers
+	transferCtrl := controller.NewTransferController(transferUC, validator)
+
+	// Routes
+	rg.POST("", middleware.JwtMiddleware(), transferCtrl.CreateTransfer)
+	rg.GET("", middleware.JwtMiddleware(), transferCtrl.GetTransfersByUserID)
+	rg.GET("/trash", middleware.JwtMiddleware(), transferCtrl.GetDeletedTransfers)
+	rg.GET("/:id", middleware.JwtMiddleware(), transferCtrl.GetTransferByID)
+	rg.PUT("/:id", middleware.JwtMiddleware(), transferCtrl.UpdateTransfer)
+	rg.DELETE("/:id", middleware.JwtMiddleware(), transferCtrl.DeleteTransfer)
+	rg.POST("/:id/restore", middleware.JwtMiddleware(), transferCtrl.RestoreTransfer)
+}
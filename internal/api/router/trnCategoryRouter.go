@@ -14,27 +14,26 @@ import (
 func InitCategoryRouter(rg *gin.RouterGroup, db *sql.DB, validator *validation.Validator) {
 	// Repositories
 	transactionCategoryRepo := pgrepository.NewTransactionCategoryPgRepository(db)
-	transactionSubCategoryRepo := pgrepository.NewTransactionSubCategoryPgRepository(db)
+	userRepo := pgrepository.NewUserPgRepository(db)
+	accountRepo := pgrepository.NewAccountPgRepository(db)
+	ledgerRepo := pgrepository.NewLedgerPgRepository(db)
 
 	// Usecases
-	transactionCategoryUC := service.NewTransactionCategoryService(transactionCategoryRepo)
-	transactionSubCategoryUC := service.NewTransactionSubCategoryService(transactionSubCategoryRepo)
+	transactionCategoryUC := service.NewTransactionCategoryService(transactionCategoryRepo, userRepo, accountRepo, ledgerRepo, db)
 
 	// Controllers
 	transactionCategoryCtrl := controller.NewTransactionCategoryController(transactionCategoryUC, validator)
-	transactionSubCategoryCtrl := controller.NewTransactionSubCategoryController(transactionSubCategoryUC, transactionCategoryUC, validator)
 
 	// Routes
 	rg.POST("", middleware.JwtMiddleware(), transactionCategoryCtrl.CreateTransactionCategory)
 	rg.GET("", middleware.JwtMiddleware(), transactionCategoryCtrl.GetTransactionCategoriesByUserID)
 	rg.PUT("/:id", middleware.JwtMiddleware(), transactionCategoryCtrl.UpdateTransactionCategory)
 
-	rg.POST("/sub-categories", middleware.JwtMiddleware(), transactionSubCategoryCtrl.CreateTransactionSubCategory)
-	rg.GET("/sub-categories", middleware.JwtMiddleware(), transactionSubCategoryCtrl.FindAllTransactionSubCategories)
-	rg.GET("/sub-categories/:id", middleware.JwtMiddleware(), transactionSubCategoryCtrl.FindTransactionSubCategoryByID)
-	rg.DELETE("/sub-categories/:id", middleware.JwtMiddleware(), transactionSubCategoryCtrl.DeleteTransactionSubCategory)
-	rg.PUT("/sub-categories/:id", middleware.JwtMiddleware(), transactionSubCategoryCtrl.UpdateTransactionSubCategory)
+	rg.GET("/tree", middleware.JwtMiddleware(), transactionCategoryCtrl.GetTransactionCategoryTree)
 
 	rg.GET("/:id", middleware.JwtMiddleware(), transactionCategoryCtrl.GetTransactionCategoryByID)
 	rg.DELETE("/:id", middleware.JwtMiddleware(), transactionCategoryCtrl.DeleteTransactionCategory)
+	rg.POST("/:id/merge", middleware.JwtMiddleware(), transactionCategoryCtrl.MergeTransactionCategory)
+	rg.POST("/:id/archive", middleware.JwtMiddleware(), transactionCategoryCtrl.ArchiveTransactionCategory)
+	rg.POST("/:id/unarchive", middleware.JwtMiddleware(), transactionCategoryCtrl.UnarchiveTransactionCategory)
 }
\ No newline at end of file
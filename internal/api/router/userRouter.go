@@ -4,6 +4,7 @@ import (
 	"database/sql"
 
 	"github.com/dimas-pramantya/money-management/internal/api/controller"
+	"github.com/dimas-pramantya/money-management/internal/configs"
 	"github.com/dimas-pramantya/money-management/internal/api/middleware"
 	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
 	"github.com/dimas-pramantya/money-management/internal/service"
@@ -14,9 +15,13 @@ import (
 func InitUserRouter(rg *gin.RouterGroup, db *sql.DB, validator *validation.Validator) {
 	// Repositories
 	userRepo := pgrepository.NewUserPgRepository(db)
+	accountRepo := pgrepository.NewAccountPgRepository(db)
+	ledgerRepo := pgrepository.NewLedgerPgRepository(db)
+	exchangeRateRepo := pgrepository.NewExchangeRatePgRepository(db)
+	transactionCategoryRepo := pgrepository.NewTransactionCategoryPgRepository(db)
 
 	// Usecases
-	userUC := service.NewUserService(userRepo)
+	userUC := service.NewUserService(userRepo, accountRepo, ledgerRepo, exchangeRateRepo, transactionCategoryRepo, configs.DefaultCategories(), db)
 
 	// Controllers
 	userCtrl := controller.NewUserController(userUC, validator)
@@ -26,4 +31,5 @@ func InitUserRouter(rg *gin.RouterGroup, db *sql.DB, validator *validation.Valid
 	rg.POST("/login", userCtrl.Login)
 	rg.GET("/profile", middleware.JwtMiddleware(),  userCtrl.GetUserProfile)
 	rg.PATCH("/balance", middleware.JwtMiddleware(), userCtrl.UpdateUserBalance)
+	rg.PATCH("/base-currency", middleware.JwtMiddleware(), userCtrl.UpdateBaseCurrency)
 }
\ No newline at end of file
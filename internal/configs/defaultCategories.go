@@ -0,0 +1,82 @@
+package configs
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/spf13/viper"
+)
+
+//go:embed defaultCategories.json
+var embeddedDefaultCategories []byte
+
+// DefaultCategories returns the categories seeded for new users. DEFAULT_CATEGORIES_FILE may point
+// at a JSON file in the format of defaultCategories.json to replace the built-in set. A file that
+// cannot be read or is invalid stops the server at startup rather than quietly seeding the
+// built-in set instead.
+func DefaultCategories() domain.DefaultCategorySet {
+	data, source := embeddedDefaultCategories, "the built-in default categories"
+	if path := viper.GetString("DEFAULT_CATEGORIES_FILE"); path != "" {
+		file, err := os.ReadFile(path)
+		if err != nil {
+			panic(fmt.Sprintf("cannot read DEFAULT_CATEGORIES_FILE: %v", err))
+		}
+		data, source = file, path
+	}
+
+	set, err := parseDefaultCategories(data)
+	if err != nil {
+		panic(fmt.Sprintf("invalid default categories in %s: %v", source, err))
+	}
+	return set
+}
+
+// parseDefaultCategories reads and validates a set of default categories, normalizing its locales
+// for DefaultCategorySet.Name.
+func parseDefaultCategories(data []byte) (domain.DefaultCategorySet, error) {
+	var set domain.DefaultCategorySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return set, err
+	}
+	set.DefaultLocale = domain.NormalizeLocale(set.DefaultLocale)
+	if err := normalizeDefaultCategories(set.Categories); err != nil {
+		return set, err
+	}
+	return set, nil
+}
+
+// normalizeDefaultCategories checks the kind and names of every category and child, and lower
+// cases the locales of their names in place.
+func normalizeDefaultCategories(categories []domain.DefaultCategory) error {
+	for i := range categories {
+		category := &categories[i]
+		switch category.Kind {
+		case domain.CategoryKindIncome, domain.CategoryKindExpense, domain.CategoryKindBoth:
+		default:
+			return fmt.Errorf("unknown category kind %q", category.Kind)
+		}
+		if len(category.Names) == 0 {
+			return errors.New("a category has no names")
+		}
+		names := make(map[string]string, len(category.Names))
+		for locale, name := range category.Names {
+			normalized := domain.NormalizeLocale(locale)
+			if normalized == "" || name == "" {
+				return fmt.Errorf("category names %v have an empty locale or name", category.Names)
+			}
+			if _, ok := names[normalized]; ok {
+				return fmt.Errorf("category names %v repeat the locale %q", category.Names, normalized)
+			}
+			names[normalized] = name
+		}
+		category.Names = names
+		if err := normalizeDefaultCategories(category.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
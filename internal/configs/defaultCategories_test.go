@@ -0,0 +1,58 @@
+package configs
+
+import "testing"
+
+func TestParseDefaultCategoriesBuiltIn(t *testing.T) {
+	set, err := parseDefaultCategories(embeddedDefaultCategories)
+	if err != nil {
+		t.Fatalf("built-in default categories are invalid: %v", err)
+	}
+	if len(set.Categories) == 0 {
+		t.Fatal("built-in default categories are empty")
+	}
+}
+
+func TestParseDefaultCategories(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		locale  string
+		want    string
+		wantErr bool
+	}{
+		{name: "locale keys are lower cased", locale: "en-US", want: "Food",
+			data: `{"default_locale": "id", "categories": [{"kind": "expense", "names": {"en-US": "Food", "id": "Makanan"}}]}`},
+		{name: "underscore locale keys", locale: "en-us", want: "Food",
+			data: `{"default_locale": "id", "categories": [{"kind": "expense", "names": {"en_US": "Food", "id": "Makanan"}}]}`},
+		{name: "default locale is lower cased", locale: "fr", want: "Food",
+			data: `{"default_locale": "EN", "categories": [{"kind": "expense", "names": {"en": "Food"}}]}`},
+		{name: "unknown kind", wantErr: true,
+			data: `{"default_locale": "en", "categories": [{"kind": "savings", "names": {"en": "Food"}}]}`},
+		{name: "no names", wantErr: true,
+			data: `{"default_locale": "en", "categories": [{"kind": "expense", "names": {}}]}`},
+		{name: "child without names", wantErr: true,
+			data: `{"default_locale": "en", "categories": [{"kind": "expense", "names": {"en": "Food"}, "children": [{"kind": "expense"}]}]}`},
+		{name: "empty name", wantErr: true,
+			data: `{"default_locale": "en", "categories": [{"kind": "expense", "names": {"en": ""}}]}`},
+		{name: "repeated locale", wantErr: true,
+			data: `{"default_locale": "en", "categories": [{"kind": "expense", "names": {"en": "Food", "EN": "Meals"}}]}`},
+		{name: "malformed JSON", wantErr: true, data: `{"categories": [`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := parseDefaultCategories([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := set.Name(set.Categories[0], tt.locale); got != tt.want {
+				t.Errorf("Name(%q) = %q, want %q", tt.locale, got, tt.want)
+			}
+		})
+	}
+}
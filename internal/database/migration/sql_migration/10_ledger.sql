@@ -0,0 +1,86 @@
+-- +migrate Up
+CREATE TYPE ledger_entry_type_enum AS ENUM ('opening', 'transaction', 'transfer', 'adjustment', 'reversal');
+-- +migrate StatementBegin
+
+-- Every balance change is a journal: entries sharing a journal_id always sum to zero. Entries with an
+-- account_id move that account's balance; the others are the nominal side (income, expenses, equity).
+-- transaction_id and transfer_id carry no foreign key so history survives deletes.
+CREATE TABLE ledger_entries (
+    id BIGSERIAL PRIMARY KEY,
+    journal_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    account_id int,
+    ledger_account VARCHAR(255) NOT NULL,
+    entry_type ledger_entry_type_enum NOT NULL,
+    amount BIGINT NOT NULL,
+    transaction_id int,
+    transfer_id int,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- +migrate StatementEnd
+
+CREATE INDEX idx_ledger_entries_account_id ON ledger_entries(account_id);
+CREATE INDEX idx_ledger_entries_journal_id ON ledger_entries(journal_id);
+CREATE INDEX idx_ledger_entries_transaction_id ON ledger_entries(transaction_id);
+
+-- +migrate StatementBegin
+CREATE FUNCTION ledger_entries_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_ledger_entries_append_only
+BEFORE UPDATE OR DELETE ON ledger_entries
+FOR EACH ROW EXECUTE FUNCTION ledger_entries_append_only();
+
+-- Backfill one journal per existing transaction (one per transfer for its legs)...
+-- +migrate StatementBegin
+WITH transfer_journals AS (
+    SELECT id, gen_random_uuid() AS journal_id FROM transfers
+), transaction_journals AS (
+    SELECT t.*, COALESCE(tj.journal_id, gen_random_uuid()) AS journal_id,
+        CASE
+            WHEN t.transaction_type = 'expense' THEN -t.ammount
+            WHEN t.transaction_type = 'transfer' AND t.transfer_direction = 'out' THEN -t.ammount
+            ELSE t.ammount
+        END AS signed_amount
+    FROM transactions t
+    LEFT JOIN transfer_journals tj ON tj.id = t.transfer_id
+)
+INSERT INTO ledger_entries (journal_id, user_id, account_id, ledger_account, entry_type, amount, transaction_id, transfer_id, created_by)
+SELECT journal_id, user_id, account_id, 'assets:account:' || account_id,
+    CASE WHEN transfer_id IS NULL THEN 'transaction' ELSE 'transfer' END::ledger_entry_type_enum,
+    signed_amount, id, transfer_id, 'SYSTEM'
+FROM transaction_journals
+UNION ALL
+SELECT journal_id, user_id, NULL,
+    CASE WHEN transaction_type = 'income' THEN 'income:category:' ELSE 'expenses:category:' END || transaction_category_id,
+    CASE WHEN transfer_id IS NULL THEN 'transaction' ELSE 'transfer' END::ledger_entry_type_enum,
+    -signed_amount, id, transfer_id, 'SYSTEM'
+FROM transaction_journals
+WHERE transaction_type <> 'transfer';
+-- +migrate StatementEnd
+
+-- ...and an opening balance for whatever the stored account balance holds beyond that history.
+-- +migrate StatementBegin
+WITH openings AS (
+    SELECT a.id, a.user_id, gen_random_uuid() AS journal_id,
+        a.balance - COALESCE((SELECT SUM(l.amount) FROM ledger_entries l WHERE l.account_id = a.id), 0) AS amount
+    FROM accounts a
+)
+INSERT INTO ledger_entries (journal_id, user_id, account_id, ledger_account, entry_type, amount, created_by)
+SELECT journal_id, user_id, id, 'assets:account:' || id, 'opening'::ledger_entry_type_enum, amount, 'SYSTEM' FROM openings WHERE amount <> 0
+UNION ALL
+SELECT journal_id, user_id, NULL, 'equity:opening-balances', 'opening'::ledger_entry_type_enum, -amount, 'SYSTEM' FROM openings WHERE amount <> 0;
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TABLE ledger_entries;
+DROP FUNCTION ledger_entries_append_only();
+DROP TYPE ledger_entry_type_enum;
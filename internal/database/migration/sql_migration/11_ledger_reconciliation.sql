@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+ALTER TYPE ledger_entry_type_enum ADD VALUE IF NOT EXISTS 'reconciliation';
+
+-- +migrate Down
+-- Postgres cannot drop a value from an enum; ledger entries are append-only and keep it.
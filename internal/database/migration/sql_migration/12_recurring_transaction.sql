@@ -0,0 +1,47 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+CREATE TYPE recurring_frequency_enum AS ENUM ('daily', 'weekly', 'monthly', 'yearly');
+
+CREATE TABLE recurring_transactions (
+    id SERIAL PRIMARY KEY,
+    user_id uuid NOT NULL,
+    account_id int NOT NULL,
+    transaction_category_id int NOT NULL,
+    transaction_sub_category_id int,
+    transaction_type transaction_type_enum NOT NULL,
+    ammount BIGINT NOT NULL,
+    notes TEXT,
+    frequency recurring_frequency_enum NOT NULL,
+    interval_count int NOT NULL DEFAULT 1 CHECK (interval_count > 0),
+    day_of_month int CHECK (day_of_month BETWEEN 1 AND 31),
+    start_date DATE NOT NULL,
+    end_date DATE,
+    occurrences int NOT NULL DEFAULT 0,
+    next_run_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP,
+    updated_by VARCHAR(255),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (transaction_category_id) REFERENCES transaction_categories(id) ON DELETE CASCADE,
+    FOREIGN KEY (transaction_sub_category_id) REFERENCES transaction_sub_categories(id) ON DELETE SET NULL,
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX idx_recurring_transactions_next_run_date ON recurring_transactions(next_run_date);
+
+-- +migrate StatementEnd
+
+-- Each occurrence of a rule is posted at most once, even if the scheduler runs twice.
+ALTER TABLE transactions ADD COLUMN recurring_transaction_id int REFERENCES recurring_transactions(id) ON DELETE SET NULL;
+ALTER TABLE transactions ADD COLUMN recurring_date DATE;
+CREATE UNIQUE INDEX idx_transactions_recurring_occurrence ON transactions(recurring_transaction_id, recurring_date);
+
+-- +migrate Down
+DROP INDEX idx_transactions_recurring_occurrence;
+ALTER TABLE transactions DROP COLUMN recurring_date;
+ALTER TABLE transactions DROP COLUMN recurring_transaction_id;
+DROP TABLE recurring_transactions;
+DROP TYPE recurring_frequency_enum;
@@ -0,0 +1,31 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+CREATE TYPE budget_period_enum AS ENUM ('weekly', 'monthly', 'yearly');
+
+CREATE TABLE budgets (
+    id SERIAL PRIMARY KEY,
+    user_id uuid NOT NULL,
+    transaction_category_id int NOT NULL,
+    transaction_sub_category_id int,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    period budget_period_enum NOT NULL DEFAULT 'monthly',
+    start_date DATE NOT NULL,
+    end_date DATE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP,
+    updated_by VARCHAR(255),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (transaction_category_id) REFERENCES transaction_categories(id) ON DELETE CASCADE,
+    FOREIGN KEY (transaction_sub_category_id) REFERENCES transaction_sub_categories(id) ON DELETE CASCADE,
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX idx_budgets_user_id ON budgets(user_id);
+
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TABLE budgets;
+DROP TYPE budget_period_enum;
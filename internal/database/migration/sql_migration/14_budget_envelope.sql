@@ -0,0 +1,33 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+CREATE TYPE budget_rollover_enum AS ENUM ('none', 'full', 'capped');
+
+ALTER TABLE budgets ADD COLUMN rollover budget_rollover_enum NOT NULL DEFAULT 'none';
+ALTER TABLE budgets ADD COLUMN rollover_cap BIGINT CHECK (rollover_cap >= 0);
+ALTER TABLE budgets ADD CONSTRAINT budgets_rollover_cap_check CHECK (rollover <> 'capped' OR rollover_cap IS NOT NULL);
+
+-- Each row moves money into (positive) or out of (negative) an envelope for one budget period.
+-- Whatever income is not assigned to an envelope stays in the user's unassigned pool.
+CREATE TABLE budget_allocations (
+    id SERIAL PRIMARY KEY,
+    user_id uuid NOT NULL,
+    budget_id int NOT NULL,
+    period_start DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (budget_id) REFERENCES budgets(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_budget_allocations_budget_id ON budget_allocations(budget_id, period_start);
+
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TABLE budget_allocations;
+ALTER TABLE budgets DROP CONSTRAINT budgets_rollover_cap_check;
+ALTER TABLE budgets DROP COLUMN rollover_cap;
+ALTER TABLE budgets DROP COLUMN rollover;
+DROP TYPE budget_rollover_enum;
@@ -0,0 +1,34 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Describes how to read one bank's CSV statements. Columns are header names, or 1-based
+-- positions when the file has no header row.
+CREATE TABLE import_profiles (
+    id SERIAL PRIMARY KEY,
+    user_id uuid NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    delimiter VARCHAR(1) NOT NULL DEFAULT ',',
+    has_header BOOLEAN NOT NULL DEFAULT TRUE,
+    date_column VARCHAR(255) NOT NULL,
+    date_format VARCHAR(50) NOT NULL DEFAULT 'YYYY-MM-DD',
+    amount_column VARCHAR(255) NOT NULL,
+    decimal_separator VARCHAR(1) NOT NULL DEFAULT '.',
+    type_column VARCHAR(255),
+    income_value VARCHAR(255),
+    expense_value VARCHAR(255),
+    note_column VARCHAR(255),
+    category_column VARCHAR(255),
+    default_category_id int,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP,
+    updated_by VARCHAR(255),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (default_category_id) REFERENCES transaction_categories(id) ON DELETE SET NULL,
+    UNIQUE (user_id, name)
+);
+
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TABLE import_profiles;
@@ -0,0 +1,9 @@
+-- +migrate Up
+-- Statement imports keep the bank's own id (OFX FITID) so a statement imported twice into the
+-- same account does not post its entries twice.
+ALTER TABLE transactions ADD COLUMN external_id VARCHAR(255);
+CREATE UNIQUE INDEX idx_transactions_account_external_id ON transactions(account_id, external_id) WHERE external_id IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX idx_transactions_account_external_id;
+ALTER TABLE transactions DROP COLUMN external_id;
@@ -0,0 +1,50 @@
+-- +migrate Up
+-- Amounts are kept in the currency of their account. A transaction always carries its account's
+-- currency; reports and the profile total convert into the user's base currency.
+ALTER TABLE users ADD COLUMN base_currency CHAR(3) NOT NULL DEFAULT 'IDR';
+ALTER TABLE accounts ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR';
+ALTER TABLE transactions ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+-- +migrate StatementBegin
+
+-- One unit of from_currency is worth rate units of to_currency from effective_date until the
+-- next rate for the same pair.
+CREATE TABLE exchange_rates (
+    id SERIAL PRIMARY KEY,
+    user_id uuid NOT NULL,
+    from_currency CHAR(3) NOT NULL,
+    to_currency CHAR(3) NOT NULL,
+    rate NUMERIC(24, 12) NOT NULL CHECK (rate > 0),
+    effective_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP,
+    updated_by VARCHAR(255),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (user_id, from_currency, to_currency, effective_date),
+    CHECK (from_currency <> to_currency)
+);
+
+-- +migrate StatementEnd
+
+-- convert_amount converts amount with the user's latest rate on or before on_date, using the
+-- inverse of the opposite pair when that is the only one recorded. It returns NULL without a rate.
+-- +migrate StatementBegin
+CREATE FUNCTION convert_amount(p_user_id uuid, p_amount bigint, p_from CHAR(3), p_to CHAR(3), p_on DATE) RETURNS bigint AS $$
+    SELECT CASE WHEN p_from = p_to THEN p_amount ELSE (
+        SELECT ROUND(p_amount * CASE WHEN er.from_currency = p_from THEN er.rate ELSE 1 / er.rate END)::bigint
+        FROM exchange_rates er
+        WHERE er.user_id = p_user_id AND er.effective_date <= p_on
+            AND ((er.from_currency = p_from AND er.to_currency = p_to) OR (er.from_currency = p_to AND er.to_currency = p_from))
+        ORDER BY er.effective_date DESC, er.from_currency = p_from DESC
+        LIMIT 1
+    ) END
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP FUNCTION convert_amount(uuid, bigint, CHAR(3), CHAR(3), DATE);
+DROP TABLE exchange_rates;
+ALTER TABLE transactions DROP COLUMN currency;
+ALTER TABLE accounts DROP COLUMN currency;
+ALTER TABLE users DROP COLUMN base_currency;
@@ -0,0 +1,105 @@
+-- +migrate Up
+-- Amounts are integers in the minor units of their currency (see money.MinorUnits) and always
+-- positive; the transaction type and transfer direction give the sign. Existing rows are not
+-- re-checked.
+ALTER TABLE transactions RENAME COLUMN ammount TO amount;
+ALTER TABLE recurring_transactions RENAME COLUMN ammount TO amount;
+ALTER TABLE transactions ADD CONSTRAINT transactions_amount_positive CHECK (amount > 0) NOT VALID;
+ALTER TABLE recurring_transactions ADD CONSTRAINT recurring_transactions_amount_positive CHECK (amount > 0) NOT VALID;
+
+-- currency_minor_units mirrors money.MinorUnits.
+-- +migrate StatementBegin
+CREATE FUNCTION currency_minor_units(p_currency CHAR(3)) RETURNS int AS $$
+    SELECT CASE
+        WHEN p_currency IN ('IDR', 'BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG',
+            'RWF', 'UGX', 'UYI', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 0
+        WHEN p_currency IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 3
+        WHEN p_currency IN ('CLF', 'UYW') THEN 4
+        ELSE 2
+    END
+$$ LANGUAGE sql IMMUTABLE;
+-- +migrate StatementEnd
+
+-- Amounts recorded so far are whole units of their currency; they are rescaled into minor units.
+-- Account amounts follow the account's currency, budgets and the user balance the base currency.
+-- Every ledger journal posts to at least one account, whose currency the whole journal shares.
+UPDATE accounts SET balance = balance * POWER(10::numeric, currency_minor_units(currency));
+UPDATE transactions SET amount = amount * POWER(10::numeric, currency_minor_units(currency));
+UPDATE recurring_transactions r SET amount = r.amount * POWER(10::numeric, currency_minor_units(a.currency))
+FROM accounts a WHERE a.id = r.account_id;
+UPDATE transfers t SET amount = t.amount * POWER(10::numeric, currency_minor_units(a.currency)), fee = t.fee * POWER(10::numeric, currency_minor_units(a.currency))
+FROM accounts a WHERE a.id = t.from_account_id;
+UPDATE users SET balance = balance * POWER(10::numeric, currency_minor_units(base_currency));
+UPDATE budgets b SET amount = b.amount * POWER(10::numeric, currency_minor_units(u.base_currency)),
+    rollover_cap = b.rollover_cap * POWER(10::numeric, currency_minor_units(u.base_currency))
+FROM users u WHERE u.id = b.user_id;
+UPDATE budget_allocations ba SET amount = ba.amount * POWER(10::numeric, currency_minor_units(u.base_currency))
+FROM users u WHERE u.id = ba.user_id;
+
+ALTER TABLE ledger_entries DISABLE TRIGGER trg_ledger_entries_append_only;
+UPDATE ledger_entries l SET amount = l.amount * POWER(10::numeric, currency_minor_units(j.currency))
+FROM (
+    SELECT DISTINCT ON (le.journal_id) le.journal_id, a.currency
+    FROM ledger_entries le INNER JOIN accounts a ON a.id = le.account_id
+    ORDER BY le.journal_id
+) j
+WHERE j.journal_id = l.journal_id;
+ALTER TABLE ledger_entries ENABLE TRIGGER trg_ledger_entries_append_only;
+
+-- Rates are quoted between major units, so the result is rescaled when the two currencies have a
+-- different number of decimals.
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION convert_amount(p_user_id uuid, p_amount bigint, p_from CHAR(3), p_to CHAR(3), p_on DATE) RETURNS bigint AS $$
+    SELECT CASE WHEN p_from = p_to THEN p_amount ELSE (
+        SELECT ROUND(p_amount * CASE WHEN er.from_currency = p_from THEN er.rate ELSE 1 / er.rate END
+            * POWER(10::numeric, currency_minor_units(p_to) - currency_minor_units(p_from)))::bigint
+        FROM exchange_rates er
+        WHERE er.user_id = p_user_id AND er.effective_date <= p_on
+            AND ((er.from_currency = p_from AND er.to_currency = p_to) OR (er.from_currency = p_to AND er.to_currency = p_from))
+        ORDER BY er.effective_date DESC, er.from_currency = p_from DESC
+        LIMIT 1
+    ) END
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate Down
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION convert_amount(p_user_id uuid, p_amount bigint, p_from CHAR(3), p_to CHAR(3), p_on DATE) RETURNS bigint AS $$
+    SELECT CASE WHEN p_from = p_to THEN p_amount ELSE (
+        SELECT ROUND(p_amount * CASE WHEN er.from_currency = p_from THEN er.rate ELSE 1 / er.rate END)::bigint
+        FROM exchange_rates er
+        WHERE er.user_id = p_user_id AND er.effective_date <= p_on
+            AND ((er.from_currency = p_from AND er.to_currency = p_to) OR (er.from_currency = p_to AND er.to_currency = p_from))
+        ORDER BY er.effective_date DESC, er.from_currency = p_from DESC
+        LIMIT 1
+    ) END
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+ALTER TABLE ledger_entries DISABLE TRIGGER trg_ledger_entries_append_only;
+UPDATE ledger_entries l SET amount = l.amount / POWER(10::numeric, currency_minor_units(j.currency))
+FROM (
+    SELECT DISTINCT ON (le.journal_id) le.journal_id, a.currency
+    FROM ledger_entries le INNER JOIN accounts a ON a.id = le.account_id
+    ORDER BY le.journal_id
+) j
+WHERE j.journal_id = l.journal_id;
+ALTER TABLE ledger_entries ENABLE TRIGGER trg_ledger_entries_append_only;
+
+UPDATE budget_allocations ba SET amount = ba.amount / POWER(10::numeric, currency_minor_units(u.base_currency))
+FROM users u WHERE u.id = ba.user_id;
+UPDATE budgets b SET amount = b.amount / POWER(10::numeric, currency_minor_units(u.base_currency)),
+    rollover_cap = b.rollover_cap / POWER(10::numeric, currency_minor_units(u.base_currency))
+FROM users u WHERE u.id = b.user_id;
+UPDATE users SET balance = balance / POWER(10::numeric, currency_minor_units(base_currency));
+UPDATE transfers t SET amount = t.amount / POWER(10::numeric, currency_minor_units(a.currency)), fee = t.fee / POWER(10::numeric, currency_minor_units(a.currency))
+FROM accounts a WHERE a.id = t.from_account_id;
+UPDATE recurring_transactions r SET amount = r.amount / POWER(10::numeric, currency_minor_units(a.currency))
+FROM accounts a WHERE a.id = r.account_id;
+UPDATE transactions SET amount = amount / POWER(10::numeric, currency_minor_units(currency));
+UPDATE accounts SET balance = balance / POWER(10::numeric, currency_minor_units(currency));
+DROP FUNCTION currency_minor_units(CHAR(3));
+ALTER TABLE recurring_transactions DROP CONSTRAINT recurring_transactions_amount_positive;
+ALTER TABLE transactions DROP CONSTRAINT transactions_amount_positive;
+ALTER TABLE recurring_transactions RENAME COLUMN amount TO ammount;
+ALTER TABLE transactions RENAME COLUMN amount TO ammount;
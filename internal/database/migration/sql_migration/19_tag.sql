@@ -0,0 +1,33 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Free-form labels that cut across categories, e.g. "vacation-bali-2026" or "reimbursable". A
+-- transaction can carry any number of tags.
+CREATE TABLE tags (
+    id SERIAL PRIMARY KEY,
+    user_id uuid NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP,
+    updated_by VARCHAR(255),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX tags_user_id_name_key ON tags (user_id, lower(name));
+
+CREATE TABLE transaction_tags (
+    transaction_id int NOT NULL,
+    tag_id int NOT NULL,
+    PRIMARY KEY (transaction_id, tag_id),
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX transaction_tags_tag_id_idx ON transaction_tags (tag_id);
+
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TABLE transaction_tags;
+DROP TABLE tags;
@@ -0,0 +1,26 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- A split transaction spreads its amount over several categories, e.g. one supermarket receipt
+-- covering groceries and household goods. The transaction itself then has no category; its splits
+-- carry one each and their amounts add up to the transaction amount.
+CREATE TABLE transaction_splits (
+    id SERIAL PRIMARY KEY,
+    transaction_id int NOT NULL,
+    transaction_category_id int NOT NULL,
+    transaction_sub_category_id int,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    notes TEXT,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    -- Deleting a category must not silently drop a share of a split and unbalance it.
+    FOREIGN KEY (transaction_category_id) REFERENCES transaction_categories(id),
+    FOREIGN KEY (transaction_sub_category_id) REFERENCES transaction_sub_categories(id) ON DELETE SET NULL
+);
+
+CREATE INDEX transaction_splits_transaction_id_idx ON transaction_splits (transaction_id);
+CREATE INDEX transaction_splits_category_idx ON transaction_splits (transaction_category_id, transaction_sub_category_id);
+
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TABLE transaction_splits;
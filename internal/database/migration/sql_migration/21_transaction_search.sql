@@ -0,0 +1,130 @@
+-- +migrate Up
+-- Full-text search over transactions. search_vector holds the notes (weight A) and the category
+-- and sub-category names (weight B), including those of a split transaction's splits. The names
+-- live in other tables, so triggers keep it current rather than a generated column. The 'simple'
+-- configuration is used since notes mix languages and stemming one of them would hurt the others.
+ALTER TABLE transactions ADD COLUMN search_vector tsvector NOT NULL DEFAULT ''::tsvector;
+
+-- +migrate StatementBegin
+CREATE FUNCTION transaction_search_notes(p_transaction_id int) RETURNS text AS $$
+    SELECT concat_ws(' ', t.notes,
+        (SELECT string_agg(s.notes, ' ' ORDER BY s.id) FROM transaction_splits s WHERE s.transaction_id = t.id))
+    FROM transactions t
+    WHERE t.id = p_transaction_id
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE FUNCTION transaction_search_categories(p_transaction_id int) RETURNS text AS $$
+    SELECT concat_ws(' ', tc.name, tsc.name,
+        (SELECT string_agg(concat_ws(' ', sc.name, ssc.name), ' ' ORDER BY s.id)
+            FROM transaction_splits s
+            INNER JOIN transaction_categories sc ON s.transaction_category_id = sc.id
+            LEFT JOIN transaction_sub_categories ssc ON s.transaction_sub_category_id = ssc.id
+            WHERE s.transaction_id = t.id))
+    FROM transactions t
+    LEFT JOIN transaction_categories tc ON t.transaction_category_id = tc.id
+    LEFT JOIN transaction_sub_categories tsc ON t.transaction_sub_category_id = tsc.id
+    WHERE t.id = p_transaction_id
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE FUNCTION transaction_search_vector(p_transaction_id int) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(transaction_search_notes(p_transaction_id), '')), 'A')
+        || setweight(to_tsvector('simple', COALESCE(transaction_search_categories(p_transaction_id), '')), 'B')
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- transaction_search_text is the document snippets are cut from.
+-- +migrate StatementBegin
+CREATE FUNCTION transaction_search_text(p_transaction_id int) RETURNS text AS $$
+    SELECT concat_ws(' · ', NULLIF(transaction_search_notes(p_transaction_id), ''),
+        NULLIF(transaction_search_categories(p_transaction_id), ''))
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- Setting only search_vector does not fire the UPDATE OF trigger below again.
+-- +migrate StatementBegin
+CREATE FUNCTION transactions_refresh_search() RETURNS trigger AS $$
+BEGIN
+    UPDATE transactions SET search_vector = transaction_search_vector(NEW.id) WHERE id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_transactions_refresh_search
+AFTER INSERT OR UPDATE OF notes, transaction_category_id, transaction_sub_category_id ON transactions
+FOR EACH ROW EXECUTE FUNCTION transactions_refresh_search();
+
+-- +migrate StatementBegin
+CREATE FUNCTION transaction_splits_refresh_search() RETURNS trigger AS $$
+DECLARE
+    v_transaction_id int;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        v_transaction_id := OLD.transaction_id;
+    ELSE
+        v_transaction_id := NEW.transaction_id;
+    END IF;
+    UPDATE transactions SET search_vector = transaction_search_vector(id) WHERE id = v_transaction_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_transaction_splits_refresh_search
+AFTER INSERT OR UPDATE OR DELETE ON transaction_splits
+FOR EACH ROW EXECUTE FUNCTION transaction_splits_refresh_search();
+
+-- Renaming a category re-indexes every transaction filed under it.
+-- +migrate StatementBegin
+CREATE FUNCTION transaction_categories_refresh_search() RETURNS trigger AS $$
+BEGIN
+    UPDATE transactions SET search_vector = transaction_search_vector(id)
+    WHERE transaction_category_id = NEW.id
+        OR id IN (SELECT transaction_id FROM transaction_splits WHERE transaction_category_id = NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_transaction_categories_refresh_search
+AFTER UPDATE OF name ON transaction_categories
+FOR EACH ROW EXECUTE FUNCTION transaction_categories_refresh_search();
+
+-- +migrate StatementBegin
+CREATE FUNCTION transaction_sub_categories_refresh_search() RETURNS trigger AS $$
+BEGIN
+    UPDATE transactions SET search_vector = transaction_search_vector(id)
+    WHERE transaction_sub_category_id = NEW.id
+        OR id IN (SELECT transaction_id FROM transaction_splits WHERE transaction_sub_category_id = NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_transaction_sub_categories_refresh_search
+AFTER UPDATE OF name ON transaction_sub_categories
+FOR EACH ROW EXECUTE FUNCTION transaction_sub_categories_refresh_search();
+
+UPDATE transactions SET search_vector = transaction_search_vector(id);
+
+CREATE INDEX idx_transactions_search_vector ON transactions USING GIN (search_vector);
+
+-- +migrate Down
+DROP INDEX idx_transactions_search_vector;
+DROP TRIGGER trg_transaction_sub_categories_refresh_search ON transaction_sub_categories;
+DROP TRIGGER trg_transaction_categories_refresh_search ON transaction_categories;
+DROP TRIGGER trg_transaction_splits_refresh_search ON transaction_splits;
+DROP TRIGGER trg_transactions_refresh_search ON transactions;
+DROP FUNCTION transaction_sub_categories_refresh_search();
+DROP FUNCTION transaction_categories_refresh_search();
+DROP FUNCTION transaction_splits_refresh_search();
+DROP FUNCTION transactions_refresh_search();
+DROP FUNCTION transaction_search_text(int);
+DROP FUNCTION transaction_search_vector(int);
+DROP FUNCTION transaction_search_categories(int);
+DROP FUNCTION transaction_search_notes(int);
+ALTER TABLE transactions DROP COLUMN search_vector;
@@ -0,0 +1,6 @@
+-- +migrate Up
+-- Serves cursor pagination, which pages each user's transactions on (transaction_date, id).
+CREATE INDEX idx_transactions_user_date_id ON transactions (user_id, transaction_date, id);
+
+-- +migrate Down
+DROP INDEX idx_transactions_user_date_id;
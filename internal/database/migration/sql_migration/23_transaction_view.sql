@@ -0,0 +1,23 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Saved transaction filters, e.g. "this month's food excluding rent". filter holds the query
+-- parameters of GET /transactions as JSON, see dto.TransactionFilterDto.
+CREATE TABLE transaction_views (
+    id SERIAL PRIMARY KEY,
+    user_id uuid NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP,
+    updated_by VARCHAR(255),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX transaction_views_user_id_name_key ON transaction_views (user_id, lower(name));
+
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TABLE transaction_views;
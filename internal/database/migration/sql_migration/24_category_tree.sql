@@ -0,0 +1,255 @@
+-- +migrate Up
+-- Categories become one tree of any depth: parent_id points at the enclosing category and is NULL
+-- for a root. Every sub-category turns into a child of its category, and whatever was filed under
+-- a (category, sub-category) pair is filed under that child alone.
+ALTER TABLE transaction_categories ADD COLUMN parent_id int REFERENCES transaction_categories(id) ON DELETE CASCADE;
+CREATE INDEX idx_transaction_categories_parent_id ON transaction_categories(parent_id);
+
+ALTER TABLE transaction_categories ADD COLUMN legacy_sub_category_id int;
+INSERT INTO transaction_categories (name, user_id, parent_id, created_at, created_by, updated_at, updated_by, legacy_sub_category_id)
+SELECT s.name, c.user_id, c.id, s.created_at, s.created_by, s.updated_at, s.updated_by, s.id
+FROM transaction_sub_categories s
+INNER JOIN transaction_categories c ON c.id = s.transaction_category_id;
+
+-- The search triggers watch columns that are about to go; they are rebuilt below.
+DROP TRIGGER trg_transaction_sub_categories_refresh_search ON transaction_sub_categories;
+DROP TRIGGER trg_transactions_refresh_search ON transactions;
+DROP TRIGGER trg_transaction_splits_refresh_search ON transaction_splits;
+DROP FUNCTION transaction_sub_categories_refresh_search();
+
+-- The nominal postings of re-filed transactions and splits move to the account of their new
+-- category, so that reversing them later cancels out. A share is posted as +amount for an expense
+-- and -amount for income, see transactionPostings.
+-- +migrate StatementBegin
+WITH moved AS (
+    SELECT t.id AS transaction_id, t.user_id, t.transaction_type, t.amount, c.parent_id AS from_category, c.id AS to_category
+    FROM transactions t
+    INNER JOIN transaction_categories c ON c.legacy_sub_category_id = t.transaction_sub_category_id
+    WHERE t.transaction_type IN ('income', 'expense')
+        AND NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.id)
+    UNION ALL
+    SELECT t.id, t.user_id, t.transaction_type, s.amount, c.parent_id, c.id
+    FROM transaction_splits s
+    INNER JOIN transactions t ON t.id = s.transaction_id
+    INNER JOIN transaction_categories c ON c.legacy_sub_category_id = s.transaction_sub_category_id
+), journals AS (
+    SELECT m.*, gen_random_uuid() AS journal_id,
+        CASE WHEN m.transaction_type = 'income' THEN 'income:category:' ELSE 'expenses:category:' END AS root,
+        CASE WHEN m.transaction_type = 'expense' THEN m.amount ELSE -m.amount END AS posted
+    FROM moved m
+)
+INSERT INTO ledger_entries (journal_id, user_id, ledger_account, entry_type, amount, transaction_id, description, created_by)
+SELECT journal_id, user_id, root || from_category, 'adjustment'::ledger_entry_type_enum, -posted, transaction_id, 'Sub-category became a category', 'SYSTEM' FROM journals
+UNION ALL
+SELECT journal_id, user_id, root || to_category, 'adjustment'::ledger_entry_type_enum, posted, transaction_id, 'Sub-category became a category', 'SYSTEM' FROM journals;
+-- +migrate StatementEnd
+
+UPDATE transactions t SET transaction_category_id = c.id
+FROM transaction_categories c WHERE c.legacy_sub_category_id = t.transaction_sub_category_id;
+UPDATE transaction_splits s SET transaction_category_id = c.id
+FROM transaction_categories c WHERE c.legacy_sub_category_id = s.transaction_sub_category_id;
+UPDATE recurring_transactions r SET transaction_category_id = c.id
+FROM transaction_categories c WHERE c.legacy_sub_category_id = r.transaction_sub_category_id;
+UPDATE budgets b SET transaction_category_id = c.id
+FROM transaction_categories c WHERE c.legacy_sub_category_id = b.transaction_sub_category_id;
+
+-- Saved views filtering on a sub-category filter on the category it became.
+UPDATE transaction_views v SET filter = v.filter || jsonb_build_object('category_id', c.id)
+FROM transaction_categories c WHERE c.legacy_sub_category_id = (v.filter->>'sub_category_id')::int;
+UPDATE transaction_views SET filter = filter - 'sub_category_id' - 'has_sub_category';
+
+ALTER TABLE transactions DROP COLUMN transaction_sub_category_id;
+ALTER TABLE transaction_splits DROP COLUMN transaction_sub_category_id;
+ALTER TABLE recurring_transactions DROP COLUMN transaction_sub_category_id;
+ALTER TABLE budgets DROP COLUMN transaction_sub_category_id;
+DROP TABLE transaction_sub_categories;
+ALTER TABLE transaction_categories DROP COLUMN legacy_sub_category_id;
+
+CREATE INDEX transaction_splits_category_idx ON transaction_splits (transaction_category_id);
+
+-- transaction_category_subtree lists a category and everything below it.
+-- +migrate StatementBegin
+CREATE FUNCTION transaction_category_subtree(p_category_id int) RETURNS SETOF int AS $$
+    WITH RECURSIVE subtree AS (
+        SELECT id FROM transaction_categories WHERE id = p_category_id
+        UNION ALL
+        SELECT c.id FROM transaction_categories c INNER JOIN subtree s ON c.parent_id = s.id
+    )
+    SELECT id FROM subtree
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- transaction_category_path names a category from its root down, e.g. "Food:Groceries".
+-- +migrate StatementBegin
+CREATE FUNCTION transaction_category_path(p_category_id int) RETURNS text AS $$
+    WITH RECURSIVE path AS (
+        SELECT id, parent_id, name, 0 AS depth FROM transaction_categories WHERE id = p_category_id
+        UNION ALL
+        SELECT c.id, c.parent_id, c.name, p.depth + 1 FROM transaction_categories c INNER JOIN path p ON c.id = p.parent_id
+    )
+    SELECT string_agg(name, ':' ORDER BY depth DESC) FROM path
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION transaction_search_categories(p_transaction_id int) RETURNS text AS $$
+    SELECT concat_ws(' ', transaction_category_path(t.transaction_category_id),
+        (SELECT string_agg(transaction_category_path(s.transaction_category_id), ' ' ORDER BY s.id)
+            FROM transaction_splits s WHERE s.transaction_id = t.id))
+    FROM transactions t
+    WHERE t.id = p_transaction_id
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION transaction_search_vector(p_transaction_id int) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(transaction_search_notes(p_transaction_id), '')), 'A')
+        || setweight(to_tsvector('simple', replace(COALESCE(transaction_search_categories(p_transaction_id), ''), ':', ' ')), 'B')
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- Renaming or moving a category changes the path of everything below it.
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION transaction_categories_refresh_search() RETURNS trigger AS $$
+BEGIN
+    UPDATE transactions SET search_vector = transaction_search_vector(id)
+    WHERE transaction_category_id IN (SELECT transaction_category_subtree(NEW.id))
+        OR id IN (SELECT transaction_id FROM transaction_splits
+            WHERE transaction_category_id IN (SELECT transaction_category_subtree(NEW.id)));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+DROP TRIGGER trg_transaction_categories_refresh_search ON transaction_categories;
+CREATE TRIGGER trg_transaction_categories_refresh_search
+AFTER UPDATE OF name, parent_id ON transaction_categories
+FOR EACH ROW EXECUTE FUNCTION transaction_categories_refresh_search();
+
+CREATE TRIGGER trg_transactions_refresh_search
+AFTER INSERT OR UPDATE OF notes, transaction_category_id ON transactions
+FOR EACH ROW EXECUTE FUNCTION transactions_refresh_search();
+
+CREATE TRIGGER trg_transaction_splits_refresh_search
+AFTER INSERT OR UPDATE OR DELETE ON transaction_splits
+FOR EACH ROW EXECUTE FUNCTION transaction_splits_refresh_search();
+
+UPDATE transactions SET search_vector = transaction_search_vector(id);
+
+-- +migrate Down
+-- Two levels cannot hold a deeper tree: every category below a root becomes a sub-category of its
+-- root. The ledger adjustments made on the way up are left in place.
+DROP TRIGGER trg_transaction_splits_refresh_search ON transaction_splits;
+DROP TRIGGER trg_transactions_refresh_search ON transactions;
+DROP TRIGGER trg_transaction_categories_refresh_search ON transaction_categories;
+
+CREATE TABLE transaction_sub_categories (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    transaction_category_id int NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP,
+    updated_by VARCHAR(255),
+    legacy_category_id int,
+    FOREIGN KEY (transaction_category_id) REFERENCES transaction_categories(id) ON DELETE CASCADE
+);
+
+-- +migrate StatementBegin
+WITH RECURSIVE roots AS (
+    SELECT id, id AS root_id FROM transaction_categories WHERE parent_id IS NULL
+    UNION ALL
+    SELECT c.id, r.root_id FROM transaction_categories c INNER JOIN roots r ON c.parent_id = r.id
+)
+INSERT INTO transaction_sub_categories (name, transaction_category_id, created_at, created_by, updated_at, updated_by, legacy_category_id)
+SELECT c.name, r.root_id, c.created_at, c.created_by, c.updated_at, c.updated_by, c.id
+FROM transaction_categories c
+INNER JOIN roots r ON r.id = c.id
+WHERE c.parent_id IS NOT NULL;
+-- +migrate StatementEnd
+
+ALTER TABLE transactions ADD COLUMN transaction_sub_category_id int REFERENCES transaction_sub_categories(id) ON DELETE CASCADE;
+ALTER TABLE transaction_splits ADD COLUMN transaction_sub_category_id int REFERENCES transaction_sub_categories(id) ON DELETE SET NULL;
+ALTER TABLE recurring_transactions ADD COLUMN transaction_sub_category_id int REFERENCES transaction_sub_categories(id) ON DELETE SET NULL;
+ALTER TABLE budgets ADD COLUMN transaction_sub_category_id int REFERENCES transaction_sub_categories(id) ON DELETE CASCADE;
+
+UPDATE transactions t SET transaction_category_id = s.transaction_category_id, transaction_sub_category_id = s.id
+FROM transaction_sub_categories s WHERE s.legacy_category_id = t.transaction_category_id;
+UPDATE transaction_splits t SET transaction_category_id = s.transaction_category_id, transaction_sub_category_id = s.id
+FROM transaction_sub_categories s WHERE s.legacy_category_id = t.transaction_category_id;
+UPDATE recurring_transactions t SET transaction_category_id = s.transaction_category_id, transaction_sub_category_id = s.id
+FROM transaction_sub_categories s WHERE s.legacy_category_id = t.transaction_category_id;
+UPDATE budgets t SET transaction_category_id = s.transaction_category_id, transaction_sub_category_id = s.id
+FROM transaction_sub_categories s WHERE s.legacy_category_id = t.transaction_category_id;
+
+DELETE FROM transaction_categories WHERE parent_id IS NOT NULL;
+ALTER TABLE transaction_sub_categories DROP COLUMN legacy_category_id;
+DROP INDEX idx_transaction_categories_parent_id;
+ALTER TABLE transaction_categories DROP COLUMN parent_id;
+DROP INDEX transaction_splits_category_idx;
+CREATE INDEX transaction_splits_category_idx ON transaction_splits (transaction_category_id, transaction_sub_category_id);
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION transaction_search_categories(p_transaction_id int) RETURNS text AS $$
+    SELECT concat_ws(' ', tc.name, tsc.name,
+        (SELECT string_agg(concat_ws(' ', sc.name, ssc.name), ' ' ORDER BY s.id)
+            FROM transaction_splits s
+            INNER JOIN transaction_categories sc ON s.transaction_category_id = sc.id
+            LEFT JOIN transaction_sub_categories ssc ON s.transaction_sub_category_id = ssc.id
+            WHERE s.transaction_id = t.id))
+    FROM transactions t
+    LEFT JOIN transaction_categories tc ON t.transaction_category_id = tc.id
+    LEFT JOIN transaction_sub_categories tsc ON t.transaction_sub_category_id = tsc.id
+    WHERE t.id = p_transaction_id
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION transaction_search_vector(p_transaction_id int) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(transaction_search_notes(p_transaction_id), '')), 'A')
+        || setweight(to_tsvector('simple', COALESCE(transaction_search_categories(p_transaction_id), '')), 'B')
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION transaction_categories_refresh_search() RETURNS trigger AS $$
+BEGIN
+    UPDATE transactions SET search_vector = transaction_search_vector(id)
+    WHERE transaction_category_id = NEW.id
+        OR id IN (SELECT transaction_id FROM transaction_splits WHERE transaction_category_id = NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE FUNCTION transaction_sub_categories_refresh_search() RETURNS trigger AS $$
+BEGIN
+    UPDATE transactions SET search_vector = transaction_search_vector(id)
+    WHERE transaction_sub_category_id = NEW.id
+        OR id IN (SELECT transaction_id FROM transaction_splits WHERE transaction_sub_category_id = NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+DROP FUNCTION transaction_category_path(int);
+DROP FUNCTION transaction_category_subtree(int);
+
+CREATE TRIGGER trg_transaction_categories_refresh_search
+AFTER UPDATE OF name ON transaction_categories
+FOR EACH ROW EXECUTE FUNCTION transaction_categories_refresh_search();
+
+CREATE TRIGGER trg_transaction_sub_categories_refresh_search
+AFTER UPDATE OF name ON transaction_sub_categories
+FOR EACH ROW EXECUTE FUNCTION transaction_sub_categories_refresh_search();
+
+CREATE TRIGGER trg_transactions_refresh_search
+AFTER INSERT OR UPDATE OF notes, transaction_category_id, transaction_sub_category_id ON transactions
+FOR EACH ROW EXECUTE FUNCTION transactions_refresh_search();
+
+CREATE TRIGGER trg_transaction_splits_refresh_search
+AFTER INSERT OR UPDATE OR DELETE ON transaction_splits
+FOR EACH ROW EXECUTE FUNCTION transaction_splits_refresh_search();
+
+UPDATE transactions SET search_vector = transaction_search_vector(id);
@@ -0,0 +1,27 @@
+-- +migrate Up
+-- A category takes income, expenses or both. Existing categories get the one kind they were used
+-- for so far, counting splits and recurring templates, and both when they were used for neither
+-- or for each.
+CREATE TYPE transaction_category_kind_enum AS ENUM ('income', 'expense', 'both');
+ALTER TABLE transaction_categories ADD COLUMN kind transaction_category_kind_enum NOT NULL DEFAULT 'both';
+
+-- +migrate StatementBegin
+WITH usage AS (
+    SELECT transaction_category_id AS id, transaction_type::text AS transaction_type FROM transactions
+    WHERE transaction_category_id IS NOT NULL AND transaction_type IN ('income', 'expense')
+    UNION
+    SELECT s.transaction_category_id, t.transaction_type::text FROM transaction_splits s
+    INNER JOIN transactions t ON t.id = s.transaction_id
+    UNION
+    SELECT transaction_category_id, transaction_type::text FROM recurring_transactions
+    WHERE transaction_type IN ('income', 'expense')
+), kinds AS (
+    SELECT id, MIN(transaction_type) AS transaction_type FROM usage GROUP BY id HAVING COUNT(DISTINCT transaction_type) = 1
+)
+UPDATE transaction_categories c SET kind = k.transaction_type::transaction_category_kind_enum
+FROM kinds k WHERE k.id = c.id;
+-- +migrate StatementEnd
+
+-- +migrate Down
+ALTER TABLE transaction_categories DROP COLUMN kind;
+DROP TYPE transaction_category_kind_enum;
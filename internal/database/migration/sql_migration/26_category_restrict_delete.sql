@@ -0,0 +1,28 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Deleting a category used to cascade to its transactions and recurring templates, dropping them
+-- without a ledger reversal. Entries now have to be reassigned first; see
+-- TransactionCategoryService.Delete and Merge.
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_category_id_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_category_id_fkey
+    FOREIGN KEY (transaction_category_id) REFERENCES transaction_categories(id) ON DELETE RESTRICT;
+
+ALTER TABLE recurring_transactions DROP CONSTRAINT recurring_transactions_transaction_category_id_fkey;
+ALTER TABLE recurring_transactions ADD CONSTRAINT recurring_transactions_transaction_category_id_fkey
+    FOREIGN KEY (transaction_category_id) REFERENCES transaction_categories(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_ledger_entries_ledger_account ON ledger_entries (user_id, ledger_account);
+
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP INDEX idx_ledger_entries_ledger_account;
+
+ALTER TABLE recurring_transactions DROP CONSTRAINT recurring_transactions_transaction_category_id_fkey;
+ALTER TABLE recurring_transactions ADD CONSTRAINT recurring_transactions_transaction_category_id_fkey
+    FOREIGN KEY (transaction_category_id) REFERENCES transaction_categories(id) ON DELETE CASCADE;
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_transaction_category_id_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_category_id_fkey
+    FOREIGN KEY (transaction_category_id) REFERENCES transaction_categories(id) ON DELETE CASCADE;
@@ -0,0 +1,19 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Archived categories keep their history but drop out of category listings. Sub-categories are
+-- child categories since 24_category_tree, so they are archived the same way.
+ALTER TABLE transaction_categories ADD COLUMN archived_at TIMESTAMP;
+
+-- Deleted transactions stay in the trash, reversed in the ledger, until they are restored or
+-- purged.
+ALTER TABLE transactions ADD COLUMN deleted_at TIMESTAMP;
+CREATE INDEX idx_transactions_deleted_at ON transactions (user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP INDEX idx_transactions_deleted_at;
+DELETE FROM transactions WHERE deleted_at IS NOT NULL;
+ALTER TABLE transactions DROP COLUMN deleted_at;
+ALTER TABLE transaction_categories DROP COLUMN archived_at;
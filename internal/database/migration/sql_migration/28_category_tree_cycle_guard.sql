@@ -0,0 +1,52 @@
+-- +migrate Up
+-- The category tree walks stop at a category they have already visited, so a parent_id cycle can
+-- no longer make every listing of its owner run forever. TransactionCategoryService keeps cycles
+-- from being written in the first place.
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION transaction_category_subtree(p_category_id int) RETURNS SETOF int AS $$
+    WITH RECURSIVE subtree AS (
+        SELECT id FROM transaction_categories WHERE id = p_category_id
+        UNION
+        SELECT c.id FROM transaction_categories c INNER JOIN subtree s ON c.parent_id = s.id
+    )
+    SELECT id FROM subtree
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION transaction_category_path(p_category_id int) RETURNS text AS $$
+    WITH RECURSIVE path AS (
+        SELECT id, parent_id, name, 0 AS depth, ARRAY[id] AS visited
+        FROM transaction_categories WHERE id = p_category_id
+        UNION ALL
+        SELECT c.id, c.parent_id, c.name, p.depth + 1, p.visited || c.id
+        FROM transaction_categories c INNER JOIN path p ON c.id = p.parent_id
+        WHERE NOT c.id = ANY(p.visited)
+    )
+    SELECT string_agg(name, ':' ORDER BY depth DESC) FROM path
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate Down
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION transaction_category_subtree(p_category_id int) RETURNS SETOF int AS $$
+    WITH RECURSIVE subtree AS (
+        SELECT id FROM transaction_categories WHERE id = p_category_id
+        UNION ALL
+        SELECT c.id FROM transaction_categories c INNER JOIN subtree s ON c.parent_id = s.id
+    )
+    SELECT id FROM subtree
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION transaction_category_path(p_category_id int) RETURNS text AS $$
+    WITH RECURSIVE path AS (
+        SELECT id, parent_id, name, 0 AS depth FROM transaction_categories WHERE id = p_category_id
+        UNION ALL
+        SELECT c.id, c.parent_id, c.name, p.depth + 1 FROM transaction_categories c INNER JOIN path p ON c.id = p.parent_id
+    )
+    SELECT string_agg(name, ':' ORDER BY depth DESC) FROM path
+$$ LANGUAGE sql STABLE;
+-- +migrate StatementEnd
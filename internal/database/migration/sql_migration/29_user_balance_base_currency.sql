@@ -0,0 +1,9 @@
+-- +migrate Up
+-- users.balance only sums the accounts held in the user's base currency; amounts in other
+-- currencies cannot be added to it without a rate.
+UPDATE users u SET balance = COALESCE((
+    SELECT SUM(a.balance) FROM accounts a WHERE a.user_id = u.id AND a.currency = u.base_currency
+), 0);
+
+-- +migrate Down
+UPDATE users u SET balance = COALESCE((SELECT SUM(a.balance) FROM accounts a WHERE a.user_id = u.id), 0);
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- Deleted transfers go to the trash with their legs, reversed in the ledger, until they are
+-- restored or purged, the same as transactions since 27_archive_soft_delete.
+ALTER TABLE transfers ADD COLUMN deleted_at TIMESTAMP;
+CREATE INDEX idx_transfers_deleted_at ON transfers (user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP INDEX idx_transfers_deleted_at;
+DELETE FROM transfers WHERE deleted_at IS NOT NULL;
+ALTER TABLE transfers DROP COLUMN deleted_at;
@@ -0,0 +1,33 @@
+-- +migrate Up
+CREATE TYPE account_type_enum AS ENUM ('cash', 'bank', 'e_wallet', 'credit_card');
+-- +migrate StatementBegin
+
+CREATE TABLE accounts (
+    id SERIAL PRIMARY KEY,
+    user_id uuid NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    account_type account_type_enum NOT NULL,
+    balance BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP,
+    updated_by VARCHAR(255),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +migrate StatementEnd
+
+-- Every existing user gets a cash account holding their current balance so old transactions have a home.
+INSERT INTO accounts (user_id, name, account_type, balance, created_by)
+SELECT id, 'Cash', 'cash', COALESCE(balance, 0), 'SYSTEM' FROM users;
+
+ALTER TABLE transactions ADD COLUMN account_id int REFERENCES accounts(id);
+
+UPDATE transactions t SET account_id = a.id FROM accounts a WHERE a.user_id = t.user_id;
+
+ALTER TABLE transactions ALTER COLUMN account_id SET NOT NULL;
+
+-- +migrate Down
+ALTER TABLE transactions DROP COLUMN account_id;
+DROP TABLE accounts;
+DROP TYPE account_type_enum;
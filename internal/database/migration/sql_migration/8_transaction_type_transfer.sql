@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+ALTER TYPE transaction_type_enum ADD VALUE IF NOT EXISTS 'transfer';
+
+-- +migrate Down
+-- Postgres cannot drop a value from an enum; 'transfer' rows are removed by 9_transfer.sql's down migration.
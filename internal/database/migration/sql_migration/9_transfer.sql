@@ -0,0 +1,38 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+CREATE TABLE transfers (
+    id SERIAL PRIMARY KEY,
+    user_id uuid NOT NULL,
+    from_account_id int NOT NULL,
+    to_account_id int NOT NULL,
+    amount BIGINT NOT NULL,
+    fee BIGINT NOT NULL DEFAULT 0,
+    transfer_date DATE NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR(255),
+    updated_at TIMESTAMP,
+    updated_by VARCHAR(255),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (from_account_id) REFERENCES accounts(id),
+    FOREIGN KEY (to_account_id) REFERENCES accounts(id),
+    CHECK (from_account_id <> to_account_id)
+);
+
+-- +migrate StatementEnd
+
+-- Transfer legs have no category; the fee leg is a regular expense and keeps one.
+ALTER TABLE transactions ALTER COLUMN transaction_category_id DROP NOT NULL;
+ALTER TABLE transactions ADD COLUMN transfer_id int REFERENCES transfers(id) ON DELETE CASCADE;
+ALTER TABLE transactions ADD COLUMN transfer_direction VARCHAR(3) CHECK (transfer_direction IN ('out', 'in'));
+
+CREATE INDEX idx_transactions_transfer_id ON transactions(transfer_id);
+
+-- +migrate Down
+DELETE FROM transactions WHERE transfer_id IS NOT NULL;
+DROP INDEX idx_transactions_transfer_id;
+ALTER TABLE transactions DROP COLUMN transfer_direction;
+ALTER TABLE transactions DROP COLUMN transfer_id;
+ALTER TABLE transactions ALTER COLUMN transaction_category_id SET NOT NULL;
+DROP TABLE transfers;
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/google/uuid"
+)
+
+const (
+	AccountTypeCash       = "cash"
+	AccountTypeBank       = "bank"
+	AccountTypeEWallet    = "e_wallet"
+	AccountTypeCreditCard = "credit_card"
+)
+
+type Account struct {
+	ID          int        `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Name        string     `json:"name"`
+	AccountType string     `json:"account_type"`
+	Currency    string     `json:"currency"`
+	Balance     int64      `json:"balance"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	CreatedBy   string     `json:"created_by"`
+	UpdatedBy   *string    `json:"updated_by"`
+}
+
+type AccountRepository interface {
+	FindByID(id int) (*Account, error)
+	FindByIDTx(tx *sql.Tx, id int) (*Account, error)
+	FindByUserID(userID uuid.UUID) ([]Account, error)
+	FindByUserIDTx(tx *sql.Tx, userID uuid.UUID) ([]Account, error)
+	CountTransactions(id int) (int, error)
+	Create(tx *sql.Tx, account *Account) (*Account, error)
+	Update(account *Account) (*Account, error)
+	UpdateBalanceTx(tx *sql.Tx, account *Account) (*Account, error)
+	Delete(id int) error
+}
+
+type AccountUseCase interface {
+	FindByID(id int, userID uuid.UUID) (*dto.AccountDto, error)
+	FindByUserID(userID uuid.UUID) ([]dto.AccountDto, error)
+	Create(req dto.CreateAccountDto, userID uuid.UUID) (*dto.AccountDto, error)
+	Update(req dto.UpdateAccountDto, id int, userID uuid.UUID) (*dto.AccountDto, error)
+	Delete(id int, userID uuid.UUID) error
+	FindLedgerEntries(id int, userID uuid.UUID, params dto.GetLedgerParams) (dto.PaginationResponse[dto.LedgerEntryDto], error)
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/google/uuid"
+)
+
+const (
+	BudgetPeriodWeekly  = "weekly"
+	BudgetPeriodMonthly = "monthly"
+	BudgetPeriodYearly  = "yearly"
+
+	RolloverNone   = "none"
+	RolloverFull   = "full"
+	RolloverCapped = "capped"
+)
+
+// Budget caps spending in a category and every category below it, for every period between
+// StartDate and EndDate. Periods are calendar weeks (starting Monday), months or years.
+//
+// Budgets double as envelopes: Amount is only the target of a period, which is funded by the
+// allocations moved in from the unassigned pool, and whatever is left at the end of a period rolls
+// into the next one according to Rollover.
+type Budget struct {
+	ID          int        `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	CategoryID  int        `json:"category_id"`
+	Amount      int64      `json:"amount"`
+	Period      string     `json:"period"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+	Rollover    string     `json:"rollover"`
+	RolloverCap *int64     `json:"rollover_cap"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	CreatedBy   string     `json:"created_by"`
+	UpdatedBy   *string    `json:"updated_by"`
+}
+
+// BudgetAllocation moves money into (positive Amount) or out of (negative Amount) the envelope
+// of a budget for the period starting at PeriodStart.
+type BudgetAllocation struct {
+	ID          int       `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	BudgetID    int       `json:"budget_id"`
+	PeriodStart time.Time `json:"period_start"`
+	Amount      int64     `json:"amount"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   string    `json:"created_by"`
+}
+
+// BudgetPeriod is the state of a budget's envelope for one period. Budgeted is the income
+// assigned to the period, i.e. its allocations, RolloverIn what was carried over from the previous one, and Released
+// the part of Remaining that does not roll over and goes back to the unassigned pool once the
+// period has ended. Overspending is released as a negative amount, i.e. covered by the pool.
+type BudgetPeriod struct {
+	Start      time.Time
+	End        time.Time
+	Allocated  int64
+	Budgeted   int64
+	RolloverIn int64
+	Spent      int64
+	Remaining  int64
+	Released   int64
+}
+
+// PeriodBounds returns the first and last day of the budget period that contains date.
+func (b *Budget) PeriodBounds(date time.Time) (time.Time, time.Time) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	switch b.Period {
+	case BudgetPeriodWeekly:
+		offset := (int(day.Weekday()) + 6) % 7
+		start := day.AddDate(0, 0, -offset)
+		return start, start.AddDate(0, 0, 6)
+	case BudgetPeriodYearly:
+		start := time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, -1)
+	default:
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, -1)
+	}
+}
+
+// Timeline replays every period from the one containing StartDate up to the one containing asOf
+// (or EndDate, if earlier), carrying leftovers forward. spent and allocated are keyed by period start.
+func (b *Budget) Timeline(asOf time.Time, spent map[time.Time]int64, allocated map[time.Time]int64) []BudgetPeriod {
+	var periods []BudgetPeriod
+	var carry int64
+	start, end := b.PeriodBounds(b.StartDate)
+	for !start.After(asOf) && (b.EndDate == nil || !start.After(*b.EndDate)) {
+		period := BudgetPeriod{
+			Start:      start,
+			End:        end,
+			Allocated:  allocated[start],
+			RolloverIn: carry,
+			Spent:      spent[start],
+		}
+		period.Budgeted = period.Allocated
+		period.Remaining = period.Budgeted + period.RolloverIn - period.Spent
+		carry = b.carryOver(period.Remaining)
+		period.Released = period.Remaining - carry
+		periods = append(periods, period)
+
+		start, end = b.PeriodBounds(end.AddDate(0, 0, 1))
+	}
+	return periods
+}
+
+// carryOver applies the rollover rule to what is left at the end of a period. Only unspent money
+// rolls; overspending is never carried into the next period.
+func (b *Budget) carryOver(remaining int64) int64 {
+	if remaining <= 0 {
+		return 0
+	}
+	switch b.Rollover {
+	case RolloverFull:
+		return remaining
+	case RolloverCapped:
+		if b.RolloverCap != nil && remaining > *b.RolloverCap {
+			return *b.RolloverCap
+		}
+		return remaining
+	default:
+		return 0
+	}
+}
+
+type BudgetRepository interface {
+	FindByID(id int) (*Budget, error)
+	FindByUserID(userID uuid.UUID) ([]Budget, error)
+	Create(budget *Budget) (*Budget, error)
+	Update(budget *Budget) (*Budget, error)
+	Delete(id int) error
+	SumSpentByPeriod(budget *Budget, from time.Time, to time.Time) (map[time.Time]int64, error)
+	SumAllocatedByPeriod(budget *Budget, to time.Time) (map[time.Time]int64, error)
+	SumIncome(userID uuid.UUID, to time.Time) (int64, error)
+	CreateAllocation(tx *sql.Tx, allocation *BudgetAllocation) (*BudgetAllocation, error)
+}
+
+type BudgetUseCase interface {
+	FindByID(id int, userID uuid.UUID, params dto.GetBudgetParams) (*dto.BudgetDto, error)
+	FindByUserID(userID uuid.UUID, params dto.GetBudgetParams) ([]dto.BudgetDto, error)
+	Create(req dto.CreateBudgetDto, userID uuid.UUID) (*dto.BudgetDto, error)
+	Update(req dto.UpdateBudgetDto, id int, userID uuid.UUID) (*dto.BudgetDto, error)
+	Delete(id int, userID uuid.UUID) error
+	FindEnvelopes(userID uuid.UUID, params dto.GetBudgetParams) (*dto.EnvelopeSummaryDto, error)
+	MoveAllocation(req dto.MoveAllocationDto, userID uuid.UUID) (*dto.EnvelopeSummaryDto, error)
+}
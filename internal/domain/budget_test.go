@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func date(value string) time.Time {
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return day
+}
+
+func TestBudgetCarryOver(t *testing.T) {
+	limit := int64(200)
+	tests := []struct {
+		name        string
+		rollover    string
+		rolloverCap *int64
+		remaining   int64
+		want        int64
+	}{
+		{"none keeps nothing", RolloverNone, nil, 300, 0},
+		{"unknown rule keeps nothing", "", nil, 300, 0},
+		{"full keeps everything", RolloverFull, nil, 300, 300},
+		{"capped keeps up to the cap", RolloverCapped, &limit, 300, 200},
+		{"capped below the cap keeps everything", RolloverCapped, &limit, 150, 150},
+		{"capped at the cap", RolloverCapped, &limit, 200, 200},
+		{"capped without a cap keeps everything", RolloverCapped, nil, 300, 300},
+		{"nothing left", RolloverFull, nil, 0, 0},
+		{"overspending is never carried", RolloverFull, nil, -100, 0},
+		{"overspending is never carried when capped", RolloverCapped, &limit, -100, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := &Budget{Rollover: tt.rollover, RolloverCap: tt.rolloverCap}
+			if got := budget.carryOver(tt.remaining); got != tt.want {
+				t.Errorf("carryOver(%d) = %d, want %d", tt.remaining, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBudgetPeriodBounds(t *testing.T) {
+	tests := []struct {
+		period    string
+		day       string
+		wantStart string
+		wantEnd   string
+	}{
+		{BudgetPeriodWeekly, "2026-01-07", "2026-01-05", "2026-01-11"},
+		{BudgetPeriodWeekly, "2026-01-05", "2026-01-05", "2026-01-11"},
+		{BudgetPeriodWeekly, "2026-01-11", "2026-01-05", "2026-01-11"},
+		{BudgetPeriodWeekly, "2026-01-01", "2025-12-29", "2026-01-04"},
+		{BudgetPeriodMonthly, "2026-02-14", "2026-02-01", "2026-02-28"},
+		{BudgetPeriodMonthly, "2028-02-29", "2028-02-01", "2028-02-29"},
+		{BudgetPeriodMonthly, "2026-12-31", "2026-12-01", "2026-12-31"},
+		{BudgetPeriodYearly, "2026-07-04", "2026-01-01", "2026-12-31"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period+" "+tt.day, func(t *testing.T) {
+			budget := &Budget{Period: tt.period}
+			start, end := budget.PeriodBounds(date(tt.day))
+			if !start.Equal(date(tt.wantStart)) || !end.Equal(date(tt.wantEnd)) {
+				t.Errorf("got %s..%s, want %s..%s", start.Format("2006-01-02"), end.Format("2006-01-02"), tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestBudgetTimeline(t *testing.T) {
+	limit := int64(200)
+	endDate := date("2026-02-10")
+	monthly := func(rollover string, rolloverCap *int64, end *time.Time) *Budget {
+		return &Budget{
+			Amount:      1000,
+			Period:      BudgetPeriodMonthly,
+			StartDate:   date("2026-01-15"),
+			EndDate:     end,
+			Rollover:    rollover,
+			RolloverCap: rolloverCap,
+		}
+	}
+	allocated := map[time.Time]int64{date("2026-01-01"): 1000, date("2026-02-01"): 500}
+	spent := map[time.Time]int64{date("2026-01-01"): 700, date("2026-02-01"): 900, date("2026-03-01"): 100}
+	period := func(start, end string, allocated, rolloverIn, spent, released int64) BudgetPeriod {
+		remaining := allocated + rolloverIn - spent
+		return BudgetPeriod{
+			Start:      date(start),
+			End:        date(end),
+			Allocated:  allocated,
+			Budgeted:   allocated,
+			RolloverIn: rolloverIn,
+			Spent:      spent,
+			Remaining:  remaining,
+			Released:   released,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		budget    *Budget
+		asOf      string
+		allocated map[time.Time]int64
+		spent     map[time.Time]int64
+		want      []BudgetPeriod
+	}{
+		{
+			name:   "without rollover leftovers are released",
+			budget: monthly(RolloverNone, nil, nil), asOf: "2026-03-10", allocated: allocated, spent: spent,
+			want: []BudgetPeriod{
+				period("2026-01-01", "2026-01-31", 1000, 0, 700, 300),
+				period("2026-02-01", "2026-02-28", 500, 0, 900, -400),
+				period("2026-03-01", "2026-03-31", 0, 0, 100, -100),
+			},
+		},
+		{
+			name:   "full rollover carries leftovers but not overspending",
+			budget: monthly(RolloverFull, nil, nil), asOf: "2026-03-10", allocated: allocated, spent: spent,
+			want: []BudgetPeriod{
+				period("2026-01-01", "2026-01-31", 1000, 0, 700, 0),
+				period("2026-02-01", "2026-02-28", 500, 300, 900, -100),
+				period("2026-03-01", "2026-03-31", 0, 0, 100, -100),
+			},
+		},
+		{
+			name:   "capped rollover releases what is above the cap",
+			budget: monthly(RolloverCapped, &limit, nil), asOf: "2026-03-10", allocated: allocated, spent: spent,
+			want: []BudgetPeriod{
+				period("2026-01-01", "2026-01-31", 1000, 0, 700, 100),
+				period("2026-02-01", "2026-02-28", 500, 200, 900, -200),
+				period("2026-03-01", "2026-03-31", 0, 0, 100, -100),
+			},
+		},
+		{
+			name:   "the amount is a target and funds nothing",
+			budget: monthly(RolloverFull, nil, nil), asOf: "2026-01-20", allocated: nil, spent: nil,
+			want: []BudgetPeriod{
+				period("2026-01-01", "2026-01-31", 0, 0, 0, 0),
+			},
+		},
+		{
+			name:   "stops at the end date",
+			budget: monthly(RolloverFull, nil, &endDate), asOf: "2026-05-01", allocated: allocated, spent: spent,
+			want: []BudgetPeriod{
+				period("2026-01-01", "2026-01-31", 1000, 0, 700, 0),
+				period("2026-02-01", "2026-02-28", 500, 300, 900, -100),
+			},
+		},
+		{
+			name:   "nothing before the start date",
+			budget: monthly(RolloverFull, nil, nil), asOf: "2025-12-31", allocated: allocated, spent: spent,
+			want: nil,
+		},
+		{
+			name: "weekly periods start on Monday",
+			budget: &Budget{
+				Period:    BudgetPeriodWeekly,
+				StartDate: date("2026-01-07"),
+				Rollover:  RolloverFull,
+			},
+			asOf:      "2026-01-20",
+			allocated: map[time.Time]int64{date("2026-01-05"): 100, date("2026-01-19"): 50},
+			spent:     map[time.Time]int64{date("2026-01-12"): 30},
+			want: []BudgetPeriod{
+				period("2026-01-05", "2026-01-11", 100, 0, 0, 0),
+				period("2026-01-12", "2026-01-18", 0, 100, 30, 0),
+				period("2026-01-19", "2026-01-25", 50, 70, 0, 0),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.budget.Timeline(date(tt.asOf), tt.spent, tt.allocated)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
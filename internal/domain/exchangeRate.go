@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"database/sql"
+	"io"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/google/uuid"
+)
+
+// ExchangeRate says one unit of FromCurrency is worth Rate units of ToCurrency from EffectiveDate
+// until the next rate recorded for the pair. Rate is kept as the exact decimal string Postgres
+// stores; amounts are converted in SQL by convert_amount.
+type ExchangeRate struct {
+	ID            int        `json:"id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	FromCurrency  string     `json:"from_currency"`
+	ToCurrency    string     `json:"to_currency"`
+	Rate          string     `json:"rate"`
+	EffectiveDate time.Time  `json:"effective_date"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+	CreatedBy     string     `json:"created_by"`
+	UpdatedBy     *string    `json:"updated_by"`
+}
+
+type ExchangeRateRepository interface {
+	FindByID(id int) (*ExchangeRate, error)
+	FindByUserID(userID uuid.UUID, currency *string) ([]ExchangeRate, error)
+	FindByPair(userID uuid.UUID, fromCurrency string, toCurrency string, effectiveDate time.Time) (*ExchangeRate, error)
+	Upsert(tx *sql.Tx, rate *ExchangeRate) (*ExchangeRate, error)
+	Update(rate *ExchangeRate) (*ExchangeRate, error)
+	Delete(id int) error
+	// Convert returns amount in toCurrency at the rate in effect on date, or nil without a rate.
+	Convert(userID uuid.UUID, amount int64, fromCurrency string, toCurrency string, date time.Time) (*int64, error)
+}
+
+type ExchangeRateUseCase interface {
+	FindByID(id int, userID uuid.UUID) (*dto.ExchangeRateDto, error)
+	FindByUserID(params dto.GetExchangeRateParams, userID uuid.UUID) ([]dto.ExchangeRateDto, error)
+	Create(req dto.CreateExchangeRateDto, userID uuid.UUID) (*dto.ExchangeRateDto, error)
+	Update(req dto.UpdateExchangeRateDto, id int, userID uuid.UUID) (*dto.ExchangeRateDto, error)
+	Delete(id int, userID uuid.UUID) error
+	ImportCSV(file io.Reader, userID uuid.UUID) (*dto.ExchangeRateImportDto, error)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"io"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/google/uuid"
+)
+
+// ImportProfile tells the CSV importer where a bank keeps each field. Column references are header
+// names, or 1-based positions when HasHeader is false. Without a TypeColumn the sign of the amount
+// decides: negative amounts are expenses.
+type ImportProfile struct {
+	ID                int        `json:"id"`
+	UserID            uuid.UUID  `json:"user_id"`
+	Name              string     `json:"name"`
+	Delimiter         string     `json:"delimiter"`
+	HasHeader         bool       `json:"has_header"`
+	DateColumn        string     `json:"date_column"`
+	DateFormat        string     `json:"date_format"`
+	AmountColumn      string     `json:"amount_column"`
+	DecimalSeparator  string     `json:"decimal_separator"`
+	TypeColumn        *string    `json:"type_column"`
+	IncomeValue       *string    `json:"income_value"`
+	ExpenseValue      *string    `json:"expense_value"`
+	NoteColumn        *string    `json:"note_column"`
+	CategoryColumn    *string    `json:"category_column"`
+	DefaultCategoryID *int       `json:"default_category_id"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         *time.Time `json:"updated_at"`
+	CreatedBy         string     `json:"created_by"`
+	UpdatedBy         *string    `json:"updated_by"`
+}
+
+type ImportProfileRepository interface {
+	FindByID(id int) (*ImportProfile, error)
+	FindByUserID(userID uuid.UUID) ([]ImportProfile, error)
+	Create(profile *ImportProfile) (*ImportProfile, error)
+	Update(profile *ImportProfile) (*ImportProfile, error)
+	Delete(id int) error
+}
+
+type ImportUseCase interface {
+	FindProfileByID(id int, userID uuid.UUID) (*dto.ImportProfileDto, error)
+	FindProfilesByUserID(userID uuid.UUID) ([]dto.ImportProfileDto, error)
+	CreateProfile(req dto.CreateImportProfileDto, userID uuid.UUID) (*dto.ImportProfileDto, error)
+	UpdateProfile(req dto.UpdateImportProfileDto, id int, userID uuid.UUID) (*dto.ImportProfileDto, error)
+	DeleteProfile(id int, userID uuid.UUID) error
+	ImportCSV(file io.Reader, req dto.ImportTransactionsDto, userID uuid.UUID) (*dto.ImportReportDto, error)
+	ImportOFX(file io.Reader, req dto.ImportTransactionsDto, userID uuid.UUID) (*dto.ImportReportDto, error)
+	ImportQIF(file io.Reader, req dto.ImportTransactionsDto, userID uuid.UUID) (*dto.ImportReportDto, error)
+}
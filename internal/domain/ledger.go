@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	LedgerEntryOpening     = "opening"
+	LedgerEntryTransaction = "transaction"
+	LedgerEntryTransfer    = "transfer"
+	LedgerEntryAdjustment  = "adjustment"
+	LedgerEntryReversal    = "reversal"
+
+	LedgerAccountOpeningBalances = "equity:opening-balances"
+	LedgerAccountAdjustments     = "equity:adjustments"
+)
+
+// LedgerEntry is one posting of a journal. Entries that share a JournalID sum to zero; those with
+// an AccountID move that account's balance.
+type LedgerEntry struct {
+	ID            int64     `json:"id"`
+	JournalID     uuid.UUID `json:"journal_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	AccountID     *int      `json:"account_id"`
+	LedgerAccount string    `json:"ledger_account"`
+	EntryType     string    `json:"entry_type"`
+	Amount        int64     `json:"amount"`
+	TransactionID *int      `json:"transaction_id"`
+	TransferID    *int      `json:"transfer_id"`
+	Description   *string   `json:"description"`
+	CreatedAt     time.Time `json:"created_at"`
+	CreatedBy     string    `json:"created_by"`
+}
+
+func AssetLedgerAccount(accountID int) string {
+	return fmt.Sprintf("assets:account:%d", accountID)
+}
+
+func IncomeLedgerAccount(categoryID int) string {
+	return fmt.Sprintf("income:category:%d", categoryID)
+}
+
+func ExpenseLedgerAccount(categoryID int) string {
+	return fmt.Sprintf("expenses:category:%d", categoryID)
+}
+
+type LedgerRepository interface {
+	Create(tx *sql.Tx, entry *LedgerEntry) (*LedgerEntry, error)
+	FindByAccountID(accountID int, limit int, offset int) ([]LedgerEntry, error)
+	CountByAccountID(accountID int) (int, error)
+	SumByAccountIDTx(tx *sql.Tx, accountID int) (int64, error)
+	SumByLedgerAccountTx(tx *sql.Tx, userID uuid.UUID, ledgerAccount string) (int64, error)
+	FindEquityEntriesByUserID(userID uuid.UUID) ([]LedgerEntry, error)
+}
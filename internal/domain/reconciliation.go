@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/google/uuid"
+)
+
+const (
+	LedgerEntryReconciliation = "reconciliation"
+
+	LedgerAccountReconciliation = "equity:reconciliation"
+)
+
+// AccountBalanceCheck compares what is stored for an account with what its history says it should hold.
+// ExpectedBalance is rebuilt from transactions plus opening balances and manual adjustments.
+type AccountBalanceCheck struct {
+	UserID      uuid.UUID
+	AccountID   int
+	AccountName string
+	// InBaseCurrency is set when the account is held in the user's base currency and so counts
+	// towards users.balance.
+	InBaseCurrency  bool
+	StoredBalance   int64
+	LedgerBalance   int64
+	ExpectedBalance int64
+}
+
+type UserBalanceCheck struct {
+	UserID        uuid.UUID
+	Username      string
+	StoredBalance int64
+}
+
+type ReconciliationRepository interface {
+	FindAccountChecks(userID *uuid.UUID) ([]AccountBalanceCheck, error)
+	FindAccountChecksTx(tx *sql.Tx, userID *uuid.UUID) ([]AccountBalanceCheck, error)
+	FindUserChecks(userID *uuid.UUID) ([]UserBalanceCheck, error)
+	FindUserChecksTx(tx *sql.Tx, userID *uuid.UUID) ([]UserBalanceCheck, error)
+}
+
+type ReconciliationUseCase interface {
+	Run(userID *uuid.UUID, fix bool) (*dto.ReconciliationReportDto, error)
+}
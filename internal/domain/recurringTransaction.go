@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/google/uuid"
+)
+
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyYearly  = "yearly"
+)
+
+// RecurringTransaction is a template the scheduler turns into a Transaction on every occurrence
+// between StartDate and EndDate. Occurrences counts how many have been handled so far and
+// NextRunDate is the date of the next one.
+type RecurringTransaction struct {
+	ID              int        `json:"id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	AccountID       int        `json:"account_id"`
+	CategoryID      int        `json:"category_id"`
+	TransactionType string     `json:"transaction_type"`
+	Amount          int64      `json:"amount"`
+	Notes           *string    `json:"note"`
+	Frequency       string     `json:"frequency"`
+	IntervalCount   int        `json:"interval_count"`
+	DayOfMonth      *int       `json:"day_of_month"`
+	StartDate       time.Time  `json:"start_date"`
+	EndDate         *time.Time `json:"end_date"`
+	Occurrences     int        `json:"occurrences"`
+	NextRunDate     time.Time  `json:"next_run_date"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       *time.Time `json:"updated_at"`
+	CreatedBy       string     `json:"created_by"`
+	UpdatedBy       *string    `json:"updated_by"`
+}
+
+// OccurrenceDate returns the date of the n-th occurrence, counting from 0 at StartDate. Monthly
+// rules fall on DayOfMonth (or the start day), clamped to the last day of shorter months; every
+// date is computed from StartDate so clamping never drifts.
+func (r *RecurringTransaction) OccurrenceDate(n int) time.Time {
+	step := n * r.IntervalCount
+	start := r.StartDate
+	switch r.Frequency {
+	case FrequencyDaily:
+		return start.AddDate(0, 0, step)
+	case FrequencyWeekly:
+		return start.AddDate(0, 0, 7*step)
+	case FrequencyMonthly:
+		day := start.Day()
+		if r.DayOfMonth != nil {
+			day = *r.DayOfMonth
+		}
+		return clampedDate(start.Year(), start.Month()+time.Month(step), day)
+	default:
+		return clampedDate(start.Year()+step, start.Month(), start.Day())
+	}
+}
+
+// Reschedule points NextRunDate at the first occurrence on or after from, skipping earlier ones.
+func (r *RecurringTransaction) Reschedule(from time.Time) {
+	r.Occurrences = 0
+	for r.OccurrenceDate(r.Occurrences).Before(from) {
+		r.Occurrences++
+	}
+	r.NextRunDate = r.OccurrenceDate(r.Occurrences)
+}
+
+// Finished reports whether the next occurrence falls after EndDate.
+func (r *RecurringTransaction) Finished() bool {
+	return r.EndDate != nil && r.NextRunDate.After(*r.EndDate)
+}
+
+func clampedDate(year int, month time.Month, day int) time.Time {
+	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+type RecurringTransactionRepository interface {
+	FindByID(id int) (*RecurringTransaction, error)
+	FindByUserID(userID uuid.UUID) ([]RecurringTransaction, error)
+	FindDue(date time.Time) ([]RecurringTransaction, error)
+	Create(recurring *RecurringTransaction) (*RecurringTransaction, error)
+	Update(recurring *RecurringTransaction) (*RecurringTransaction, error)
+	UpdateSchedule(recurring *RecurringTransaction) error
+	Delete(id int) error
+}
+
+type RecurringTransactionUseCase interface {
+	FindByID(id int, userID uuid.UUID) (*dto.RecurringTransactionDto, error)
+	FindByUserID(userID uuid.UUID) ([]dto.RecurringTransactionDto, error)
+	Create(req dto.CreateRecurringTransactionDto, userID uuid.UUID) (*dto.RecurringTransactionDto, error)
+	Update(req dto.UpdateRecurringTransactionDto, id int, userID uuid.UUID) (*dto.RecurringTransactionDto, error)
+	Delete(id int, userID uuid.UUID) error
+	RunDue(today time.Time) (int, error)
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/google/uuid"
+)
+
+const (
+	ReportBucketDay   = "day"
+	ReportBucketWeek  = "week"
+	ReportBucketMonth = "month"
+	ReportBucketYear  = "year"
+)
+
+// ReportFilter selects the income and expense transactions a report aggregates. Transfer legs
+// only move money between accounts and are never counted. Amounts are converted into the user's
+// base currency.
+type ReportFilter struct {
+	UserID    uuid.UUID
+	StartDate time.Time
+	EndDate   time.Time
+	Bucket    string
+	AccountID *int
+}
+
+// ReportBucketTotal is the income and expense of one day, week, month or year.
+type ReportBucketTotal struct {
+	PeriodStart time.Time
+	Income      int64
+	Expense     int64
+}
+
+// ReportCategoryTotal is the income and expense booked directly against one category, leaving out
+// the categories below it. EntryCount is the number of transactions and splits behind the totals.
+type ReportCategoryTotal struct {
+	CategoryID int
+	Category   string
+	ParentID   *int
+	Income     int64
+	Expense    int64
+	EntryCount int
+}
+
+// ReportTagTotal is the income and expense of the transactions carrying one tag. A transaction
+// with several tags counts towards each of them.
+type ReportTagTotal struct {
+	TagID            int
+	Tag              string
+	Income           int64
+	Expense          int64
+	TransactionCount int
+}
+
+// MissingExchangeRate names a currency some transactions cannot be converted from, with the
+// earliest date a rate is needed for.
+type MissingExchangeRate struct {
+	Currency  string
+	FirstDate time.Time
+}
+
+type ReportRepository interface {
+	SumByBucket(filter ReportFilter) ([]ReportBucketTotal, error)
+	SumByCategory(filter ReportFilter) ([]ReportCategoryTotal, error)
+	SumByTag(filter ReportFilter) ([]ReportTagTotal, error)
+	FindMissingRates(filter ReportFilter) ([]MissingExchangeRate, error)
+}
+
+type ReportUseCase interface {
+	Summary(params dto.GetReportSummaryParams, userID uuid.UUID) (*dto.ReportSummaryDto, error)
+	ByTag(params dto.GetReportByTagParams, userID uuid.UUID) (*dto.ReportTagSummaryDto, error)
+}
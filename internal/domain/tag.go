@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/google/uuid"
+)
+
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
+// Tag is a user-defined label; unlike a category a transaction can carry several. Names are
+// unique per user regardless of case.
+type Tag struct {
+	ID        int        `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+	CreatedBy string     `json:"created_by"`
+	UpdatedBy *string    `json:"updated_by"`
+}
+
+type TagRepository interface {
+	FindByID(id int) (*Tag, error)
+	FindByIDs(userID uuid.UUID, ids []int) ([]Tag, error)
+	FindByName(userID uuid.UUID, name string) (*Tag, error)
+	FindByUserID(userID uuid.UUID) ([]Tag, error)
+	FindByTransactionID(transactionID int) ([]Tag, error)
+	Create(tag *Tag) (*Tag, error)
+	Update(tag *Tag) (*Tag, error)
+	Delete(id int) error
+	// SetTransactionTags replaces the tags of a transaction with tagIDs.
+	SetTransactionTags(tx *sql.Tx, transactionID int, tagIDs []int) error
+}
+
+type TagUseCase interface {
+	FindByID(id int, userID uuid.UUID) (*dto.TagDto, error)
+	FindByUserID(userID uuid.UUID) ([]dto.TagDto, error)
+	Create(req dto.CreateTagDto, userID uuid.UUID) (*dto.TagDto, error)
+	Update(req dto.UpdateTagDto, id int, userID uuid.UUID) (*dto.TagDto, error)
+	Delete(id int, userID uuid.UUID) error
+}
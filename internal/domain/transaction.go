@@ -2,40 +2,197 @@ package domain
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/dimas-pramantya/money-management/dto"
 	"github.com/google/uuid"
 )
 
+const (
+	TransactionTypeIncome  = "income"
+	TransactionTypeExpense = "expense"
+	TransactionTypeTransfer = "transfer"
+
+	TransferDirectionOut = "out"
+	TransferDirectionIn  = "in"
+
+	ExportFormatCSV       = "csv"
+	ExportFormatLedger    = "ledger"
+	ExportFormatHledger   = "hledger"
+	ExportFormatBeancount = "beancount"
+)
+
 type Transaction struct {
 	ID              int   `json:"id"`
-	Ammount          int64 `json:"amount"`
-	CategoryID      int   `json:"category_id"`
-	SubCategoryID   *int  `json:"sub_category_id"`
+	Amount          int64 `json:"amount"`
+	Currency        string `json:"currency"`
+	AccountID       int   `json:"account_id"`
+	CategoryID      *int  `json:"category_id"`
 	TransactionDate time.Time  `json:"transaction_date"`
 	TransactionType string `json:"transaction_type"`
 	Notes            *string `json:"note"`
+	TransferID      *int    `json:"transfer_id"`
+	TransferDirection *string `json:"transfer_direction"`
+	RecurringTransactionID *int `json:"recurring_transaction_id"`
+	RecurringDate   *time.Time `json:"recurring_date"`
+	ExternalID      *string `json:"external_id"`
 	UserID         	uuid.UUID `json:"user_id"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       *time.Time `json:"updated_at"`
 	CreatedBy       string `json:"created_by"`
 	UpdatedBy       *string `json:"updated_by"`
+	// DeletedAt is set while the transaction is in the trash.
+	DeletedAt       *time.Time `json:"deleted_at"`
+	// Splits spread the amount over several categories; CategoryID is nil then.
+	Splits          []TransactionSplit `json:"splits"`
+}
+
+// TransactionSplit is the share of a split transaction booked on one category. The amounts of a
+// transaction's splits add up to the transaction amount.
+type TransactionSplit struct {
+	ID            int     `json:"id"`
+	TransactionID int     `json:"transaction_id"`
+	CategoryID    int     `json:"category_id"`
+	Amount        int64   `json:"amount"`
+	Notes         *string `json:"note"`
+}
+
+// SignedAmount returns the amount as it affects the account balance: positive for income and
+// incoming transfer legs, negative for expense and outgoing transfer legs.
+func (t *Transaction) SignedAmount() int64 {
+	if t.TransactionType == TransactionTypeExpense {
+		return -t.Amount
+	}
+	if t.TransactionType == TransactionTypeTransfer && t.TransferDirection != nil && *t.TransferDirection == TransferDirectionOut {
+		return -t.Amount
+	}
+	return t.Amount
+}
+
+// Fields a transaction listing can be sorted by. Relevance only means something when searching.
+const (
+	TransactionSortDate      = "transaction_date"
+	TransactionSortAmount    = "amount"
+	TransactionSortCreatedAt = "created_at"
+	TransactionSortUpdatedAt = "updated_at"
+	TransactionSortAccount   = "account"
+	TransactionSortCategory  = "category"
+	TransactionSortRelevance = "relevance"
+)
+
+var transactionSortFields = map[string]bool{
+	TransactionSortDate:      true,
+	TransactionSortAmount:    true,
+	TransactionSortCreatedAt: true,
+	TransactionSortUpdatedAt: true,
+	TransactionSortAccount:   true,
+	TransactionSortCategory:  true,
+	TransactionSortRelevance: true,
+}
+
+// TransactionSort is one key of the order of a transaction listing.
+type TransactionSort struct {
+	Field      string
+	Descending bool
+}
+
+// ParseTransactionSort reads a sort parameter such as "amount:desc" or "category,transaction_date:desc":
+// comma-separated fields, each ascending unless followed by ":desc".
+func ParseTransactionSort(value string) ([]TransactionSort, error) {
+	var keys []TransactionSort
+	for _, part := range strings.Split(value, ",") {
+		field, direction, _ := strings.Cut(strings.TrimSpace(part), ":")
+		if !transactionSortFields[field] {
+			return nil, BadRequestError(fmt.Sprintf("Cannot sort transactions by %q", field), nil)
+		}
+		switch strings.ToLower(direction) {
+		case "", "asc":
+			keys = append(keys, TransactionSort{Field: field})
+		case "desc":
+			keys = append(keys, TransactionSort{Field: field, Descending: true})
+		default:
+			return nil, BadRequestError(fmt.Sprintf("Invalid sort direction %q, expected asc or desc", direction), nil)
+		}
+	}
+	return keys, nil
+}
+
+// TransactionCursor marks the last transaction of a page in cursor pagination, which pages on
+// (transaction_date, id) so rows inserted meanwhile never shift later pages.
+type TransactionCursor struct {
+	Date string `json:"d"`
+	ID   int    `json:"i"`
+}
+
+// Encode returns the opaque form of the cursor handed to clients.
+func (c TransactionCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeTransactionCursor reads a cursor made by Encode.
+func DecodeTransactionCursor(value string) (*TransactionCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, BadRequestError("Invalid cursor", nil)
+	}
+	cursor := &TransactionCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil || cursor.ID <= 0 {
+		return nil, BadRequestError("Invalid cursor", nil)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, cursor.Date); err != nil {
+		return nil, BadRequestError("Invalid cursor", nil)
+	}
+	return cursor, nil
 }
 
 type TransactionRepository interface {
 	FindByID(id int) (*Transaction, error)
+	FindByIDTx(tx *sql.Tx, id int) (*Transaction, error)
+	FindByTransferID(transferID int) ([]Transaction, error)
+	FindByTransferIDTx(tx *sql.Tx, transferID int) ([]Transaction, error)
+	ExistsByRecurrence(recurringTransactionID int, date time.Time) (bool, error)
+	ExistsByExternalID(accountID int, externalID string) (bool, error)
 	FindByFilter(params dto.GetTransactionParams) ([]dto.TransactionDto, error)
+	// FindByCursor returns up to params.Limit transactions matching params that come after the
+	// cursor, or from the start when it is nil, ordered by transaction date and id.
+	FindByCursor(params dto.GetTransactionParams, after *TransactionCursor, descending bool) ([]dto.TransactionDto, error)
 	CountByFilter(params dto.GetTransactionParams) (int, error)
+	StreamByFilter(params dto.GetTransactionParams, fn func(*dto.TransactionDto) error) error
 	Create(tx *sql.Tx, transaction *Transaction) (*Transaction, error)
 	Update(tx *sql.Tx, transaction *Transaction) (*Transaction, error)
 	Delete(tx *sql.Tx, id int, userID uuid.UUID) error
+	// SoftDelete moves a transaction to the trash; Restore takes it out again.
+	SoftDelete(tx *sql.Tx, id int, userID uuid.UUID) error
+	Restore(tx *sql.Tx, id int, userID uuid.UUID) (*Transaction, error)
+	// FindDeleted lists the user's trash, most recently deleted first.
+	FindDeleted(userID uuid.UUID, limit int, offset int) ([]dto.TransactionDto, error)
+	CountDeleted(userID uuid.UUID) (int, error)
+	// PurgeDeleted removes for good the transactions deleted before before, returning how many.
+	PurgeDeleted(before time.Time) (int64, error)
+	FindSplitsByTransactionID(transactionID int) ([]TransactionSplit, error)
+	FindSplitsByTransactionIDTx(tx *sql.Tx, transactionID int) ([]TransactionSplit, error)
+	// ReplaceSplits swaps the splits of a transaction for splits; none leaves it unsplit.
+	ReplaceSplits(tx *sql.Tx, transactionID int, splits []TransactionSplit) ([]TransactionSplit, error)
 }
 
 type TransactionUseCase interface {
-	FindByID(id int) (*dto.TransactionDto, error)
+	FindByID(id int, userID uuid.UUID) (*dto.TransactionDto, error)
 	FindByFilter(params dto.GetTransactionParams) (dto.PaginationResponse[dto.TransactionDto], error)
+	FindByCursor(params dto.GetTransactionParams) (dto.CursorResponse[dto.TransactionDto], error)
+	ExportCSV(params dto.GetTransactionParams, w io.Writer) error
+	ExportPlainText(params dto.GetTransactionParams, format string, w io.Writer) error
 	Create(req dto.CreateTransactionDto, userID uuid.UUID) (*dto.TransactionDto, error)
+	ValidateCreate(req dto.CreateTransactionDto, userID uuid.UUID) error
+	CreateBatch(reqs []dto.CreateTransactionDto, userID uuid.UUID) ([]dto.TransactionDto, error)
 	Update(req dto.UpdateTransactionDto, id int, userID uuid.UUID) (*dto.TransactionDto, error)
 	Delete(id int, userID uuid.UUID) error
+	FindDeleted(userID uuid.UUID, params dto.GetDeletedTransactionParams) (dto.PaginationResponse[dto.TransactionDto], error)
+	Restore(id int, userID uuid.UUID) (*dto.TransactionDto, error)
+	PurgeDeleted(before time.Time) (int64, error)
 }
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,28 +11,108 @@ import (
 	. "github.com/dimas-pramantya/money-management/dto"
 )
 
+// Kinds of category: the transaction types a category may be used for.
+const (
+	CategoryKindIncome  = "income"
+	CategoryKindExpense = "expense"
+	CategoryKindBoth    = "both"
+)
+
+// TransactionCategory is a node of the user's category tree; ParentID is nil for a root. Path names
+// the category from its root down, e.g. "Food:Groceries", and is read-only. An archived category
+// keeps its transactions but is hidden from category listings.
 type TransactionCategory struct {
-	ID        int        `json:"id" db:"id"`
-	Name      string     `json:"name" db:"name"`
-	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
-	CreatedAt time.Time  `json:"created_at" db:"created_at"`
-	CreatedBy string     `json:"created_by" db:"created_by"`
-	UpdatedAt *time.Time `json:"updated_at" db:"modified_at"`
-	UpdatedBy *string    `json:"updated_by" db:"modified_by"`
+	ID         int        `json:"id" db:"id"`
+	Name       string     `json:"name" db:"name"`
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+	ParentID   *int       `json:"parent_id" db:"parent_id"`
+	Kind       string     `json:"kind" db:"kind"`
+	Path       string     `json:"path" db:"path"`
+	ArchivedAt *time.Time `json:"archived_at" db:"archived_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	CreatedBy  string     `json:"created_by" db:"created_by"`
+	UpdatedAt  *time.Time `json:"updated_at" db:"modified_at"`
+	UpdatedBy  *string    `json:"updated_by" db:"modified_by"`
+}
+
+// Accepts reports whether transactions of transactionType may be filed under the category.
+func (c *TransactionCategory) Accepts(transactionType string) bool {
+	return c.Kind == CategoryKindBoth || c.Kind == transactionType
+}
+
+// DefaultCategory is a category every new user starts with. Names holds its name per locale,
+// e.g. "en" or "id", and Children the categories seeded below it.
+type DefaultCategory struct {
+	Kind     string            `json:"kind"`
+	Names    map[string]string `json:"names"`
+	Children []DefaultCategory `json:"children"`
+}
+
+// DefaultCategorySet is the configured tree of default categories. DefaultLocale names the
+// categories for users whose locale has no names of its own.
+type DefaultCategorySet struct {
+	DefaultLocale string            `json:"default_locale"`
+	Categories    []DefaultCategory `json:"categories"`
+}
+
+// Name picks the category's name for locale, such as "en-US": the exact locale first, then its
+// language, then the set's default locale. Locales are compared after NormalizeLocale, which the
+// set's Names keys and DefaultLocale are expected to have gone through when it was loaded. It is
+// empty when none of them has a name.
+func (s DefaultCategorySet) Name(category DefaultCategory, locale string) string {
+	locale = NormalizeLocale(locale)
+	language, _, _ := strings.Cut(locale, "-")
+	for _, candidate := range []string{locale, language, s.DefaultLocale} {
+		if name := category.Names[candidate]; name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// NormalizeLocale writes a locale such as "en_US" or "en-US" as "en-us".
+func NormalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
 }
 
 type TransactionCategoryRepository interface {
 	FindByID(id int) (*TransactionCategory, error)
 	FindByUserID(userID uuid.UUID) ([]TransactionCategory, error)
+	// FindSubtreeIDs returns the ids of the category and of every category below it.
+	FindSubtreeIDs(id int) ([]int, error)
+	FindSubtreeIDsTx(tx *sql.Tx, id int) ([]int, error)
+	// LockByUserIDTx locks all of the user's categories until tx ends. Every change to the shape
+	// of the tree takes this lock first.
+	LockByUserIDTx(tx *sql.Tx, userID uuid.UUID) error
+	// CountUsage counts the transactions, splits and recurring templates of transactionType filed
+	// directly under one of the categories ids.
+	CountUsage(ids []int, transactionType string) (int, error)
+	// CountBudgets counts the budgets of the categories ids.
+	CountBudgets(ids []int) (int, error)
+	// FindBudgetPeriodConflictsTx returns the budget periods that toID would have more than one
+	// budget for once the budgets of fromIDs were moved onto it.
+	FindBudgetPeriodConflictsTx(tx *sql.Tx, fromIDs []int, toID int) ([]string, error)
 	Create(category *TransactionCategory) (*TransactionCategory, error)
-	Update(category *TransactionCategory) (*TransactionCategory, error)
-	Delete(id int) error
+	CreateTx(tx *sql.Tx, category *TransactionCategory) (*TransactionCategory, error)
+	UpdateTx(tx *sql.Tx, category *TransactionCategory) (*TransactionCategory, error)
+	// ReassignTx files everything pointing at one of fromIDs under toID instead: transactions,
+	// splits, recurring templates, budgets, import profile defaults and saved view filters.
+	ReassignTx(tx *sql.Tx, userID uuid.UUID, fromIDs []int, toID int) error
+	// SetArchived archives the categories ids, or unarchives them when archivedAt is nil.
+	SetArchived(ids []int, archivedAt *time.Time, updatedBy string) error
+	// MoveChildrenTx puts the direct children of fromID under toID.
+	MoveChildrenTx(tx *sql.Tx, fromID int, toID int, updatedBy string) error
+	DeleteTx(tx *sql.Tx, id int) error
 }
 
 type TransactionCategoryUseCase interface {
 	FindByID(id int) (*dto.TransactionCategoryDto, error)
-	FindByUserID(userID uuid.UUID) ([]dto.TransactionCategoryDto, error)
+	FindByUserID(userID uuid.UUID, params dto.GetTransactionCategoryParams) ([]dto.TransactionCategoryDto, error)
+	FindTree(userID uuid.UUID, params dto.GetTransactionCategoryParams) ([]dto.TransactionCategoryTreeDto, error)
 	Create(req CreateTransactionCategoryDto, userID uuid.UUID) (*dto.TransactionCategoryDto, error)
 	Update(req UpdateTransactionCategoryDto, id int, userID uuid.UUID) (*dto.TransactionCategoryDto, error)
-	Delete(id int, userId uuid.UUID) error
-}
\ No newline at end of file
+	Delete(id int, req DeleteTransactionCategoryDto, userId uuid.UUID) error
+	Merge(id int, req MergeTransactionCategoryDto, userID uuid.UUID) (*dto.TransactionCategoryDto, error)
+	Archive(id int, userID uuid.UUID) (*dto.TransactionCategoryDto, error)
+	Unarchive(id int, userID uuid.UUID) (*dto.TransactionCategoryDto, error)
+}
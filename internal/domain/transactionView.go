@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/google/uuid"
+)
+
+// Relative date ranges a saved view can use instead of fixed dates, resolved whenever it runs.
+const (
+	ViewPeriodThisWeek   = "this_week"
+	ViewPeriodThisMonth  = "this_month"
+	ViewPeriodLastMonth  = "last_month"
+	ViewPeriodThisYear   = "this_year"
+	ViewPeriodLast30Days = "last_30_days"
+)
+
+// TransactionView is a named, saved transaction filter. Names are unique per user regardless of
+// case.
+type TransactionView struct {
+	ID        int                      `json:"id"`
+	UserID    uuid.UUID                `json:"user_id"`
+	Name      string                   `json:"name"`
+	Filter    dto.TransactionFilterDto `json:"filter"`
+	CreatedAt time.Time                `json:"created_at"`
+	UpdatedAt *time.Time               `json:"updated_at"`
+	CreatedBy string                   `json:"created_by"`
+	UpdatedBy *string                  `json:"updated_by"`
+}
+
+// ViewPeriodRange returns the first and last day of period as of today, ok false for an unknown
+// period. Weeks start on Monday like budget periods.
+func ViewPeriodRange(period string, today time.Time) (time.Time, time.Time, bool) {
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case ViewPeriodThisWeek:
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 6), true
+	case ViewPeriodThisMonth:
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, -1), true
+	case ViewPeriodLastMonth:
+		start := time.Date(day.Year(), day.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, -1), true
+	case ViewPeriodThisYear:
+		start := time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, -1), true
+	case ViewPeriodLast30Days:
+		return day.AddDate(0, 0, -29), day, true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+type TransactionViewRepository interface {
+	FindByID(id int) (*TransactionView, error)
+	FindByName(userID uuid.UUID, name string) (*TransactionView, error)
+	FindByUserID(userID uuid.UUID) ([]TransactionView, error)
+	Create(view *TransactionView) (*TransactionView, error)
+	Update(view *TransactionView) (*TransactionView, error)
+	Delete(id int) error
+}
+
+type TransactionViewUseCase interface {
+	FindByID(id int, userID uuid.UUID) (*dto.TransactionViewDto, error)
+	FindByUserID(userID uuid.UUID) ([]dto.TransactionViewDto, error)
+	Create(req dto.CreateTransactionViewDto, userID uuid.UUID) (*dto.TransactionViewDto, error)
+	Update(req dto.UpdateTransactionViewDto, id int, userID uuid.UUID) (*dto.TransactionViewDto, error)
+	Delete(id int, userID uuid.UUID) error
+	// FindParams returns the transaction query a view stands for, with its period resolved to dates.
+	FindParams(id int, userID uuid.UUID) (dto.GetTransactionParams, error)
+}
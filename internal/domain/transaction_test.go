@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTransactionSort(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []TransactionSort
+		wantErr bool
+	}{
+		{value: "amount", want: []TransactionSort{{Field: TransactionSortAmount}}},
+		{value: "amount:asc", want: []TransactionSort{{Field: TransactionSortAmount}}},
+		{value: "amount:desc", want: []TransactionSort{{Field: TransactionSortAmount, Descending: true}}},
+		{value: "amount:DESC", want: []TransactionSort{{Field: TransactionSortAmount, Descending: true}}},
+		{value: " category , transaction_date:desc", want: []TransactionSort{
+			{Field: TransactionSortCategory},
+			{Field: TransactionSortDate, Descending: true},
+		}},
+		{value: "account,created_at:desc,updated_at,relevance:desc", want: []TransactionSort{
+			{Field: TransactionSortAccount},
+			{Field: TransactionSortCreatedAt, Descending: true},
+			{Field: TransactionSortUpdatedAt},
+			{Field: TransactionSortRelevance, Descending: true},
+		}},
+		{value: "", wantErr: true},
+		{value: "amount,", wantErr: true},
+		{value: "Amount", wantErr: true},
+		{value: "t.id", wantErr: true},
+		{value: "notes", wantErr: true},
+		{value: "amount; DROP TABLE transactions", wantErr: true},
+		{value: "amount:sideways", wantErr: true},
+		{value: "amount:desc:asc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseTransactionSort(tt.value)
+			if tt.wantErr {
+				customErr, ok := err.(*CustomError)
+				if !ok || customErr.Code != 400 {
+					t.Fatalf("got %v, %v; want a bad request error", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/google/uuid"
+)
+
+type Transfer struct {
+	ID            int        `json:"id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	FromAccountID int        `json:"from_account_id"`
+	ToAccountID   int        `json:"to_account_id"`
+	Amount        int64      `json:"amount"`
+	Fee           int64      `json:"fee"`
+	TransferDate  time.Time  `json:"transfer_date"`
+	Notes         *string    `json:"note"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+	CreatedBy     string     `json:"created_by"`
+	UpdatedBy     *string    `json:"updated_by"`
+	// DeletedAt is set while the transfer and its legs are in the trash.
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type TransferRepository interface {
+	FindByID(id int) (*Transfer, error)
+	FindByIDTx(tx *sql.Tx, id int) (*Transfer, error)
+	FindByUserID(userID uuid.UUID) ([]Transfer, error)
+	Create(tx *sql.Tx, transfer *Transfer) (*Transfer, error)
+	Update(tx *sql.Tx, transfer *Transfer) (*Transfer, error)
+	// SoftDelete moves the transfer and its legs to the trash.
+	SoftDelete(tx *sql.Tx, id int, userID uuid.UUID) error
+	// Restore takes the transfer and its legs out of the trash.
+	Restore(tx *sql.Tx, id int, userID uuid.UUID) (*Transfer, error)
+	FindDeleted(userID uuid.UUID, limit int, offset int) ([]Transfer, error)
+	CountDeleted(userID uuid.UUID) (int, error)
+	// PurgeDeleted deletes the transfers, with their legs, that were moved to the trash before
+	// before, and returns how many transfers went.
+	PurgeDeleted(before time.Time) (int64, error)
+}
+
+type TransferUseCase interface {
+	FindByID(id int, userID uuid.UUID) (*dto.TransferDto, error)
+	FindByUserID(userID uuid.UUID) ([]dto.TransferDto, error)
+	Create(req dto.CreateTransferDto, userID uuid.UUID) (*dto.TransferDto, error)
+	Update(req dto.UpdateTransferDto, id int, userID uuid.UUID) (*dto.TransferDto, error)
+	// UpdateTx is Update within tx; the caller commits.
+	UpdateTx(tx *sql.Tx, req dto.UpdateTransferDto, id int, userID uuid.UUID) (*dto.TransferDto, error)
+	Delete(id int, userID uuid.UUID) error
+	FindDeleted(userID uuid.UUID, params dto.GetDeletedTransferParams) (dto.PaginationResponse[dto.TransferDto], error)
+	Restore(id int, userID uuid.UUID) (*dto.TransferDto, error)
+	PurgeDeleted(before time.Time) (int64, error)
+}
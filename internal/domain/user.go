@@ -14,6 +14,7 @@ type User struct {
 	Password string `json:"password"`
 	Email    string `json:"email"`
 	Balance int64 `json:"balance"`
+	BaseCurrency string `json:"base_currency"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at"`
 	CreatedBy string `json:"created_by"`
@@ -22,14 +23,17 @@ type User struct {
 
 type UserRepository interface {
 	FindById(id string) (*User, error)
+	FindByIdTx(tx *sql.Tx, id string) (*User, error)
 	FindByUsername(username string) (*User, error)
 	Create(user *User) (*User, error)
+	CreateTx(tx *sql.Tx, user *User) (*User, error)
 	Update(user *User) (*User, error)
 	FindByEmail(email string) (*User, error)
 	UpdatePassword(user *User) (error)
 	FindByUsernameOrEmail(username string) (*User, error)
 	UpdateBalance(user *User) (*User, error)
 	UpdateBalanceTx(tx *sql.Tx, user *User) (*User, error)
+	UpdateBaseCurrencyTx(tx *sql.Tx, user *User) (*User, error)
 }
 
 type UserUsecase interface {
@@ -40,4 +44,5 @@ type UserUsecase interface {
 	Update(id string, req ReqUpdateUserDto) (*ResUserDto, error)
 	UpdatePassword(id string, req ReqUpdateUserPasswordDto) (error)
 	UpdateBalance(id string, req ReqUpdateUserBalanceDto) (*ResUserDto, error)
+	UpdateBaseCurrency(id string, req ReqUpdateBaseCurrencyDto) (*ResUserDto, error)
 }
\ No newline at end of file
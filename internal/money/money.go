@@ -0,0 +1,128 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in the minor units of its currency: Money{1050, "USD"} is 10.50 dollars and
+// Money{50000, "IDR"} is 50,000 rupiah. Amounts are stored and exchanged as minor units so no
+// value ever goes through a float.
+//
+// In JSON a Money is its amount in minor units. It is written as an integer and read from an
+// integer or from a string holding one, so amounts beyond 2^53 survive clients that parse numbers
+// as doubles; fractions and exponents are rejected rather than rounded. The currency travels in a
+// field of its own, so decoding leaves Currency for the caller to fill in.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// currencyMinorUnits lists the currencies whose minor unit is not a hundredth, following ISO 4217.
+// IDR is the one deliberate exception: the sen has not circulated for decades, Indonesian banks
+// and apps show whole rupiah, and amounts recorded before currencies existed are whole rupiah.
+var currencyMinorUnits = map[string]int{
+	"IDR": 0,
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// MinorUnits returns the number of decimals of currency.
+func MinorUnits(currency string) int {
+	if units, ok := currencyMinorUnits[currency]; ok {
+		return units
+	}
+	return 2
+}
+
+// Parse reads a decimal amount in major units, such as "10.50", "-3" or "0.125", exactly. More
+// decimals than the currency has are rejected unless they are zeros.
+func Parse(value string, currency string) (Money, error) {
+	text := strings.TrimSpace(value)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+	whole, fraction, _ := strings.Cut(text, ".")
+	if whole == "" || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("amount %q is not a number", value)
+	}
+
+	units := MinorUnits(currency)
+	if len(fraction) > units {
+		if strings.Trim(fraction[units:], "0") != "" {
+			return Money{}, fmt.Errorf("amount %q has more than %d decimals for %s", value, units, currency)
+		}
+		fraction = fraction[:units]
+	}
+	fraction += strings.Repeat("0", units-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("amount %q is out of range", value)
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, m.Amount, 10), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+	amount, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return fmt.Errorf("amount %s is not an integer number of minor units", data)
+	}
+	m.Amount = amount
+	return nil
+}
+
+// IsPositive reports whether the amount is above zero, as every transaction, transfer and budget
+// amount has to be.
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// Decimal formats the amount in major units with all the currency's decimals, e.g. "-10.50".
+func (m Money) Decimal() string {
+	digits := strconv.FormatInt(m.Amount, 10)
+	sign := ""
+	if m.Amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	units := MinorUnits(m.Currency)
+	if units == 0 {
+		return sign + digits
+	}
+	if len(digits) <= units {
+		digits = strings.Repeat("0", units-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-units] + "." + digits[len(digits)-units:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
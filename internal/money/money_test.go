@@ -0,0 +1,192 @@
+package money
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestMinorUnits(t *testing.T) {
+	tests := map[string]int{
+		"USD": 2,
+		"EUR": 2,
+		"IDR": 0,
+		"JPY": 0,
+		"KWD": 3,
+		"CLF": 4,
+		"XYZ": 2,
+		"":    2,
+	}
+	for currency, want := range tests {
+		if got := MinorUnits(currency); got != want {
+			t.Errorf("MinorUnits(%q) = %d, want %d", currency, got, want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		want     int64
+		wantErr  bool
+	}{
+		{value: "10.50", currency: "USD", want: 1050},
+		{value: "10.5", currency: "USD", want: 1050},
+		{value: "10", currency: "USD", want: 1000},
+		{value: "10.", currency: "USD", want: 1000},
+		{value: " 7.25 ", currency: "USD", want: 725},
+		{value: "0.01", currency: "USD", want: 1},
+		{value: "1.230", currency: "USD", want: 123},
+		{value: "-3", currency: "USD", want: -300},
+		{value: "-0.05", currency: "USD", want: -5},
+		{value: "50000", currency: "IDR", want: 50000},
+		{value: "50000.00", currency: "IDR", want: 50000},
+		{value: "1500", currency: "JPY", want: 1500},
+		{value: "0.125", currency: "KWD", want: 125},
+		{value: "2", currency: "KWD", want: 2000},
+		{value: "92233720368547758.07", currency: "USD", want: math.MaxInt64},
+		{value: "1.234", currency: "USD", wantErr: true},
+		{value: "50000.5", currency: "IDR", wantErr: true},
+		{value: "0.1255", currency: "KWD", wantErr: true},
+		{value: "92233720368547758.08", currency: "USD", wantErr: true},
+		{value: "9223372036854775808", currency: "IDR", wantErr: true},
+		{value: "", currency: "USD", wantErr: true},
+		{value: "-", currency: "USD", wantErr: true},
+		{value: ".5", currency: "USD", wantErr: true},
+		{value: "+5", currency: "USD", wantErr: true},
+		{value: "--5", currency: "USD", wantErr: true},
+		{value: "1e3", currency: "USD", wantErr: true},
+		{value: "1,000", currency: "USD", wantErr: true},
+		{value: "1.2.3", currency: "USD", wantErr: true},
+		{value: "abc", currency: "USD", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currency+" "+tt.value, func(t *testing.T) {
+			got, err := Parse(tt.value, tt.currency)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Amount != tt.want || got.Currency != tt.currency {
+				t.Errorf("got %+v, want %d %s", got, tt.want, tt.currency)
+			}
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{Money{1050, "USD"}, "10.50"},
+		{Money{5, "USD"}, "0.05"},
+		{Money{0, "USD"}, "0.00"},
+		{Money{-5, "USD"}, "-0.05"},
+		{Money{-123456, "USD"}, "-1234.56"},
+		{Money{50000, "IDR"}, "50000"},
+		{Money{-50000, "IDR"}, "-50000"},
+		{Money{125, "KWD"}, "0.125"},
+		{Money{-2000, "KWD"}, "-2.000"},
+		{Money{1, "CLF"}, "0.0001"},
+		{Money{math.MinInt64, "USD"}, "-92233720368547758.08"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.money.Decimal(); got != tt.want {
+				t.Errorf("Decimal() = %q, want %q", got, tt.want)
+			}
+			// Everything but the one amount without a positive counterpart reads back the same.
+			if tt.money.Amount == math.MinInt64 {
+				return
+			}
+			parsed, err := Parse(tt.want, tt.money.Currency)
+			if err != nil || parsed != tt.money {
+				t.Errorf("Parse(%q) = %+v, %v; want %+v", tt.want, parsed, err, tt.money)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    int64
+		wantErr bool
+	}{
+		{json: `1050`, want: 1050},
+		{json: `"1050"`, want: 1050},
+		{json: `0`, want: 0},
+		{json: `-7`, want: -7},
+		{json: `"-7"`, want: -7},
+		{json: `9007199254740993`, want: 9007199254740993},
+		{json: `"9223372036854775807"`, want: math.MaxInt64},
+		{json: `-9223372036854775808`, want: math.MinInt64},
+		// null leaves the amount as it was.
+		{json: `null`, want: 42},
+		{json: `10.5`, wantErr: true},
+		{json: `"10.5"`, wantErr: true},
+		{json: `10.0`, wantErr: true},
+		{json: `1e3`, wantErr: true},
+		{json: `"1e3"`, wantErr: true},
+		{json: `9223372036854775808`, wantErr: true},
+		{json: `"-9223372036854775809"`, wantErr: true},
+		{json: `""`, wantErr: true},
+		{json: `" 5"`, wantErr: true},
+		{json: `"abc"`, wantErr: true},
+		{json: `true`, wantErr: true},
+		{json: `{}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			var req struct {
+				Amount Money `json:"amount"`
+			}
+			req.Amount.Amount = 42
+			err := json.Unmarshal([]byte(`{"amount": `+tt.json+`}`), &req)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %+v, want an error", req.Amount)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if req.Amount.Amount != tt.want {
+				t.Errorf("got %d, want %d", req.Amount.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{Money{1050, "USD"}, `{"amount":1050}`},
+		{Money{-5, "USD"}, `{"amount":-5}`},
+		{Money{0, "IDR"}, `{"amount":0}`},
+		{Money{9007199254740993, "IDR"}, `{"amount":9007199254740993}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			data, err := json.Marshal(struct {
+				Amount Money `json:"amount"`
+			}{tt.money})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("got %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package pgrepository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/google/uuid"
+)
+
+type accountPgRepository struct {
+	db *sql.DB
+}
+
+func (a *accountPgRepository) Create(tx *sql.Tx, account *domain.Account) (*domain.Account, error) {
+	err := tx.QueryRow(`
+		INSERT INTO accounts (user_id, name, account_type, currency, balance, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, user_id, name, account_type, currency, balance, created_at, created_by
+	`, account.UserID, account.Name, account.AccountType, account.Currency, account.Balance, account.CreatedBy).Scan(
+		&account.ID, &account.UserID, &account.Name, &account.AccountType, &account.Currency, &account.Balance,
+		&account.CreatedAt, &account.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (a *accountPgRepository) Delete(id int) error {
+	_, err := a.db.Exec(`DELETE FROM accounts WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *accountPgRepository) FindByID(id int) (*domain.Account, error) {
+	row := a.db.QueryRow(`
+		SELECT id, user_id, name, account_type, currency, balance, created_at, created_by, updated_at, updated_by
+		FROM accounts WHERE id = $1
+	`, id)
+	return scanAccount(row)
+}
+
+func (a *accountPgRepository) FindByIDTx(tx *sql.Tx, id int) (*domain.Account, error) {
+	row := tx.QueryRow(`
+		SELECT id, user_id, name, account_type, currency, balance, created_at, created_by, updated_at, updated_by
+		FROM accounts WHERE id = $1 FOR UPDATE
+	`, id)
+	return scanAccount(row)
+}
+
+func (a *accountPgRepository) FindByUserID(userID uuid.UUID) ([]domain.Account, error) {
+	rows, err := a.db.Query(`
+		SELECT id, user_id, name, account_type, currency, balance, created_at, created_by, updated_at, updated_by
+		FROM accounts WHERE user_id = $1 ORDER BY id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanAccounts(rows)
+}
+
+func (a *accountPgRepository) FindByUserIDTx(tx *sql.Tx, userID uuid.UUID) ([]domain.Account, error) {
+	rows, err := tx.Query(`
+		SELECT id, user_id, name, account_type, currency, balance, created_at, created_by, updated_at, updated_by
+		FROM accounts WHERE user_id = $1 ORDER BY id FOR UPDATE
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanAccounts(rows)
+}
+
+func scanAccounts(rows *sql.Rows) ([]domain.Account, error) {
+	defer rows.Close()
+
+	var accounts []domain.Account
+	for rows.Next() {
+		account := domain.Account{}
+		err := rows.Scan(
+			&account.ID, &account.UserID, &account.Name, &account.AccountType, &account.Currency, &account.Balance,
+			&account.CreatedAt, &account.CreatedBy, &account.UpdatedAt, &account.UpdatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, rows.Err()
+}
+
+func (a *accountPgRepository) CountTransactions(id int) (int, error) {
+	var count int
+	err := a.db.QueryRow(`SELECT COUNT(*) FROM transactions WHERE account_id = $1`, id).Scan(&count)
+	return count, err
+}
+
+func (a *accountPgRepository) Update(account *domain.Account) (*domain.Account, error) {
+	err := a.db.QueryRow(`
+		UPDATE accounts SET name = $1, account_type = $2, currency = $3, updated_by = $4, updated_at = $5
+		WHERE id = $6 RETURNING name, account_type, currency, updated_at, updated_by
+	`, account.Name, account.AccountType, account.Currency, account.UpdatedBy, time.Now(), account.ID,
+	).Scan(&account.Name, &account.AccountType, &account.Currency, &account.UpdatedAt, &account.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (a *accountPgRepository) UpdateBalanceTx(tx *sql.Tx, account *domain.Account) (*domain.Account, error) {
+	err := tx.QueryRow(`UPDATE accounts SET balance = $1, updated_by = $2, updated_at = $3 WHERE id = $4 RETURNING balance, updated_at, updated_by`,
+		account.Balance, account.UserID, time.Now(), account.ID).Scan(&account.Balance, &account.UpdatedAt, &account.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func scanAccount(row *sql.Row) (*domain.Account, error) {
+	account := &domain.Account{}
+	err := row.Scan(
+		&account.ID, &account.UserID, &account.Name, &account.AccountType, &account.Currency, &account.Balance,
+		&account.CreatedAt, &account.CreatedBy, &account.UpdatedAt, &account.UpdatedBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+func NewAccountPgRepository(db *sql.DB) domain.AccountRepository {
+	return &accountPgRepository{db: db}
+}
@@ -0,0 +1,163 @@
+package pgrepository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/google/uuid"
+)
+
+type budgetPgRepository struct {
+	db *sql.DB
+}
+
+func (b *budgetPgRepository) Create(budget *domain.Budget) (*domain.Budget, error) {
+	row := b.db.QueryRow(`
+		INSERT INTO budgets (user_id, transaction_category_id, amount, period, start_date, end_date,
+		rollover, rollover_cap, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING `+budgetColumns,
+		budget.UserID, budget.CategoryID, budget.Amount, budget.Period,
+		budget.StartDate, budget.EndDate, budget.Rollover, budget.RolloverCap, budget.CreatedBy)
+	return scanBudget(row)
+}
+
+func (b *budgetPgRepository) Delete(id int) error {
+	_, err := b.db.Exec(`DELETE FROM budgets WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (b *budgetPgRepository) FindByID(id int) (*domain.Budget, error) {
+	row := b.db.QueryRow(`SELECT `+budgetColumns+` FROM budgets WHERE id = $1`, id)
+	return scanBudget(row)
+}
+
+func (b *budgetPgRepository) FindByUserID(userID uuid.UUID) ([]domain.Budget, error) {
+	rows, err := b.db.Query(`SELECT `+budgetColumns+` FROM budgets WHERE user_id = $1 ORDER BY transaction_category_id, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var budgets []domain.Budget
+	for rows.Next() {
+		budget, err := scanBudget(rows)
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, *budget)
+	}
+	return budgets, rows.Err()
+}
+
+func (b *budgetPgRepository) Update(budget *domain.Budget) (*domain.Budget, error) {
+	row := b.db.QueryRow(`
+		UPDATE budgets
+		SET transaction_category_id = $1, amount = $2, period = $3, start_date = $4, end_date = $5,
+		rollover = $6, rollover_cap = $7, updated_at = now(), updated_by = $8
+		WHERE id = $9 RETURNING `+budgetColumns,
+		budget.CategoryID, budget.Amount, budget.Period, budget.StartDate, budget.EndDate,
+		budget.Rollover, budget.RolloverCap, budget.UpdatedBy, budget.ID)
+	return scanBudget(row)
+}
+
+// SumSpentByPeriod totals the expenses booked against the budget's category or any category below
+// it, between from and to inclusive, keyed by the start of the budget period they fall in.
+// Amounts are converted into the user's base currency; BudgetService checks that every rate is
+// there first. Of a split transaction only the splits in those categories count.
+func (b *budgetPgRepository) SumSpentByPeriod(budget *domain.Budget, from time.Time, to time.Time) (map[time.Time]int64, error) {
+	rows, err := b.db.Query(`
+		SELECT date_trunc($1::text, t.transaction_date)::date, COALESCE(SUM(`+convertedAmount+`), 0) FROM `+categoryShares+` t
+		WHERE t.user_id = $2 AND t.transaction_type = 'expense'
+		AND t.transaction_category_id IN (SELECT transaction_category_subtree($3))
+		AND t.transaction_date BETWEEN $4 AND $5
+		GROUP BY 1`,
+		budgetTruncUnits[budget.Period], budget.UserID, budget.CategoryID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return scanPeriodSums(rows)
+}
+
+// SumAllocatedByPeriod totals the allocations moved in and out of the budget up to to, keyed by period start.
+func (b *budgetPgRepository) SumAllocatedByPeriod(budget *domain.Budget, to time.Time) (map[time.Time]int64, error) {
+	rows, err := b.db.Query(`
+		SELECT period_start, SUM(amount) FROM budget_allocations
+		WHERE budget_id = $1 AND period_start <= $2
+		GROUP BY period_start`, budget.ID, to)
+	if err != nil {
+		return nil, err
+	}
+	return scanPeriodSums(rows)
+}
+
+// SumIncome totals the user's income transactions dated on or before to, in the base currency.
+func (b *budgetPgRepository) SumIncome(userID uuid.UUID, to time.Time) (int64, error) {
+	var income int64
+	err := b.db.QueryRow(`
+		SELECT COALESCE(SUM(`+convertedAmount+`), 0) FROM transactions t
+		WHERE t.user_id = $1 AND t.transaction_type = 'income' AND t.transaction_date <= $2 AND t.deleted_at IS NULL`,
+		userID, to).Scan(&income)
+	return income, err
+}
+
+func (b *budgetPgRepository) CreateAllocation(tx *sql.Tx, allocation *domain.BudgetAllocation) (*domain.BudgetAllocation, error) {
+	created := &domain.BudgetAllocation{}
+	err := tx.QueryRow(`
+		INSERT INTO budget_allocations (user_id, budget_id, period_start, amount, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, user_id, budget_id, period_start, amount, created_at, created_by`,
+		allocation.UserID, allocation.BudgetID, allocation.PeriodStart, allocation.Amount, allocation.CreatedBy,
+	).Scan(&created.ID, &created.UserID, &created.BudgetID, &created.PeriodStart, &created.Amount, &created.CreatedAt, &created.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// budgetTruncUnits maps budget periods to date_trunc units; Postgres weeks start on Monday like ours.
+var budgetTruncUnits = map[string]string{
+	domain.BudgetPeriodWeekly:  "week",
+	domain.BudgetPeriodMonthly: "month",
+	domain.BudgetPeriodYearly:  "year",
+}
+
+func scanPeriodSums(rows *sql.Rows) (map[time.Time]int64, error) {
+	defer rows.Close()
+
+	sums := map[time.Time]int64{}
+	for rows.Next() {
+		var periodStart time.Time
+		var sum int64
+		if err := rows.Scan(&periodStart, &sum); err != nil {
+			return nil, err
+		}
+		sums[time.Date(periodStart.Year(), periodStart.Month(), periodStart.Day(), 0, 0, 0, 0, time.UTC)] = sum
+	}
+	return sums, rows.Err()
+}
+
+const budgetColumns = `id, user_id, transaction_category_id, amount, period, start_date, end_date,
+	rollover, rollover_cap, created_at, created_by, updated_at, updated_by`
+
+func scanBudget(row rowScanner) (*domain.Budget, error) {
+	budget := &domain.Budget{}
+	err := row.Scan(
+		&budget.ID, &budget.UserID, &budget.CategoryID, &budget.Amount, &budget.Period,
+		&budget.StartDate, &budget.EndDate, &budget.Rollover, &budget.RolloverCap, &budget.CreatedAt, &budget.CreatedBy, &budget.UpdatedAt, &budget.UpdatedBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return budget, nil
+}
+
+func NewBudgetPgRepository(db *sql.DB) domain.BudgetRepository {
+	return &budgetPgRepository{db: db}
+}
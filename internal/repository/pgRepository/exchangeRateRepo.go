@@ -0,0 +1,109 @@
+package pgrepository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/google/uuid"
+)
+
+type exchangeRatePgRepository struct {
+	db *sql.DB
+}
+
+func (e *exchangeRatePgRepository) FindByID(id int) (*domain.ExchangeRate, error) {
+	row := e.db.QueryRow(`SELECT `+exchangeRateColumns+` FROM exchange_rates WHERE id = $1`, id)
+	return scanExchangeRate(row)
+}
+
+func (e *exchangeRatePgRepository) FindByUserID(userID uuid.UUID, currency *string) ([]domain.ExchangeRate, error) {
+	rows, err := e.db.Query(`
+		SELECT `+exchangeRateColumns+` FROM exchange_rates
+		WHERE user_id = $1 AND ($2::text IS NULL OR from_currency = $2 OR to_currency = $2)
+		ORDER BY from_currency, to_currency, effective_date DESC`, userID, currency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []domain.ExchangeRate
+	for rows.Next() {
+		rate, err := scanExchangeRate(rows)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, *rate)
+	}
+	return rates, rows.Err()
+}
+
+func (e *exchangeRatePgRepository) FindByPair(userID uuid.UUID, fromCurrency string, toCurrency string, effectiveDate time.Time) (*domain.ExchangeRate, error) {
+	row := e.db.QueryRow(`
+		SELECT `+exchangeRateColumns+` FROM exchange_rates
+		WHERE user_id = $1 AND from_currency = $2 AND to_currency = $3 AND effective_date = $4`,
+		userID, fromCurrency, toCurrency, effectiveDate)
+	return scanExchangeRate(row)
+}
+
+// Upsert records rate, replacing the rate already recorded for the same pair and date.
+func (e *exchangeRatePgRepository) Upsert(tx *sql.Tx, rate *domain.ExchangeRate) (*domain.ExchangeRate, error) {
+	row := tx.QueryRow(`
+		INSERT INTO exchange_rates (user_id, from_currency, to_currency, rate, effective_date, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, from_currency, to_currency, effective_date)
+		DO UPDATE SET rate = EXCLUDED.rate, updated_at = now(), updated_by = EXCLUDED.created_by
+		RETURNING `+exchangeRateColumns,
+		rate.UserID, rate.FromCurrency, rate.ToCurrency, rate.Rate, rate.EffectiveDate, rate.CreatedBy)
+	return scanExchangeRate(row)
+}
+
+func (e *exchangeRatePgRepository) Update(rate *domain.ExchangeRate) (*domain.ExchangeRate, error) {
+	row := e.db.QueryRow(`
+		UPDATE exchange_rates
+		SET from_currency = $1, to_currency = $2, rate = $3, effective_date = $4, updated_at = now(), updated_by = $5
+		WHERE id = $6 RETURNING `+exchangeRateColumns,
+		rate.FromCurrency, rate.ToCurrency, rate.Rate, rate.EffectiveDate, rate.UpdatedBy, rate.ID)
+	return scanExchangeRate(row)
+}
+
+func (e *exchangeRatePgRepository) Delete(id int) error {
+	_, err := e.db.Exec(`DELETE FROM exchange_rates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (e *exchangeRatePgRepository) Convert(userID uuid.UUID, amount int64, fromCurrency string, toCurrency string, date time.Time) (*int64, error) {
+	var converted sql.NullInt64
+	err := e.db.QueryRow(`SELECT convert_amount($1, $2, $3, $4, $5)`, userID, amount, fromCurrency, toCurrency, date).Scan(&converted)
+	if err != nil {
+		return nil, err
+	}
+	if !converted.Valid {
+		return nil, nil
+	}
+	return &converted.Int64, nil
+}
+
+const exchangeRateColumns = `id, user_id, from_currency, to_currency, rate, effective_date, created_at, created_by, updated_at, updated_by`
+
+func scanExchangeRate(row rowScanner) (*domain.ExchangeRate, error) {
+	rate := &domain.ExchangeRate{}
+	err := row.Scan(
+		&rate.ID, &rate.UserID, &rate.FromCurrency, &rate.ToCurrency, &rate.Rate, &rate.EffectiveDate,
+		&rate.CreatedAt, &rate.CreatedBy, &rate.UpdatedAt, &rate.UpdatedBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rate, nil
+}
+
+func NewExchangeRatePgRepository(db *sql.DB) domain.ExchangeRateRepository {
+	return &exchangeRatePgRepository{db: db}
+}
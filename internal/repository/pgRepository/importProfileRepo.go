@@ -0,0 +1,92 @@
+package pgrepository
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/google/uuid"
+)
+
+type importProfilePgRepository struct {
+	db *sql.DB
+}
+
+func (i *importProfilePgRepository) Create(profile *domain.ImportProfile) (*domain.ImportProfile, error) {
+	row := i.db.QueryRow(`
+		INSERT INTO import_profiles (user_id, name, delimiter, has_header, date_column, date_format, amount_column, decimal_separator,
+		type_column, income_value, expense_value, note_column, category_column, default_category_id, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING `+importProfileColumns,
+		profile.UserID, profile.Name, profile.Delimiter, profile.HasHeader, profile.DateColumn, profile.DateFormat,
+		profile.AmountColumn, profile.DecimalSeparator, profile.TypeColumn, profile.IncomeValue, profile.ExpenseValue,
+		profile.NoteColumn, profile.CategoryColumn, profile.DefaultCategoryID, profile.CreatedBy)
+	return scanImportProfile(row)
+}
+
+func (i *importProfilePgRepository) Delete(id int) error {
+	_, err := i.db.Exec(`DELETE FROM import_profiles WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *importProfilePgRepository) FindByID(id int) (*domain.ImportProfile, error) {
+	row := i.db.QueryRow(`SELECT `+importProfileColumns+` FROM import_profiles WHERE id = $1`, id)
+	return scanImportProfile(row)
+}
+
+func (i *importProfilePgRepository) FindByUserID(userID uuid.UUID) ([]domain.ImportProfile, error) {
+	rows, err := i.db.Query(`SELECT `+importProfileColumns+` FROM import_profiles WHERE user_id = $1 ORDER BY name`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []domain.ImportProfile
+	for rows.Next() {
+		profile, err := scanImportProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, *profile)
+	}
+	return profiles, rows.Err()
+}
+
+func (i *importProfilePgRepository) Update(profile *domain.ImportProfile) (*domain.ImportProfile, error) {
+	row := i.db.QueryRow(`
+		UPDATE import_profiles
+		SET name = $1, delimiter = $2, has_header = $3, date_column = $4, date_format = $5, amount_column = $6, decimal_separator = $7,
+		type_column = $8, income_value = $9, expense_value = $10, note_column = $11, category_column = $12, default_category_id = $13,
+		updated_at = now(), updated_by = $14
+		WHERE id = $15 RETURNING `+importProfileColumns,
+		profile.Name, profile.Delimiter, profile.HasHeader, profile.DateColumn, profile.DateFormat, profile.AmountColumn,
+		profile.DecimalSeparator, profile.TypeColumn, profile.IncomeValue, profile.ExpenseValue, profile.NoteColumn,
+		profile.CategoryColumn, profile.DefaultCategoryID, profile.UpdatedBy, profile.ID)
+	return scanImportProfile(row)
+}
+
+const importProfileColumns = `id, user_id, name, delimiter, has_header, date_column, date_format, amount_column, decimal_separator,
+	type_column, income_value, expense_value, note_column, category_column, default_category_id,
+	created_at, created_by, updated_at, updated_by`
+
+func scanImportProfile(row rowScanner) (*domain.ImportProfile, error) {
+	profile := &domain.ImportProfile{}
+	err := row.Scan(
+		&profile.ID, &profile.UserID, &profile.Name, &profile.Delimiter, &profile.HasHeader, &profile.DateColumn,
+		&profile.DateFormat, &profile.AmountColumn, &profile.DecimalSeparator, &profile.TypeColumn, &profile.IncomeValue,
+		&profile.ExpenseValue, &profile.NoteColumn, &profile.CategoryColumn, &profile.DefaultCategoryID,
+		&profile.CreatedAt, &profile.CreatedBy, &profile.UpdatedAt, &profile.UpdatedBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return profile, nil
+}
+
+func NewImportProfilePgRepository(db *sql.DB) domain.ImportProfileRepository {
+	return &importProfilePgRepository{db: db}
+}
@@ -0,0 +1,107 @@
+package pgrepository
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/google/uuid"
+)
+
+type ledgerPgRepository struct {
+	db *sql.DB
+}
+
+func (l *ledgerPgRepository) Create(tx *sql.Tx, entry *domain.LedgerEntry) (*domain.LedgerEntry, error) {
+	row := tx.QueryRow(`
+		INSERT INTO ledger_entries (journal_id, user_id, account_id, ledger_account, entry_type, amount, transaction_id, transfer_id, description, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING `+ledgerEntryColumns,
+		entry.JournalID, entry.UserID, entry.AccountID, entry.LedgerAccount, entry.EntryType, entry.Amount,
+		entry.TransactionID, entry.TransferID, entry.Description, entry.CreatedBy)
+	return scanLedgerEntry(row)
+}
+
+func (l *ledgerPgRepository) FindByAccountID(accountID int, limit int, offset int) ([]domain.LedgerEntry, error) {
+	rows, err := l.db.Query(`
+		SELECT `+ledgerEntryColumns+` FROM ledger_entries WHERE account_id = $1
+		ORDER BY id DESC LIMIT $2 OFFSET $3
+	`, accountID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []domain.LedgerEntry
+	for rows.Next() {
+		entry, err := scanLedgerEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+	return entries, rows.Err()
+}
+
+func (l *ledgerPgRepository) CountByAccountID(accountID int) (int, error) {
+	var count int
+	err := l.db.QueryRow(`SELECT COUNT(*) FROM ledger_entries WHERE account_id = $1`, accountID).Scan(&count)
+	return count, err
+}
+
+func (l *ledgerPgRepository) SumByAccountIDTx(tx *sql.Tx, accountID int) (int64, error) {
+	var total int64
+	err := tx.QueryRow(`SELECT COALESCE(SUM(amount), 0) FROM ledger_entries WHERE account_id = $1`, accountID).Scan(&total)
+	return total, err
+}
+
+func (l *ledgerPgRepository) SumByLedgerAccountTx(tx *sql.Tx, userID uuid.UUID, ledgerAccount string) (int64, error) {
+	var total int64
+	err := tx.QueryRow(`SELECT COALESCE(SUM(amount), 0) FROM ledger_entries WHERE user_id = $1 AND ledger_account = $2`,
+		userID, ledgerAccount).Scan(&total)
+	return total, err
+}
+
+// FindEquityEntriesByUserID returns the account side of every journal posted against equity
+// (opening balances, adjustments and reconciliations), oldest first.
+func (l *ledgerPgRepository) FindEquityEntriesByUserID(userID uuid.UUID) ([]domain.LedgerEntry, error) {
+	rows, err := l.db.Query(`
+		SELECT `+ledgerEntryColumns+` FROM ledger_entries
+		WHERE user_id = $1 AND account_id IS NOT NULL AND entry_type IN ('opening', 'adjustment', 'reconciliation')
+		ORDER BY created_at, id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []domain.LedgerEntry
+	for rows.Next() {
+		entry, err := scanLedgerEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+	return entries, rows.Err()
+}
+
+const ledgerEntryColumns = `id, journal_id, user_id, account_id, ledger_account, entry_type, amount, transaction_id, transfer_id,
+	description, created_at, created_by`
+
+func scanLedgerEntry(row rowScanner) (*domain.LedgerEntry, error) {
+	entry := &domain.LedgerEntry{}
+	err := row.Scan(
+		&entry.ID, &entry.JournalID, &entry.UserID, &entry.AccountID, &entry.LedgerAccount, &entry.EntryType,
+		&entry.Amount, &entry.TransactionID, &entry.TransferID, &entry.Description, &entry.CreatedAt, &entry.CreatedBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+func NewLedgerPgRepository(db *sql.DB) domain.LedgerRepository {
+	return &ledgerPgRepository{db: db}
+}
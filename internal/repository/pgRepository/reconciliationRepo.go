@@ -0,0 +1,96 @@
+package pgrepository
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/google/uuid"
+)
+
+type reconciliationPgRepository struct {
+	db *sql.DB
+}
+
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+func (r *reconciliationPgRepository) FindAccountChecks(userID *uuid.UUID) ([]domain.AccountBalanceCheck, error) {
+	return findAccountChecks(r.db, userID, "")
+}
+
+func (r *reconciliationPgRepository) FindAccountChecksTx(tx *sql.Tx, userID *uuid.UUID) ([]domain.AccountBalanceCheck, error) {
+	return findAccountChecks(tx, userID, " FOR UPDATE OF a")
+}
+
+func (r *reconciliationPgRepository) FindUserChecks(userID *uuid.UUID) ([]domain.UserBalanceCheck, error) {
+	return findUserChecks(r.db, userID, "")
+}
+
+func (r *reconciliationPgRepository) FindUserChecksTx(tx *sql.Tx, userID *uuid.UUID) ([]domain.UserBalanceCheck, error) {
+	return findUserChecks(tx, userID, " FOR UPDATE")
+}
+
+func findAccountChecks(q queryer, userID *uuid.UUID, lock string) ([]domain.AccountBalanceCheck, error) {
+	rows, err := q.Query(`
+	SELECT
+		a.user_id, a.id, a.name, a.currency = u.base_currency, a.balance,
+		COALESCE((SELECT SUM(l.amount) FROM ledger_entries l WHERE l.account_id = a.id), 0) AS ledger_balance,
+		COALESCE((
+			SELECT SUM(CASE
+				WHEN t.transaction_type = 'expense' THEN -t.amount
+				WHEN t.transaction_type = 'transfer' AND t.transfer_direction = 'out' THEN -t.amount
+				ELSE t.amount
+			END)
+			FROM transactions t WHERE t.account_id = a.id AND t.deleted_at IS NULL
+		), 0) + COALESCE((
+			SELECT SUM(l.amount) FROM ledger_entries l
+			WHERE l.account_id = a.id AND l.entry_type IN ('opening', 'adjustment')
+		), 0) AS expected_balance
+	FROM accounts a
+	INNER JOIN users u ON u.id = a.user_id
+	WHERE ($1::uuid IS NULL OR a.user_id = $1)
+	ORDER BY a.user_id, a.id`+lock, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checks []domain.AccountBalanceCheck
+	for rows.Next() {
+		var check domain.AccountBalanceCheck
+		if err := rows.Scan(
+			&check.UserID, &check.AccountID, &check.AccountName, &check.InBaseCurrency, &check.StoredBalance,
+			&check.LedgerBalance, &check.ExpectedBalance,
+		); err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+	return checks, rows.Err()
+}
+
+func findUserChecks(q queryer, userID *uuid.UUID, lock string) ([]domain.UserBalanceCheck, error) {
+	rows, err := q.Query(`
+	SELECT id, username, COALESCE(balance, 0) FROM users
+	WHERE ($1::uuid IS NULL OR id = $1)
+	ORDER BY id`+lock, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checks []domain.UserBalanceCheck
+	for rows.Next() {
+		var check domain.UserBalanceCheck
+		if err := rows.Scan(&check.UserID, &check.Username, &check.StoredBalance); err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+	return checks, rows.Err()
+}
+
+func NewReconciliationPgRepository(db *sql.DB) domain.ReconciliationRepository {
+	return &reconciliationPgRepository{db: db}
+}
@@ -0,0 +1,118 @@
+package pgrepository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/google/uuid"
+)
+
+type recurringTransactionPgRepository struct {
+	db *sql.DB
+}
+
+func (r *recurringTransactionPgRepository) Create(recurring *domain.RecurringTransaction) (*domain.RecurringTransaction, error) {
+	row := r.db.QueryRow(`
+		INSERT INTO recurring_transactions (user_id, account_id, transaction_category_id, transaction_type,
+		amount, notes, frequency, interval_count, day_of_month, start_date, end_date, occurrences, next_run_date, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING `+recurringTransactionColumns,
+		recurring.UserID, recurring.AccountID, recurring.CategoryID, recurring.TransactionType,
+		recurring.Amount, recurring.Notes, recurring.Frequency, recurring.IntervalCount, recurring.DayOfMonth,
+		recurring.StartDate, recurring.EndDate, recurring.Occurrences, recurring.NextRunDate, recurring.CreatedBy)
+	return scanRecurringTransaction(row)
+}
+
+func (r *recurringTransactionPgRepository) Delete(id int) error {
+	_, err := r.db.Exec(`DELETE FROM recurring_transactions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *recurringTransactionPgRepository) FindByID(id int) (*domain.RecurringTransaction, error) {
+	row := r.db.QueryRow(`SELECT `+recurringTransactionColumns+` FROM recurring_transactions WHERE id = $1`, id)
+	return scanRecurringTransaction(row)
+}
+
+func (r *recurringTransactionPgRepository) FindByUserID(userID uuid.UUID) ([]domain.RecurringTransaction, error) {
+	rows, err := r.db.Query(`SELECT `+recurringTransactionColumns+` FROM recurring_transactions WHERE user_id = $1 ORDER BY next_run_date, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanRecurringTransactions(rows)
+}
+
+// FindDue returns the rules with an occurrence on or before date that is still within their end date.
+func (r *recurringTransactionPgRepository) FindDue(date time.Time) ([]domain.RecurringTransaction, error) {
+	rows, err := r.db.Query(`
+		SELECT `+recurringTransactionColumns+` FROM recurring_transactions
+		WHERE next_run_date <= $1 AND (end_date IS NULL OR next_run_date <= end_date)
+		ORDER BY next_run_date, id`, date)
+	if err != nil {
+		return nil, err
+	}
+	return scanRecurringTransactions(rows)
+}
+
+func (r *recurringTransactionPgRepository) Update(recurring *domain.RecurringTransaction) (*domain.RecurringTransaction, error) {
+	row := r.db.QueryRow(`
+		UPDATE recurring_transactions
+		SET account_id = $1, transaction_category_id = $2, transaction_type = $3, amount = $4, notes = $5,
+		frequency = $6, interval_count = $7, day_of_month = $8, start_date = $9, end_date = $10, occurrences = $11, next_run_date = $12,
+		updated_at = now(), updated_by = $13
+		WHERE id = $14 RETURNING `+recurringTransactionColumns,
+		recurring.AccountID, recurring.CategoryID, recurring.TransactionType, recurring.Amount, recurring.Notes,
+		recurring.Frequency, recurring.IntervalCount, recurring.DayOfMonth, recurring.StartDate, recurring.EndDate,
+		recurring.Occurrences, recurring.NextRunDate, recurring.UpdatedBy, recurring.ID)
+	return scanRecurringTransaction(row)
+}
+
+// UpdateSchedule only moves the rule forward, so a concurrent edit of the template is not overwritten.
+func (r *recurringTransactionPgRepository) UpdateSchedule(recurring *domain.RecurringTransaction) error {
+	_, err := r.db.Exec(`
+		UPDATE recurring_transactions SET occurrences = $1, next_run_date = $2
+		WHERE id = $3 AND next_run_date < $2`,
+		recurring.Occurrences, recurring.NextRunDate, recurring.ID)
+	return err
+}
+
+const recurringTransactionColumns = `id, user_id, account_id, transaction_category_id, transaction_type,
+	amount, notes, frequency, interval_count, day_of_month, start_date, end_date, occurrences, next_run_date,
+	created_at, created_by, updated_at, updated_by`
+
+func scanRecurringTransaction(row rowScanner) (*domain.RecurringTransaction, error) {
+	recurring := &domain.RecurringTransaction{}
+	err := row.Scan(
+		&recurring.ID, &recurring.UserID, &recurring.AccountID, &recurring.CategoryID,
+		&recurring.TransactionType, &recurring.Amount, &recurring.Notes, &recurring.Frequency, &recurring.IntervalCount,
+		&recurring.DayOfMonth, &recurring.StartDate, &recurring.EndDate, &recurring.Occurrences, &recurring.NextRunDate,
+		&recurring.CreatedAt, &recurring.CreatedBy, &recurring.UpdatedAt, &recurring.UpdatedBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return recurring, nil
+}
+
+func scanRecurringTransactions(rows *sql.Rows) ([]domain.RecurringTransaction, error) {
+	defer rows.Close()
+
+	var recurrings []domain.RecurringTransaction
+	for rows.Next() {
+		recurring, err := scanRecurringTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		recurrings = append(recurrings, *recurring)
+	}
+	return recurrings, rows.Err()
+}
+
+func NewRecurringTransactionPgRepository(db *sql.DB) domain.RecurringTransactionRepository {
+	return &recurringTransactionPgRepository{db: db}
+}
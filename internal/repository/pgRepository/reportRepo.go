@@ -0,0 +1,173 @@
+package pgrepository
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+)
+
+type reportPgRepository struct {
+	db *sql.DB
+}
+
+// convertedAmount is the amount of transaction t in its owner's base currency at the rate in
+// effect on its date; NULL when no rate is recorded, see FindMissingRates.
+const convertedAmount = `convert_amount(t.user_id, t.amount, t.currency, (SELECT base_currency FROM users WHERE id = t.user_id), t.transaction_date)`
+
+// categoryShares stands in for transactions wherever amounts are totalled per category: an
+// unsplit transaction is a row of its own, a split one gives a row per split carrying the split's
+// category and amount. It keeps the transactions column names so convertedAmount applies and
+// leaves out deleted transactions.
+const categoryShares = `(
+	SELECT t.id, t.user_id, t.account_id, t.transaction_type, t.transaction_date, t.currency,
+		t.amount, t.transaction_category_id
+	FROM transactions t
+	WHERE t.deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.id)
+	UNION ALL
+	SELECT t.id, t.user_id, t.account_id, t.transaction_type, t.transaction_date, t.currency,
+		s.amount, s.transaction_category_id
+	FROM transactions t
+	INNER JOIN transaction_splits s ON s.transaction_id = t.id
+	WHERE t.deleted_at IS NULL
+)`
+
+// SumByBucket returns one row per bucket between the filter dates, including empty ones.
+func (r *reportPgRepository) SumByBucket(filter domain.ReportFilter) ([]domain.ReportBucketTotal, error) {
+	rows, err := r.db.Query(`
+	SELECT
+		b.bucket::date,
+		COALESCE(SUM(`+convertedAmount+`) FILTER (WHERE t.transaction_type = 'income'), 0) AS income,
+		COALESCE(SUM(`+convertedAmount+`) FILTER (WHERE t.transaction_type = 'expense'), 0) AS expense
+	FROM generate_series(date_trunc($1, $2::timestamp), $3::timestamp, ('1 ' || $1)::interval) AS b(bucket)
+	LEFT JOIN transactions t ON date_trunc($1, t.transaction_date::timestamp) = b.bucket
+		AND t.user_id = $4
+		AND t.deleted_at IS NULL
+		AND t.transaction_type IN ('income', 'expense')
+		AND t.transaction_date BETWEEN $2 AND $3
+		AND ($5::int IS NULL OR t.account_id = $5)
+	GROUP BY b.bucket
+	ORDER BY b.bucket`,
+		filter.Bucket, filter.StartDate, filter.EndDate, filter.UserID, filter.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []domain.ReportBucketTotal
+	for rows.Next() {
+		var total domain.ReportBucketTotal
+		if err := rows.Scan(&total.PeriodStart, &total.Income, &total.Expense); err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+	return totals, rows.Err()
+}
+
+// SumByCategory returns one row for every category of the user, ordered by name, with what is
+// booked on the category itself; totals are not rolled up to parents. Split transactions count
+// towards each split's category by the split's share.
+func (r *reportPgRepository) SumByCategory(filter domain.ReportFilter) ([]domain.ReportCategoryTotal, error) {
+	rows, err := r.db.Query(`
+	SELECT
+		tc.id, tc.name, tc.parent_id,
+		COALESCE(SUM(`+convertedAmount+`) FILTER (WHERE t.transaction_type = 'income'), 0) AS income,
+		COALESCE(SUM(`+convertedAmount+`) FILTER (WHERE t.transaction_type = 'expense'), 0) AS expense,
+		COUNT(t.id)
+	FROM transaction_categories tc
+	LEFT JOIN `+categoryShares+` t ON t.transaction_category_id = tc.id
+		AND t.transaction_type IN ('income', 'expense')
+		AND t.transaction_date BETWEEN $2 AND $3
+		AND ($4::int IS NULL OR t.account_id = $4)
+	WHERE tc.user_id = $1
+	GROUP BY tc.id, tc.name, tc.parent_id
+	ORDER BY lower(tc.name), tc.id`,
+		filter.UserID, filter.StartDate, filter.EndDate, filter.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []domain.ReportCategoryTotal
+	for rows.Next() {
+		var total domain.ReportCategoryTotal
+		if err := rows.Scan(
+			&total.CategoryID, &total.Category, &total.ParentID,
+			&total.Income, &total.Expense, &total.EntryCount,
+		); err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+	return totals, rows.Err()
+}
+
+// SumByTag returns one row per tag with any activity, ordered by name.
+func (r *reportPgRepository) SumByTag(filter domain.ReportFilter) ([]domain.ReportTagTotal, error) {
+	rows, err := r.db.Query(`
+	SELECT
+		g.id, g.name,
+		COALESCE(SUM(`+convertedAmount+`) FILTER (WHERE t.transaction_type = 'income'), 0) AS income,
+		COALESCE(SUM(`+convertedAmount+`) FILTER (WHERE t.transaction_type = 'expense'), 0) AS expense,
+		COUNT(*)
+	FROM transactions t
+	INNER JOIN transaction_tags tt ON tt.transaction_id = t.id
+	INNER JOIN tags g ON tt.tag_id = g.id
+	WHERE t.user_id = $1
+		AND t.deleted_at IS NULL
+		AND t.transaction_type IN ('income', 'expense')
+		AND t.transaction_date BETWEEN $2 AND $3
+		AND ($4::int IS NULL OR t.account_id = $4)
+	GROUP BY g.id, g.name
+	ORDER BY lower(g.name), g.id`,
+		filter.UserID, filter.StartDate, filter.EndDate, filter.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []domain.ReportTagTotal
+	for rows.Next() {
+		var total domain.ReportTagTotal
+		if err := rows.Scan(&total.TagID, &total.Tag, &total.Income, &total.Expense, &total.TransactionCount); err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+	return totals, rows.Err()
+}
+
+// FindMissingRates lists, per currency, the earliest transaction in the filter that cannot be
+// converted into the user's base currency because no rate was in effect on its date.
+func (r *reportPgRepository) FindMissingRates(filter domain.ReportFilter) ([]domain.MissingExchangeRate, error) {
+	rows, err := r.db.Query(`
+	SELECT t.currency, MIN(t.transaction_date)
+	FROM transactions t
+	WHERE t.user_id = $1
+		AND t.deleted_at IS NULL
+		AND t.transaction_type IN ('income', 'expense')
+		AND t.transaction_date BETWEEN $2 AND $3
+		AND ($4::int IS NULL OR t.account_id = $4)
+		AND `+convertedAmount+` IS NULL
+	GROUP BY t.currency
+	ORDER BY t.currency`,
+		filter.UserID, filter.StartDate, filter.EndDate, filter.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var missing []domain.MissingExchangeRate
+	for rows.Next() {
+		var rate domain.MissingExchangeRate
+		if err := rows.Scan(&rate.Currency, &rate.FirstDate); err != nil {
+			return nil, err
+		}
+		missing = append(missing, rate)
+	}
+	return missing, rows.Err()
+}
+
+func NewReportPgRepository(db *sql.DB) domain.ReportRepository {
+	return &reportPgRepository{db: db}
+}
@@ -0,0 +1,125 @@
+package pgrepository
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type tagPgRepository struct {
+	db *sql.DB
+}
+
+func (t *tagPgRepository) FindByID(id int) (*domain.Tag, error) {
+	row := t.db.QueryRow(`SELECT `+tagColumns+` FROM tags WHERE id = $1`, id)
+	return scanTag(row)
+}
+
+// FindByIDs returns the tags among ids that belong to the user.
+func (t *tagPgRepository) FindByIDs(userID uuid.UUID, ids []int) ([]domain.Tag, error) {
+	rows, err := t.db.Query(`
+		SELECT `+tagColumns+` FROM tags
+		WHERE user_id = $1 AND id = ANY($2)
+		ORDER BY lower(name)`, userID, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	return scanTags(rows)
+}
+
+// FindByName looks a tag up by name, ignoring case.
+func (t *tagPgRepository) FindByName(userID uuid.UUID, name string) (*domain.Tag, error) {
+	row := t.db.QueryRow(`SELECT `+tagColumns+` FROM tags WHERE user_id = $1 AND lower(name) = lower($2)`, userID, name)
+	return scanTag(row)
+}
+
+func (t *tagPgRepository) FindByUserID(userID uuid.UUID) ([]domain.Tag, error) {
+	rows, err := t.db.Query(`SELECT `+tagColumns+` FROM tags WHERE user_id = $1 ORDER BY lower(name)`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTags(rows)
+}
+
+func (t *tagPgRepository) FindByTransactionID(transactionID int) ([]domain.Tag, error) {
+	rows, err := t.db.Query(`
+		SELECT `+tagColumns+` FROM tags
+		WHERE id IN (SELECT tag_id FROM transaction_tags WHERE transaction_id = $1)
+		ORDER BY lower(name)`, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTags(rows)
+}
+
+func (t *tagPgRepository) Create(tag *domain.Tag) (*domain.Tag, error) {
+	row := t.db.QueryRow(`
+		INSERT INTO tags (user_id, name, created_by)
+		VALUES ($1, $2, $3) RETURNING `+tagColumns,
+		tag.UserID, tag.Name, tag.CreatedBy)
+	return scanTag(row)
+}
+
+func (t *tagPgRepository) Update(tag *domain.Tag) (*domain.Tag, error) {
+	row := t.db.QueryRow(`
+		UPDATE tags SET name = $1, updated_at = now(), updated_by = $2
+		WHERE id = $3 RETURNING `+tagColumns,
+		tag.Name, tag.UpdatedBy, tag.ID)
+	return scanTag(row)
+}
+
+func (t *tagPgRepository) Delete(id int) error {
+	_, err := t.db.Exec(`DELETE FROM tags WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *tagPgRepository) SetTransactionTags(tx *sql.Tx, transactionID int, tagIDs []int) error {
+	if _, err := tx.Exec(`DELETE FROM transaction_tags WHERE transaction_id = $1`, transactionID); err != nil {
+		return err
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(`
+		INSERT INTO transaction_tags (transaction_id, tag_id)
+		SELECT $1, tag_id FROM unnest($2::int[]) AS tag_id
+		ON CONFLICT DO NOTHING`, transactionID, pq.Array(tagIDs))
+	return err
+}
+
+const tagColumns = `id, user_id, name, created_at, created_by, updated_at, updated_by`
+
+func scanTag(row rowScanner) (*domain.Tag, error) {
+	tag := &domain.Tag{}
+	err := row.Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.CreatedAt, &tag.CreatedBy, &tag.UpdatedAt, &tag.UpdatedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
+func scanTags(rows *sql.Rows) ([]domain.Tag, error) {
+	defer rows.Close()
+
+	var tags []domain.Tag
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, *tag)
+	}
+	return tags, rows.Err()
+}
+
+func NewTagPgRepository(db *sql.DB) domain.TagRepository {
+	return &tagPgRepository{db: db}
+}
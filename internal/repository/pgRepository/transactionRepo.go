@@ -1,12 +1,17 @@
 package pgrepository
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/dimas-pramantya/money-management/dto"
 	"github.com/dimas-pramantya/money-management/internal/domain"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type transactionRepo struct {
@@ -14,66 +19,138 @@ type transactionRepo struct {
 }
 
 func (t *transactionRepo) CountByFilter(params dto.GetTransactionParams) (int, error) {
-	query := `SELECT COUNT(*) FROM transactions WHERE user_id = $1`
-	args := []interface{}{params.UserId}
-	argPos := 2
+	query, args := transactionFilter(`SELECT COUNT(*) FROM transactions t WHERE t.user_id = $1`, params)
 
+	var count int
+	err := t.db.QueryRow(query, args...).Scan(&count)
+	return count, err
+}
+
+// categorySubtrees selects the ids of the categories in the array placeholder $%d and of every
+// category below them.
+const categorySubtrees = `SELECT transaction_category_subtree(c) FROM unnest($%d::int[]) AS c`
+
+// transactionFilter appends the conditions of params to a query over transactions aliased t whose
+// first placeholder is the user id and second the search query, NULL when not searching; see
+// transactionDtoQuery. Deleted transactions are left out. Paging is left to the caller.
+func transactionFilter(query string, params dto.GetTransactionParams) (string, []interface{}) {
+	args := []interface{}{params.UserId, searchQuery(params)}
+	argPos := 3
+
+	query += " AND t.deleted_at IS NULL"
+	query += " AND ($2::text IS NULL OR t.search_vector @@ websearch_to_tsquery('simple', $2))"
+
+	if params.AccountID != nil {
+		query += fmt.Sprintf(" AND t.account_id = $%d", argPos)
+		args = append(args, *params.AccountID)
+		argPos++
+	}
+	// A category takes in everything below it, and a split transaction matches when one of its
+	// splits does.
+	categoryIDs := params.CategoryIDs
 	if params.CategoryID != nil {
-		query += fmt.Sprintf(" AND category_id = $%d", argPos)
-		args = append(args, *params.CategoryID)
+		categoryIDs = append([]int{*params.CategoryID}, categoryIDs...)
+	}
+	if len(categoryIDs) > 0 {
+		subtrees := fmt.Sprintf(categorySubtrees, argPos)
+		query += fmt.Sprintf(` AND (t.transaction_category_id IN (%[1]s)
+			OR EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.id AND s.transaction_category_id IN (%[1]s)))`, subtrees)
+		args = append(args, pq.Array(categoryIDs))
 		argPos++
 	}
-	if params.SubCategoryID != nil {
-		query += fmt.Sprintf(" AND sub_category_id = $%d", argPos)
-		args = append(args, *params.SubCategoryID)
+	if len(params.ExcludeCategoryIDs) > 0 {
+		subtrees := fmt.Sprintf(categorySubtrees, argPos)
+		query += fmt.Sprintf(` AND (t.transaction_category_id IS NULL OR t.transaction_category_id NOT IN (%[1]s))
+			AND NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.id AND s.transaction_category_id IN (%[1]s))`, subtrees)
+		args = append(args, pq.Array(params.ExcludeCategoryIDs))
 		argPos++
 	}
 	if params.TransactionType != nil {
-		query += fmt.Sprintf(" AND transaction_type = $%d", argPos)
+		query += fmt.Sprintf(" AND t.transaction_type = $%d", argPos)
 		args = append(args, *params.TransactionType)
 		argPos++
 	}
 	if params.StartDate != nil {
-		query += fmt.Sprintf(" AND transaction_date >= $%d", argPos)
+		query += fmt.Sprintf(" AND t.transaction_date >= $%d", argPos)
 		args = append(args, *params.StartDate)
 		argPos++
 	}
 	if params.EndDate != nil {
-		query += fmt.Sprintf(" AND transaction_date <= $%d", argPos)
+		query += fmt.Sprintf(" AND t.transaction_date <= $%d", argPos)
 		args = append(args, *params.EndDate)
+		argPos++
+	}
+	if params.MinAmount != nil {
+		query += fmt.Sprintf(" AND t.amount >= $%d", argPos)
+		args = append(args, *params.MinAmount)
+		argPos++
+	}
+	if params.MaxAmount != nil {
+		query += fmt.Sprintf(" AND t.amount <= $%d", argPos)
+		args = append(args, *params.MaxAmount)
+		argPos++
+	}
+	if params.NoteContains != nil && *params.NoteContains != "" {
+		query += fmt.Sprintf(" AND strpos(lower(t.notes), lower($%d)) > 0", argPos)
+		args = append(args, *params.NoteContains)
+		argPos++
+	}
+	// The created and updated bounds are dates and both inclusive.
+	if params.CreatedFrom != nil {
+		query += fmt.Sprintf(" AND t.created_at >= $%d::date", argPos)
+		args = append(args, *params.CreatedFrom)
+		argPos++
+	}
+	if params.CreatedTo != nil {
+		query += fmt.Sprintf(" AND t.created_at < $%d::date + 1", argPos)
+		args = append(args, *params.CreatedTo)
+		argPos++
+	}
+	if params.UpdatedFrom != nil {
+		query += fmt.Sprintf(" AND t.updated_at >= $%d::date", argPos)
+		args = append(args, *params.UpdatedFrom)
+		argPos++
+	}
+	if params.UpdatedTo != nil {
+		query += fmt.Sprintf(" AND t.updated_at < $%d::date + 1", argPos)
+		args = append(args, *params.UpdatedTo)
+		argPos++
 	}
+	if len(params.TagIDs) > 0 {
+		if params.TagMatch != nil && *params.TagMatch == domain.TagMatchAll {
+			distinct := map[int]bool{}
+			for _, id := range params.TagIDs {
+				distinct[id] = true
+			}
+			query += fmt.Sprintf(` AND (SELECT COUNT(*) FROM transaction_tags tt
+				WHERE tt.transaction_id = t.id AND tt.tag_id = ANY($%d)) = %d`, argPos, len(distinct))
+		} else {
+			query += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM transaction_tags tt WHERE tt.transaction_id = t.id AND tt.tag_id = ANY($%d))", argPos)
+		}
+		args = append(args, pq.Array(params.TagIDs))
+	}
+	return query, args
+}
 
-	var count int
-	err := t.db.QueryRow(query, args...).Scan(&count)
-	return count, err
+// searchQuery returns the search query of params, nil when there is none.
+func searchQuery(params dto.GetTransactionParams) *string {
+	if params.Query == nil || strings.TrimSpace(*params.Query) == "" {
+		return nil
+	}
+	return params.Query
 }
 
 // Create implements domain.TransactionRepository.
 func (t *transactionRepo) Create(tx *sql.Tx, transaction *domain.Transaction) (*domain.Transaction, error) {
-	err := tx.QueryRow(`
-		INSERT INTO transactions (ammount, transaction_category_id, transaction_sub_category_id, transaction_date, transaction_type, notes, user_id, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, ammount, transaction_category_id, transaction_sub_category_id, transaction_date, transaction_type, notes, user_id,
-		created_at, created_by, updated_at, updated_by
-	`, transaction.Ammount, transaction.CategoryID, transaction.SubCategoryID,
-		transaction.TransactionDate, transaction.TransactionType,
-		transaction.Notes, transaction.UserID.String(), transaction.CreatedBy).Scan(
-		&transaction.ID,
-		&transaction.Ammount,
-		&transaction.CategoryID,
-		&transaction.SubCategoryID,
-		&transaction.TransactionDate,
-		&transaction.TransactionType,
-		&transaction.Notes,
-		&transaction.UserID,
-		&transaction.CreatedAt,
-		&transaction.CreatedBy,
-		&transaction.UpdatedAt,
-		&transaction.UpdatedBy,
-	)
-	if err != nil {
-		return nil, err
-	}
-	return transaction, nil
+	row := tx.QueryRow(`
+		INSERT INTO transactions (amount, account_id, transaction_category_id, transaction_date, transaction_type, notes,
+		transfer_id, transfer_direction, recurring_transaction_id, recurring_date, external_id, user_id, created_by, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, (SELECT currency FROM accounts WHERE id = $2)) RETURNING `+transactionColumns,
+		transaction.Amount, transaction.AccountID, transaction.CategoryID,
+		transaction.TransactionDate, transaction.TransactionType, transaction.Notes,
+		transaction.TransferID, transaction.TransferDirection, transaction.RecurringTransactionID, transaction.RecurringDate,
+		transaction.ExternalID, transaction.UserID.String(), transaction.CreatedBy)
+	return scanTransaction(row)
 }
 
 // Delete implements domain.TransactionRepository.
@@ -85,50 +162,69 @@ func (t *transactionRepo) Delete(tx *sql.Tx, id int, userID uuid.UUID) error {
 	return nil
 }
 
-func (t *transactionRepo) FindByFilter(params dto.GetTransactionParams) ([]dto.TransactionDto, error) {
-	query := `
-	SELECT 
-		t.id, t.ammount, t.transaction_category_id, t.transaction_sub_category_id, t.transaction_date, 
-		t.transaction_type, t.notes, t.user_id, t.created_at, t.created_by, 
-		t.updated_at, t.updated_by,
-		tc.name AS category,
-		tsc.name AS sub_category
-	FROM transactions t
-	INNER JOIN transaction_categories tc ON t.transaction_category_id = tc.id
-	LEFT JOIN transaction_sub_categories tsc ON t.transaction_sub_category_id = tsc.id
-	WHERE t.user_id = $1
-	`
+// SoftDelete implements domain.TransactionRepository.
+func (t *transactionRepo) SoftDelete(tx *sql.Tx, id int, userID uuid.UUID) error {
+	_, err := tx.Exec(`
+		UPDATE transactions SET deleted_at = now(), updated_at = now(), updated_by = $2
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`,
+		id, userID)
+	return err
+}
 
-	args := []interface{}{params.UserId}
-	argPos := 2
+// Restore implements domain.TransactionRepository.
+func (t *transactionRepo) Restore(tx *sql.Tx, id int, userID uuid.UUID) (*domain.Transaction, error) {
+	row := tx.QueryRow(`
+		UPDATE transactions SET deleted_at = NULL, updated_at = now(), updated_by = $2
+		WHERE id = $1 AND user_id = $2 RETURNING `+transactionColumns,
+		id, userID)
+	return scanTransaction(row)
+}
 
-	if params.CategoryID != nil {
-		query += fmt.Sprintf(" AND t.category_id = $%d", argPos)
-		args = append(args, *params.CategoryID)
-		argPos++
-	}
-	if params.SubCategoryID != nil {
-		query += fmt.Sprintf(" AND t.sub_category_id = $%d", argPos)
-		args = append(args, *params.SubCategoryID)
-		argPos++
-	}
-	if params.TransactionType != nil {
-		query += fmt.Sprintf(" AND t.transaction_type = $%d", argPos)
-		args = append(args, *params.TransactionType)
-		argPos++
+// FindDeleted implements domain.TransactionRepository.
+func (t *transactionRepo) FindDeleted(userID uuid.UUID, limit int, offset int) ([]dto.TransactionDto, error) {
+	rows, err := t.db.Query(transactionDtoQuery+`
+		AND t.deleted_at IS NOT NULL
+		ORDER BY t.deleted_at DESC, t.id DESC
+		LIMIT $3 OFFSET $4`,
+		userID, nil, limit, offset)
+	if err != nil {
+		return nil, err
 	}
-	if params.StartDate != nil {
-		query += fmt.Sprintf(" AND t.transaction_date >= $%d", argPos)
-		args = append(args, *params.StartDate)
-		argPos++
+	defer rows.Close()
+
+	var transactions []dto.TransactionDto
+	for rows.Next() {
+		transaction, err := scanTransactionDto(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, *transaction)
 	}
-	if params.EndDate != nil {
-		query += fmt.Sprintf(" AND t.transaction_date <= $%d", argPos)
-		args = append(args, *params.EndDate)
-		argPos++
+	return transactions, rows.Err()
+}
+
+// CountDeleted implements domain.TransactionRepository.
+func (t *transactionRepo) CountDeleted(userID uuid.UUID) (int, error) {
+	var count int
+	err := t.db.QueryRow(`SELECT COUNT(*) FROM transactions WHERE user_id = $1 AND deleted_at IS NOT NULL`, userID).Scan(&count)
+	return count, err
+}
+
+// PurgeDeleted implements domain.TransactionRepository. Their ledger entries were reversed when
+// they were deleted and stay behind; splits and tags go with the rows. Transfer legs are purged
+// with their transfer.
+func (t *transactionRepo) PurgeDeleted(before time.Time) (int64, error) {
+	result, err := t.db.Exec(`DELETE FROM transactions WHERE deleted_at < $1 AND transfer_id IS NULL`, before)
+	if err != nil {
+		return 0, err
 	}
+	return result.RowsAffected()
+}
 
-	query += fmt.Sprintf(" ORDER BY t.transaction_date ASC LIMIT $%d OFFSET $%d", argPos, argPos+1)
+func (t *transactionRepo) FindByFilter(params dto.GetTransactionParams) ([]dto.TransactionDto, error) {
+	query, args := transactionFilter(transactionDtoQuery, params)
+	query += transactionOrder(params)
+	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
 	args = append(args, params.Limit, (params.Page-1)*params.Limit)
 
 	rows, err := t.db.Query(query, args...)
@@ -139,73 +235,353 @@ func (t *transactionRepo) FindByFilter(params dto.GetTransactionParams) ([]dto.T
 
 	var transactions []dto.TransactionDto
 	for rows.Next() {
-		var tx dto.TransactionDto
-		if err := rows.Scan(
-			&tx.ID, &tx.Ammount, &tx.CategoryID, &tx.SubCategoryID, &tx.TransactionDate,
-			&tx.TransactionType, &tx.Notes, &tx.UserID, &tx.CreatedAt, &tx.CreatedBy,
-			&tx.UpdatedAt, &tx.UpdatedBy, &tx.Category, &tx.SubCategory,
-		); err != nil {
+		tx, err := scanTransactionDto(rows)
+		if err != nil {
 			return nil, err
 		}
-		transactions = append(transactions, tx)
+		transactions = append(transactions, *tx)
 	}
 
 	return transactions, nil
 }
 
+// FindByCursor implements domain.TransactionRepository.
+func (t *transactionRepo) FindByCursor(params dto.GetTransactionParams, after *domain.TransactionCursor, descending bool) ([]dto.TransactionDto, error) {
+	query, args := transactionFilter(transactionDtoQuery, params)
+	comparison, direction := ">", "ASC"
+	if descending {
+		comparison, direction = "<", "DESC"
+	}
+	if after != nil {
+		query += fmt.Sprintf(" AND (t.transaction_date, t.id) %s ($%d::timestamp, $%d)", comparison, len(args)+1, len(args)+2)
+		args = append(args, after.Date, after.ID)
+	}
+	query += fmt.Sprintf(" ORDER BY t.transaction_date %[1]s, t.id %[1]s LIMIT $%[2]d", direction, len(args)+1)
+	args = append(args, params.Limit)
 
-func (t *transactionRepo) FindByID(id int) (*domain.Transaction, error) {
-	row := t.db.QueryRow(`
-		SELECT id, amount, category_id, sub_category_id, transaction_date, transaction_type, notes, user_id, created_at, created_by, updated_at, updated_by
-		FROM transactions WHERE id = $1
-	`, id)
-	transaction := &domain.Transaction{}
+	rows, err := t.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []dto.TransactionDto
+	for rows.Next() {
+		tx, err := scanTransactionDto(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, *tx)
+	}
+	return transactions, rows.Err()
+}
+
+// transactionSortColumns maps the sortable fields to expressions of transactionDtoQuery.
+var transactionSortColumns = map[string]string{
+	domain.TransactionSortDate:      "t.transaction_date",
+	domain.TransactionSortAmount:    "t.amount",
+	domain.TransactionSortCreatedAt: "t.created_at",
+	domain.TransactionSortUpdatedAt: "t.updated_at",
+	domain.TransactionSortAccount:   "lower(a.name)",
+	domain.TransactionSortCategory:  "lower(tc.name)",
+	domain.TransactionSortRelevance: "search_rank",
+}
+
+// transactionOrder returns the ORDER BY clause for params.Sort, which the caller has validated.
+// Without one, search results come by relevance and other listings by date. The id always comes
+// last so rows that tie keep a stable order across pages.
+func transactionOrder(params dto.GetTransactionParams) string {
+	keys := []domain.TransactionSort{{Field: domain.TransactionSortDate}}
+	if searchQuery(params) != nil {
+		keys = append([]domain.TransactionSort{{Field: domain.TransactionSortRelevance, Descending: true}}, keys...)
+	}
+	if params.Sort != nil && *params.Sort != "" {
+		if parsed, err := domain.ParseTransactionSort(*params.Sort); err == nil {
+			keys = parsed
+		}
+	}
+
+	terms := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		direction := "ASC"
+		if key.Descending {
+			direction = "DESC"
+		}
+		terms = append(terms, transactionSortColumns[key.Field]+" "+direction+" NULLS LAST")
+	}
+	terms = append(terms, "t.id ASC")
+	return " ORDER BY " + strings.Join(terms, ", ")
+}
+
+// StreamByFilter calls fn for every transaction matching params, ignoring paging. Rows are read
+// through a server-side cursor in batches, so the result set never has to fit in memory.
+func (t *transactionRepo) StreamByFilter(params dto.GetTransactionParams, fn func(*dto.TransactionDto) error) error {
+	query, args := transactionFilter(transactionDtoQuery, params)
+	query += " ORDER BY t.transaction_date ASC, t.id ASC"
+
+	tx, err := t.db.BeginTx(context.Background(), &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DECLARE transaction_export NO SCROLL CURSOR FOR `+query, args...); err != nil {
+		return err
+	}
+	for {
+		rows, err := tx.Query(fmt.Sprintf(`FETCH %d FROM transaction_export`, streamBatchSize))
+		if err != nil {
+			return err
+		}
+		fetched := 0
+		for rows.Next() {
+			fetched++
+			transaction, err := scanTransactionDto(rows)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			if err := fn(transaction); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if fetched < streamBatchSize {
+			break
+		}
+	}
+
+	if _, err := tx.Exec(`CLOSE transaction_export`); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+const streamBatchSize = 500
+
+const transactionDtoQuery = `
+	SELECT 
+		t.id, t.amount, t.currency, t.account_id, t.transaction_category_id, t.transaction_date, 
+		t.transaction_type, t.notes, t.transfer_id, t.transfer_direction, t.recurring_transaction_id, t.user_id, t.created_at, t.created_by, 
+		t.updated_at, t.updated_by, t.deleted_at,
+		(SELECT COALESCE(json_agg(json_build_object('id', g.id, 'name', g.name) ORDER BY lower(g.name)), '[]')
+			FROM transaction_tags tt INNER JOIN tags g ON tt.tag_id = g.id
+			WHERE tt.transaction_id = t.id) AS tags,
+		(SELECT COALESCE(json_agg(json_build_object(
+				'category_id', s.transaction_category_id, 'category', sc.name,
+				'category_path', transaction_category_path(s.transaction_category_id),
+				'amount', s.amount, 'note', s.notes) ORDER BY s.id), '[]')
+			FROM transaction_splits s
+			INNER JOIN transaction_categories sc ON s.transaction_category_id = sc.id
+			WHERE s.transaction_id = t.id) AS splits,
+		CASE WHEN $2::text IS NOT NULL THEN ts_rank(t.search_vector, websearch_to_tsquery('simple', $2)) END AS search_rank,
+		CASE WHEN $2::text IS NOT NULL THEN ts_headline('simple', transaction_search_text(t.id), websearch_to_tsquery('simple', $2),
+			'MaxWords=20, MinWords=5, MaxFragments=2') END AS snippet,
+		a.name AS account,
+		tc.name AS category,
+		transaction_category_path(tc.id) AS category_path
+	FROM transactions t
+	INNER JOIN accounts a ON t.account_id = a.id
+	LEFT JOIN transaction_categories tc ON t.transaction_category_id = tc.id
+	WHERE t.user_id = $1
+	`
+
+func scanTransactionDto(row rowScanner) (*dto.TransactionDto, error) {
+	tx := &dto.TransactionDto{}
+	var tags, splits []byte
 	err := row.Scan(
-		&transaction.ID,
-		&transaction.Ammount,
-		&transaction.CategoryID,
-		&transaction.SubCategoryID,
-		&transaction.TransactionDate,
-		&transaction.TransactionType,
-		&transaction.Notes,
-		&transaction.UserID,
-		&transaction.CreatedAt,
-		&transaction.CreatedBy,
-		&transaction.UpdatedAt,
-		&transaction.UpdatedBy,
+		&tx.ID, &tx.Amount.Amount, &tx.Currency, &tx.AccountID, &tx.CategoryID, &tx.TransactionDate,
+		&tx.TransactionType, &tx.Notes, &tx.TransferID, &tx.TransferDirection, &tx.RecurringTransactionID, &tx.UserID, &tx.CreatedAt, &tx.CreatedBy,
+		&tx.UpdatedAt, &tx.UpdatedBy, &tx.DeletedAt, &tags, &splits, &tx.Rank, &tx.Snippet, &tx.Account, &tx.Category, &tx.CategoryPath,
 	)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
 		return nil, err
 	}
-	return transaction, nil
+	if err := json.Unmarshal(tags, &tx.Tags); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(splits, &tx.Splits); err != nil {
+		return nil, err
+	}
+	tx.Amount.Currency = tx.Currency
+	for i := range tx.Splits {
+		tx.Splits[i].Amount.Currency = tx.Currency
+	}
+	return tx, nil
+}
+
+func (t *transactionRepo) FindByID(id int) (*domain.Transaction, error) {
+	row := t.db.QueryRow(`SELECT `+transactionColumns+` FROM transactions WHERE id = $1`, id)
+	return scanTransaction(row)
+}
+
+// FindByIDTx locks the transaction row until tx ends so concurrent edits cannot apply the same balance correction twice.
+func (t *transactionRepo) FindByIDTx(tx *sql.Tx, id int) (*domain.Transaction, error) {
+	row := tx.QueryRow(`SELECT `+transactionColumns+` FROM transactions WHERE id = $1 FOR UPDATE`, id)
+	return scanTransaction(row)
+}
+
+func (t *transactionRepo) FindByTransferID(transferID int) ([]domain.Transaction, error) {
+	rows, err := t.db.Query(`SELECT `+transactionColumns+` FROM transactions WHERE transfer_id = $1 ORDER BY id`, transferID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTransactions(rows)
+}
+
+func (t *transactionRepo) FindByTransferIDTx(tx *sql.Tx, transferID int) ([]domain.Transaction, error) {
+	rows, err := tx.Query(`SELECT `+transactionColumns+` FROM transactions WHERE transfer_id = $1 ORDER BY id FOR UPDATE`, transferID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTransactions(rows)
+}
+
+// ExistsByRecurrence reports whether the occurrence of a recurring rule on date has already been posted.
+func (t *transactionRepo) ExistsByRecurrence(recurringTransactionID int, date time.Time) (bool, error) {
+	var exists bool
+	err := t.db.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM transactions WHERE recurring_transaction_id = $1 AND recurring_date = $2)`,
+		recurringTransactionID, date).Scan(&exists)
+	return exists, err
+}
+
+// ExistsByExternalID reports whether a statement entry with externalID has already been imported into the account.
+func (t *transactionRepo) ExistsByExternalID(accountID int, externalID string) (bool, error) {
+	var exists bool
+	err := t.db.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM transactions WHERE account_id = $1 AND external_id = $2)`,
+		accountID, externalID).Scan(&exists)
+	return exists, err
+}
+
+func scanTransactions(rows *sql.Rows) ([]domain.Transaction, error) {
+	defer rows.Close()
+
+	var transactions []domain.Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, *transaction)
+	}
+	return transactions, rows.Err()
 }
 
 func (t *transactionRepo) Update(tx *sql.Tx, transaction *domain.Transaction) (*domain.Transaction, error) {
-	err := tx.QueryRow(`
+	row := tx.QueryRow(`
 		UPDATE transactions
-		SET ammount = $1, transaction_category_id = $2, transaction_sub_category_id = $3, transaction_date = $4, transaction_type = $5, notes = $6, updated_at = now(), updated_by = $7
-		WHERE id = $8 RETURNING id, ammount, transaction_category_id, transaction_sub_category_id, transaction_date, transaction_type, notes, user_id, created_at, created_by,
-		updated_at, updated_by
-	`, transaction.Ammount, transaction.CategoryID, transaction.SubCategoryID,
+		SET amount = $1, account_id = $2, transaction_category_id = $3, transaction_date = $4, transaction_type = $5, notes = $6,
+		currency = (SELECT currency FROM accounts WHERE id = $2), updated_at = now(), updated_by = $7
+		WHERE id = $8 AND user_id = $9 RETURNING `+transactionColumns,
+		transaction.Amount, transaction.AccountID, transaction.CategoryID,
 		transaction.TransactionDate, transaction.TransactionType,
-		transaction.Notes, transaction.UpdatedBy, transaction.ID).Scan(
+		transaction.Notes, transaction.UpdatedBy, transaction.ID, transaction.UserID)
+	return scanTransaction(row)
+}
+
+func (t *transactionRepo) FindSplitsByTransactionID(transactionID int) ([]domain.TransactionSplit, error) {
+	rows, err := t.db.Query(`SELECT `+transactionSplitColumns+` FROM transaction_splits WHERE transaction_id = $1 ORDER BY id`, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTransactionSplits(rows)
+}
+
+func (t *transactionRepo) FindSplitsByTransactionIDTx(tx *sql.Tx, transactionID int) ([]domain.TransactionSplit, error) {
+	rows, err := tx.Query(`SELECT `+transactionSplitColumns+` FROM transaction_splits WHERE transaction_id = $1 ORDER BY id`, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTransactionSplits(rows)
+}
+
+// ReplaceSplits implements domain.TransactionRepository.
+func (t *transactionRepo) ReplaceSplits(tx *sql.Tx, transactionID int, splits []domain.TransactionSplit) ([]domain.TransactionSplit, error) {
+	if _, err := tx.Exec(`DELETE FROM transaction_splits WHERE transaction_id = $1`, transactionID); err != nil {
+		return nil, err
+	}
+	created := make([]domain.TransactionSplit, 0, len(splits))
+	for _, split := range splits {
+		row := tx.QueryRow(`
+			INSERT INTO transaction_splits (transaction_id, transaction_category_id, amount, notes)
+			VALUES ($1, $2, $3, $4) RETURNING `+transactionSplitColumns,
+			transactionID, split.CategoryID, split.Amount, split.Notes)
+		createdSplit, err := scanTransactionSplit(row)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, *createdSplit)
+	}
+	return created, nil
+}
+
+const transactionSplitColumns = `id, transaction_id, transaction_category_id, amount, notes`
+
+func scanTransactionSplit(row rowScanner) (*domain.TransactionSplit, error) {
+	split := &domain.TransactionSplit{}
+	err := row.Scan(&split.ID, &split.TransactionID, &split.CategoryID, &split.Amount, &split.Notes)
+	if err != nil {
+		return nil, err
+	}
+	return split, nil
+}
+
+func scanTransactionSplits(rows *sql.Rows) ([]domain.TransactionSplit, error) {
+	defer rows.Close()
+
+	var splits []domain.TransactionSplit
+	for rows.Next() {
+		split, err := scanTransactionSplit(rows)
+		if err != nil {
+			return nil, err
+		}
+		splits = append(splits, *split)
+	}
+	return splits, rows.Err()
+}
+
+// A transaction always carries the currency of its account; Create and Update copy it over.
+const transactionColumns = `id, amount, currency, account_id, transaction_category_id, transaction_date, transaction_type, notes,
+	transfer_id, transfer_direction, recurring_transaction_id, recurring_date, external_id, user_id, created_at, created_by, updated_at, updated_by,
+	deleted_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTransaction(row rowScanner) (*domain.Transaction, error) {
+	transaction := &domain.Transaction{}
+	err := row.Scan(
 		&transaction.ID,
-		&transaction.Ammount,
+		&transaction.Amount,
+		&transaction.Currency,
+		&transaction.AccountID,
 		&transaction.CategoryID,
-		&transaction.SubCategoryID,
 		&transaction.TransactionDate,
 		&transaction.TransactionType,
 		&transaction.Notes,
+		&transaction.TransferID,
+		&transaction.TransferDirection,
+		&transaction.RecurringTransactionID,
+		&transaction.RecurringDate,
+		&transaction.ExternalID,
 		&transaction.UserID,
 		&transaction.CreatedAt,
 		&transaction.CreatedBy,
 		&transaction.UpdatedAt,
 		&transaction.UpdatedBy,
+		&transaction.DeletedAt,
 	)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
 		return nil, err
 	}
 	return transaction, nil
@@ -0,0 +1,238 @@
+package pgrepository
+
+import (
+	"database/sql"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/database/migration"
+	"github.com/google/uuid"
+)
+
+var migrateOnce sync.Once
+
+// testDB connects to TEST_DATABASE_URL and brings its schema up to date, skipping the test when
+// the variable is not set.
+func testDB(t *testing.T) *sql.DB {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.Ping(); err != nil {
+		t.Fatalf("connect to test database: %v", err)
+	}
+	migrateOnce.Do(func() { migration.Initiator(db) })
+	return db
+}
+
+// transactionFixture is a user of its own with a few transactions, so the tests neither see nor
+// disturb other rows of the test database. Transactions are referred to by name.
+type transactionFixture struct {
+	userID string
+	names  map[int]string
+	ids    map[string]int
+	cats   map[string]int
+}
+
+func seedTransactions(t *testing.T, db *sql.DB) *transactionFixture {
+	t.Helper()
+	f := &transactionFixture{
+		userID: uuid.New().String(),
+		names:  map[int]string{},
+		ids:    map[string]int{},
+		cats:   map[string]int{},
+	}
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.Exec(query, args...); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+	insert := func(query string, args ...interface{}) int {
+		t.Helper()
+		var id int
+		if err := db.QueryRow(query+` RETURNING id`, args...).Scan(&id); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+		return id
+	}
+
+	exec(`INSERT INTO users (id, username, password, email, created_by) VALUES ($1, $2, 'x', $3, 'TEST')`,
+		f.userID, "filter-"+f.userID, f.userID+"@example.com")
+	t.Cleanup(func() {
+		// Transactions restrict deleting their categories, so they go before the user's cascade.
+		db.Exec(`DELETE FROM transactions WHERE user_id = $1`, f.userID)
+		db.Exec(`DELETE FROM users WHERE id = $1`, f.userID)
+	})
+
+	wallet := insert(`INSERT INTO accounts (user_id, name, account_type, created_by) VALUES ($1, 'Wallet', 'cash', 'TEST')`, f.userID)
+	bank := insert(`INSERT INTO accounts (user_id, name, account_type, created_by) VALUES ($1, 'Bank', 'bank', 'TEST')`, f.userID)
+
+	category := func(name string, parentID interface{}) {
+		f.cats[name] = insert(`INSERT INTO transaction_categories (name, parent_id, user_id, created_by) VALUES ($1, $2, $3, 'TEST')`,
+			name, parentID, f.userID)
+	}
+	category("Food", nil)
+	category("Groceries", f.cats["Food"])
+	category("Salary", nil)
+	category("Rent", nil)
+
+	transaction := func(name string, amount int64, accountID int, categoryID interface{}, date, transactionType string,
+		notes interface{}, createdAt string, updatedAt interface{}) {
+		id := insert(`INSERT INTO transactions (amount, account_id, transaction_category_id, transaction_date, transaction_type,
+			notes, user_id, created_at, updated_at, created_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 'TEST')`,
+			amount, accountID, categoryID, date, transactionType, notes, f.userID, createdAt, updatedAt)
+		f.ids[name], f.names[id] = id, name
+	}
+	transaction("lunch", 50000, wallet, f.cats["Food"], "2026-01-05", "expense", "Lunch with the team", "2026-01-05 12:00:00", nil)
+	transaction("groceries", 120000, bank, f.cats["Groceries"], "2026-01-06", "expense", "Weekly GROCERIES run", "2026-01-06 09:00:00", "2026-02-01 10:00:00")
+	transaction("salary", 5000000, bank, f.cats["Salary"], "2026-01-01", "income", "January salary", "2026-01-01 08:00:00", "2026-01-10 23:59:00")
+	transaction("rent", 2000000, bank, f.cats["Rent"], "2026-01-05", "expense", nil, "2026-01-31 23:59:59", nil)
+	transaction("split", 300000, wallet, nil, "2026-01-08", "expense", "Market and rent share", "2026-01-08 10:00:00", nil)
+	transaction("coffee", 50000, wallet, f.cats["Food"], "2026-01-07", "expense", "Coffee, 50% off", "2026-01-07 10:00:00", nil)
+	transaction("deleted", 50000, wallet, f.cats["Food"], "2026-01-05", "expense", "Lunch again", "2026-01-05 13:00:00", nil)
+
+	exec(`INSERT INTO transaction_splits (transaction_id, transaction_category_id, amount) VALUES ($1, $2, 100000), ($1, $3, 200000)`,
+		f.ids["split"], f.cats["Groceries"], f.cats["Rent"])
+	exec(`UPDATE transactions SET deleted_at = now() WHERE id = $1`, f.ids["deleted"])
+	return f
+}
+
+// find lists the fixture's transactions matching params, by name and in the order returned, and
+// checks CountByFilter agrees with it.
+func (f *transactionFixture) find(t *testing.T, repo *transactionRepo, params dto.GetTransactionParams) []string {
+	t.Helper()
+	params.UserId = &f.userID
+	params.Limit, params.Page = 100, 1
+
+	transactions, err := repo.FindByFilter(params)
+	if err != nil {
+		t.Fatalf("FindByFilter: %v", err)
+	}
+	names := []string{}
+	for _, transaction := range transactions {
+		names = append(names, f.names[transaction.ID])
+	}
+
+	count, err := repo.CountByFilter(params)
+	if err != nil {
+		t.Fatalf("CountByFilter: %v", err)
+	}
+	if count != len(names) {
+		t.Errorf("CountByFilter = %d, FindByFilter returned %d", count, len(names))
+	}
+	return names
+}
+
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTransactionFilter(t *testing.T) {
+	db := testDB(t)
+	f := seedTransactions(t, db)
+	repo := &transactionRepo{db: db}
+
+	intPtr := func(v int) *int { return &v }
+	int64Ptr := func(v int64) *int64 { return &v }
+	strPtr := func(v string) *string { return &v }
+
+	tests := []struct {
+		name   string
+		params dto.GetTransactionParams
+		want   []string
+	}{
+		{"no filter leaves out deleted", dto.GetTransactionParams{},
+			[]string{"salary", "lunch", "rent", "groceries", "coffee", "split"}},
+		{"category takes in its subtree and splits", dto.GetTransactionParams{CategoryIDs: []int{f.cats["Food"]}},
+			[]string{"lunch", "groceries", "coffee", "split"}},
+		{"leaf category", dto.GetTransactionParams{CategoryIDs: []int{f.cats["Groceries"]}},
+			[]string{"groceries", "split"}},
+		{"several categories", dto.GetTransactionParams{CategoryIDs: []int{f.cats["Salary"], f.cats["Rent"]}},
+			[]string{"salary", "rent", "split"}},
+		{"category_id joins category_ids", dto.GetTransactionParams{CategoryID: intPtr(f.cats["Salary"]), CategoryIDs: []int{f.cats["Rent"]}},
+			[]string{"salary", "rent", "split"}},
+		{"min amount is inclusive", dto.GetTransactionParams{MinAmount: int64Ptr(120000)},
+			[]string{"salary", "rent", "groceries", "split"}},
+		{"max amount is inclusive", dto.GetTransactionParams{MaxAmount: int64Ptr(50000)},
+			[]string{"lunch", "coffee"}},
+		{"amount range", dto.GetTransactionParams{MinAmount: int64Ptr(100000), MaxAmount: int64Ptr(2000000)},
+			[]string{"rent", "groceries", "split"}},
+		{"note contains ignores case", dto.GetTransactionParams{NoteContains: strPtr("groceries")},
+			[]string{"groceries"}},
+		{"note contains is literal", dto.GetTransactionParams{NoteContains: strPtr("50%")},
+			[]string{"coffee"}},
+		{"empty note contains is ignored", dto.GetTransactionParams{NoteContains: strPtr("")},
+			[]string{"salary", "lunch", "rent", "groceries", "coffee", "split"}},
+		{"created from", dto.GetTransactionParams{CreatedFrom: strPtr("2026-01-06")},
+			[]string{"rent", "groceries", "coffee", "split"}},
+		{"created to takes in the whole day", dto.GetTransactionParams{CreatedTo: strPtr("2026-01-05")},
+			[]string{"salary", "lunch"}},
+		{"created on a single day", dto.GetTransactionParams{CreatedFrom: strPtr("2026-01-31"), CreatedTo: strPtr("2026-01-31")},
+			[]string{"rent"}},
+		{"updated from", dto.GetTransactionParams{UpdatedFrom: strPtr("2026-01-10")},
+			[]string{"salary", "groceries"}},
+		{"updated to leaves out never updated", dto.GetTransactionParams{UpdatedTo: strPtr("2026-01-10")},
+			[]string{"salary"}},
+		{"updated on a single day", dto.GetTransactionParams{UpdatedFrom: strPtr("2026-02-01"), UpdatedTo: strPtr("2026-02-01")},
+			[]string{"groceries"}},
+		{"combined", dto.GetTransactionParams{CategoryIDs: []int{f.cats["Food"]}, MaxAmount: int64Ptr(120000), CreatedFrom: strPtr("2026-01-06")},
+			[]string{"groceries", "coffee"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.find(t, repo, tt.params); !equalNames(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransactionOrder(t *testing.T) {
+	db := testDB(t)
+	f := seedTransactions(t, db)
+	repo := &transactionRepo{db: db}
+
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{"", []string{"salary", "lunch", "rent", "groceries", "coffee", "split"}},
+		// Equal amounts and dates fall back to the id, ascending whatever the direction.
+		{"amount", []string{"lunch", "coffee", "groceries", "split", "rent", "salary"}},
+		{"amount:desc", []string{"salary", "rent", "split", "groceries", "lunch", "coffee"}},
+		{"amount:DESC", []string{"salary", "rent", "split", "groceries", "lunch", "coffee"}},
+		{"transaction_date:desc", []string{"split", "coffee", "groceries", "lunch", "rent", "salary"}},
+		{"created_at:desc", []string{"rent", "split", "coffee", "groceries", "lunch", "salary"}},
+		{"updated_at", []string{"salary", "groceries", "lunch", "rent", "split", "coffee"}},
+		{"account,amount:desc", []string{"salary", "rent", "groceries", "split", "lunch", "coffee"}},
+		{"category", []string{"lunch", "coffee", "groceries", "rent", "salary", "split"}},
+		// Unknown keys never reach SQL; the listing keeps its default order.
+		{"t.id; DROP TABLE transactions", []string{"salary", "lunch", "rent", "groceries", "coffee", "split"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			sort := tt.sort
+			got := f.find(t, repo, dto.GetTransactionParams{Sort: &sort})
+			if !equalNames(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
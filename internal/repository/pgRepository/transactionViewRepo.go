@@ -0,0 +1,96 @@
+package pgrepository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/google/uuid"
+)
+
+type transactionViewPgRepository struct {
+	db *sql.DB
+}
+
+func (t *transactionViewPgRepository) FindByID(id int) (*domain.TransactionView, error) {
+	row := t.db.QueryRow(`SELECT `+transactionViewColumns+` FROM transaction_views WHERE id = $1`, id)
+	return scanTransactionView(row)
+}
+
+// FindByName looks a view up by name, ignoring case.
+func (t *transactionViewPgRepository) FindByName(userID uuid.UUID, name string) (*domain.TransactionView, error) {
+	row := t.db.QueryRow(`SELECT `+transactionViewColumns+` FROM transaction_views WHERE user_id = $1 AND lower(name) = lower($2)`, userID, name)
+	return scanTransactionView(row)
+}
+
+func (t *transactionViewPgRepository) FindByUserID(userID uuid.UUID) ([]domain.TransactionView, error) {
+	rows, err := t.db.Query(`SELECT `+transactionViewColumns+` FROM transaction_views WHERE user_id = $1 ORDER BY lower(name)`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []domain.TransactionView
+	for rows.Next() {
+		view, err := scanTransactionView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, *view)
+	}
+	return views, rows.Err()
+}
+
+func (t *transactionViewPgRepository) Create(view *domain.TransactionView) (*domain.TransactionView, error) {
+	filter, err := json.Marshal(view.Filter)
+	if err != nil {
+		return nil, err
+	}
+	row := t.db.QueryRow(`
+		INSERT INTO transaction_views (user_id, name, filter, created_by)
+		VALUES ($1, $2, $3, $4) RETURNING `+transactionViewColumns,
+		view.UserID, view.Name, filter, view.CreatedBy)
+	return scanTransactionView(row)
+}
+
+func (t *transactionViewPgRepository) Update(view *domain.TransactionView) (*domain.TransactionView, error) {
+	filter, err := json.Marshal(view.Filter)
+	if err != nil {
+		return nil, err
+	}
+	row := t.db.QueryRow(`
+		UPDATE transaction_views SET name = $1, filter = $2, updated_at = now(), updated_by = $3
+		WHERE id = $4 RETURNING `+transactionViewColumns,
+		view.Name, filter, view.UpdatedBy, view.ID)
+	return scanTransactionView(row)
+}
+
+func (t *transactionViewPgRepository) Delete(id int) error {
+	_, err := t.db.Exec(`DELETE FROM transaction_views WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+const transactionViewColumns = `id, user_id, name, filter, created_at, created_by, updated_at, updated_by`
+
+func scanTransactionView(row rowScanner) (*domain.TransactionView, error) {
+	view := &domain.TransactionView{}
+	var filter []byte
+	err := row.Scan(&view.ID, &view.UserID, &view.Name, &filter, &view.CreatedAt, &view.CreatedBy, &view.UpdatedAt, &view.UpdatedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(filter, &view.Filter); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+func NewTransactionViewPgRepository(db *sql.DB) domain.TransactionViewRepository {
+	return &transactionViewPgRepository{db: db}
+}
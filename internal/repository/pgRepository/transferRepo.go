@@ -0,0 +1,150 @@
+package pgrepository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/google/uuid"
+)
+
+type transferPgRepository struct {
+	db *sql.DB
+}
+
+func (t *transferPgRepository) Create(tx *sql.Tx, transfer *domain.Transfer) (*domain.Transfer, error) {
+	row := tx.QueryRow(`
+		INSERT INTO transfers (user_id, from_account_id, to_account_id, amount, fee, transfer_date, notes, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING `+transferColumns,
+		transfer.UserID, transfer.FromAccountID, transfer.ToAccountID, transfer.Amount, transfer.Fee,
+		transfer.TransferDate, transfer.Notes, transfer.CreatedBy)
+	return scanTransfer(row)
+}
+
+// SoftDelete implements domain.TransferRepository.
+func (t *transferPgRepository) SoftDelete(tx *sql.Tx, id int, userID uuid.UUID) error {
+	_, err := tx.Exec(`
+		UPDATE transfers SET deleted_at = now(), updated_at = now(), updated_by = $2
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`,
+		id, userID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		UPDATE transactions SET deleted_at = now(), updated_at = now(), updated_by = $2
+		WHERE transfer_id = $1 AND user_id = $2 AND deleted_at IS NULL`,
+		id, userID)
+	return err
+}
+
+// Restore implements domain.TransferRepository.
+func (t *transferPgRepository) Restore(tx *sql.Tx, id int, userID uuid.UUID) (*domain.Transfer, error) {
+	_, err := tx.Exec(`
+		UPDATE transactions SET deleted_at = NULL, updated_at = now(), updated_by = $2
+		WHERE transfer_id = $1 AND user_id = $2`,
+		id, userID)
+	if err != nil {
+		return nil, err
+	}
+	row := tx.QueryRow(`
+		UPDATE transfers SET deleted_at = NULL, updated_at = now(), updated_by = $2
+		WHERE id = $1 AND user_id = $2 RETURNING `+transferColumns,
+		id, userID)
+	return scanTransfer(row)
+}
+
+// FindDeleted implements domain.TransferRepository.
+func (t *transferPgRepository) FindDeleted(userID uuid.UUID, limit int, offset int) ([]domain.Transfer, error) {
+	rows, err := t.db.Query(`
+		SELECT `+transferColumns+` FROM transfers
+		WHERE user_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $2 OFFSET $3`,
+		userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return scanTransfers(rows)
+}
+
+// CountDeleted implements domain.TransferRepository.
+func (t *transferPgRepository) CountDeleted(userID uuid.UUID) (int, error) {
+	var count int
+	err := t.db.QueryRow(`SELECT COUNT(*) FROM transfers WHERE user_id = $1 AND deleted_at IS NOT NULL`, userID).Scan(&count)
+	return count, err
+}
+
+// PurgeDeleted implements domain.TransferRepository. The legs go through ON DELETE CASCADE; their
+// ledger entries were reversed when the transfer was deleted and stay behind.
+func (t *transferPgRepository) PurgeDeleted(before time.Time) (int64, error) {
+	result, err := t.db.Exec(`DELETE FROM transfers WHERE deleted_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (t *transferPgRepository) FindByID(id int) (*domain.Transfer, error) {
+	row := t.db.QueryRow(`SELECT `+transferColumns+` FROM transfers WHERE id = $1`, id)
+	return scanTransfer(row)
+}
+
+func (t *transferPgRepository) FindByIDTx(tx *sql.Tx, id int) (*domain.Transfer, error) {
+	row := tx.QueryRow(`SELECT `+transferColumns+` FROM transfers WHERE id = $1 FOR UPDATE`, id)
+	return scanTransfer(row)
+}
+
+func (t *transferPgRepository) FindByUserID(userID uuid.UUID) ([]domain.Transfer, error) {
+	rows, err := t.db.Query(`SELECT `+transferColumns+` FROM transfers WHERE user_id = $1 AND deleted_at IS NULL ORDER BY transfer_date DESC, id DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTransfers(rows)
+}
+
+func (t *transferPgRepository) Update(tx *sql.Tx, transfer *domain.Transfer) (*domain.Transfer, error) {
+	row := tx.QueryRow(`
+		UPDATE transfers
+		SET from_account_id = $1, to_account_id = $2, amount = $3, fee = $4, transfer_date = $5, notes = $6, updated_at = now(), updated_by = $7
+		WHERE id = $8 RETURNING `+transferColumns,
+		transfer.FromAccountID, transfer.ToAccountID, transfer.Amount, transfer.Fee,
+		transfer.TransferDate, transfer.Notes, transfer.UpdatedBy, transfer.ID)
+	return scanTransfer(row)
+}
+
+const transferColumns = `id, user_id, from_account_id, to_account_id, amount, fee, transfer_date, notes,
+	created_at, created_by, updated_at, updated_by, deleted_at`
+
+func scanTransfer(row rowScanner) (*domain.Transfer, error) {
+	transfer := &domain.Transfer{}
+	err := row.Scan(
+		&transfer.ID, &transfer.UserID, &transfer.FromAccountID, &transfer.ToAccountID,
+		&transfer.Amount, &transfer.Fee, &transfer.TransferDate, &transfer.Notes,
+		&transfer.CreatedAt, &transfer.CreatedBy, &transfer.UpdatedAt, &transfer.UpdatedBy, &transfer.DeletedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func scanTransfers(rows *sql.Rows) ([]domain.Transfer, error) {
+	defer rows.Close()
+
+	var transfers []domain.Transfer
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, *transfer)
+	}
+	return transfers, rows.Err()
+}
+
+func NewTransferPgRepository(db *sql.DB) domain.TransferRepository {
+	return &transferPgRepository{db: db}
+}
@@ -2,61 +2,147 @@ package pgrepository
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/dimas-pramantya/money-management/internal/domain"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const transactionCategoryColumns = `id, name, user_id, parent_id, kind, transaction_category_path(id), archived_at, created_at, created_by, updated_at, updated_by`
+
 type transactionCategoryPgRepository struct {
 	db *sql.DB
 }
 
+type rowQueryer interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
 func (t *transactionCategoryPgRepository) Create(category *domain.TransactionCategory) (*domain.TransactionCategory, error) {
-	err := t.db.QueryRow(`
-		INSERT INTO transaction_categories (name, user_id, created_by)
-		VALUES ($1, $2, $3) RETURNING id, name, user_id, created_at, created_by
-	`, category.Name, category.UserID, category.CreatedBy).Scan(
-		&category.ID, &category.Name, &category.UserID, &category.CreatedAt, &category.CreatedBy,
-	)
+	return createTransactionCategory(t.db, category)
+}
+
+func (t *transactionCategoryPgRepository) CreateTx(tx *sql.Tx, category *domain.TransactionCategory) (*domain.TransactionCategory, error) {
+	return createTransactionCategory(tx, category)
+}
+
+// createTransactionCategory and UpdateTx read the category back afterwards: the path function cannot
+// see the row a statement is still writing, so it cannot go in RETURNING.
+func createTransactionCategory(q rowQueryer, category *domain.TransactionCategory) (*domain.TransactionCategory, error) {
+	var id int
+	err := q.QueryRow(`
+		INSERT INTO transaction_categories (name, user_id, parent_id, kind, created_by)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		category.Name, category.UserID, category.ParentID, category.Kind, category.CreatedBy).Scan(&id)
 	if err != nil {
 		return nil, err
 	}
-	return category, nil
+	return scanTransactionCategory(q.QueryRow(`SELECT `+transactionCategoryColumns+` FROM transaction_categories WHERE id = $1`, id))
 }
 
-func (t *transactionCategoryPgRepository) Delete(id int) error {
-	_, err := t.db.Exec(`DELETE FROM transaction_categories WHERE id = $1`, id)
+func (t *transactionCategoryPgRepository) CountUsage(ids []int, transactionType string) (int, error) {
+	var count int
+	err := t.db.QueryRow(`
+		SELECT
+			(SELECT COUNT(*) FROM transactions WHERE transaction_category_id = ANY($1) AND transaction_type = $2)
+			+ (SELECT COUNT(*) FROM transaction_splits s INNER JOIN transactions t ON t.id = s.transaction_id
+				WHERE s.transaction_category_id = ANY($1) AND t.transaction_type = $2)
+			+ (SELECT COUNT(*) FROM recurring_transactions WHERE transaction_category_id = ANY($1) AND transaction_type = $2)`,
+		pq.Array(ids), transactionType).Scan(&count)
+	return count, err
+}
+
+func (t *transactionCategoryPgRepository) CountBudgets(ids []int) (int, error) {
+	var count int
+	err := t.db.QueryRow(`SELECT COUNT(*) FROM budgets WHERE transaction_category_id = ANY($1)`, pq.Array(ids)).Scan(&count)
+	return count, err
+}
+
+func (t *transactionCategoryPgRepository) FindBudgetPeriodConflictsTx(tx *sql.Tx, fromIDs []int, toID int) ([]string, error) {
+	rows, err := tx.Query(`
+		SELECT period FROM budgets
+		WHERE transaction_category_id = ANY($1) OR transaction_category_id = $2
+		GROUP BY period
+		HAVING COUNT(*) > 1 AND COUNT(*) FILTER (WHERE transaction_category_id = ANY($1)) > 0
+		ORDER BY period`,
+		pq.Array(fromIDs), toID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	defer rows.Close()
+
+	var periods []string
+	for rows.Next() {
+		var period string
+		if err := rows.Scan(&period); err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+	return periods, rows.Err()
 }
 
-func (t *transactionCategoryPgRepository) FindByID(id int) (*domain.TransactionCategory, error) {
-	row := t.db.QueryRow(`
-		SELECT id, name, user_id, created_at, created_by, updated_at, updated_by
-		FROM transaction_categories WHERE id = $1
-	`, id)
-	category := &domain.TransactionCategory{}
-	err := row.Scan(
-		&category.ID, &category.Name, &category.UserID, 
-		&category.CreatedAt, &category.CreatedBy, &category.UpdatedAt, &category.UpdatedBy,
-	)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
+// reassignViewFilter rewrites the category ids in a saved view filter, keeping each array free of
+// repeats. %[1]s names the filter key.
+const reassignViewFilter = `
+	UPDATE transaction_views SET filter = jsonb_set(filter, '{%[1]s}', (
+		SELECT COALESCE(jsonb_agg(DISTINCT CASE WHEN e::int = ANY($2) THEN $3 ELSE e::int END), '[]'::jsonb)
+		FROM jsonb_array_elements_text(filter->'%[1]s') AS e))
+	WHERE user_id = $1 AND jsonb_typeof(filter->'%[1]s') = 'array'`
+
+func (t *transactionCategoryPgRepository) ReassignTx(tx *sql.Tx, userID uuid.UUID, fromIDs []int, toID int) error {
+	statements := []string{
+		`UPDATE transactions SET transaction_category_id = $3 WHERE user_id = $1 AND transaction_category_id = ANY($2)`,
+		`UPDATE transaction_splits SET transaction_category_id = $3 WHERE transaction_category_id = ANY($2)
+			AND transaction_id IN (SELECT id FROM transactions WHERE user_id = $1)`,
+		`UPDATE recurring_transactions SET transaction_category_id = $3 WHERE user_id = $1 AND transaction_category_id = ANY($2)`,
+		`UPDATE budgets SET transaction_category_id = $3 WHERE user_id = $1 AND transaction_category_id = ANY($2)`,
+		`UPDATE import_profiles SET default_category_id = $3 WHERE user_id = $1 AND default_category_id = ANY($2)`,
+		`UPDATE transaction_views SET filter = jsonb_set(filter, '{category_id}', to_jsonb($3::int))
+			WHERE user_id = $1 AND (filter->>'category_id')::int = ANY($2)`,
+		fmt.Sprintf(reassignViewFilter, "category_ids"),
+		fmt.Sprintf(reassignViewFilter, "exclude_category_ids"),
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement, userID, pq.Array(fromIDs), toID); err != nil {
+			return err
 		}
-		return nil, err
 	}
-	return category, nil
+	return nil
+}
+
+func (t *transactionCategoryPgRepository) SetArchived(ids []int, archivedAt *time.Time, updatedBy string) error {
+	_, err := t.db.Exec(`
+		UPDATE transaction_categories SET archived_at = $2, updated_by = $3, updated_at = now()
+		WHERE id = ANY($1)`,
+		pq.Array(ids), archivedAt, updatedBy)
+	return err
+}
+
+func (t *transactionCategoryPgRepository) MoveChildrenTx(tx *sql.Tx, fromID int, toID int, updatedBy string) error {
+	_, err := tx.Exec(`
+		UPDATE transaction_categories SET parent_id = $2, updated_by = $3, updated_at = now()
+		WHERE parent_id = $1`,
+		fromID, toID, updatedBy)
+	return err
+}
+
+func (t *transactionCategoryPgRepository) DeleteTx(tx *sql.Tx, id int) error {
+	_, err := tx.Exec(`DELETE FROM transaction_categories WHERE id = $1`, id)
+	return err
+}
+
+func (t *transactionCategoryPgRepository) FindByID(id int) (*domain.TransactionCategory, error) {
+	row := t.db.QueryRow(`SELECT `+transactionCategoryColumns+` FROM transaction_categories WHERE id = $1`, id)
+	return scanTransactionCategory(row)
 }
 
 func (t *transactionCategoryPgRepository) FindByUserID(userID uuid.UUID) ([]domain.TransactionCategory, error) {
 	rows, err := t.db.Query(`
-		SELECT id, name, user_id, created_at, created_by, updated_at, updated_by
-		FROM transaction_categories WHERE user_id = $1
-	`, userID)
+		SELECT `+transactionCategoryColumns+` FROM transaction_categories
+		WHERE user_id = $1 ORDER BY lower(name), id`, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -64,29 +150,76 @@ func (t *transactionCategoryPgRepository) FindByUserID(userID uuid.UUID) ([]doma
 
 	var categories []domain.TransactionCategory
 	for rows.Next() {
-		category := domain.TransactionCategory{}
-		err := rows.Scan(
-			&category.ID, &category.Name, &category.UserID,
-			&category.CreatedAt, &category.CreatedBy, &category.UpdatedAt, &category.UpdatedBy,
-		)
+		category, err := scanTransactionCategory(rows)
 		if err != nil {
 			return nil, err
 		}
-		categories = append(categories, category)
+		categories = append(categories, *category)
 	}
-	return categories, nil
+	return categories, rows.Err()
 }
 
-func (t *transactionCategoryPgRepository) Update(category *domain.TransactionCategory) (*domain.TransactionCategory, error) {
-	err := t.db.QueryRow(`
+func (t *transactionCategoryPgRepository) FindSubtreeIDs(id int) ([]int, error) {
+	return findTransactionCategorySubtree(t.db, id)
+}
+
+func (t *transactionCategoryPgRepository) FindSubtreeIDsTx(tx *sql.Tx, id int) ([]int, error) {
+	return findTransactionCategorySubtree(tx, id)
+}
+
+func findTransactionCategorySubtree(q queryer, id int) ([]int, error) {
+	rows, err := q.Query(`SELECT transaction_category_subtree($1)`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var subtreeID int
+		if err := rows.Scan(&subtreeID); err != nil {
+			return nil, err
+		}
+		ids = append(ids, subtreeID)
+	}
+	return ids, rows.Err()
+}
+
+// LockByUserIDTx locks every category of the user until tx ends, so moves within the tree are
+// checked and written one at a time.
+func (t *transactionCategoryPgRepository) LockByUserIDTx(tx *sql.Tx, userID uuid.UUID) error {
+	rows, err := tx.Query(`SELECT id FROM transaction_categories WHERE user_id = $1 ORDER BY id FOR UPDATE`, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func (t *transactionCategoryPgRepository) UpdateTx(tx *sql.Tx, category *domain.TransactionCategory) (*domain.TransactionCategory, error) {
+	_, err := tx.Exec(`
 		UPDATE transaction_categories
-		SET name = $1, updated_by = $2, updated_at = $3
-		WHERE id = $4 Returning type, name, updated_by, updated_at
-	`,  category.Name, category.UpdatedBy, time.Now(), category.ID,
-	).Scan(
-		&category.Name, &category.UpdatedBy, &category.UpdatedAt,
+		SET name = $1, parent_id = $2, kind = $3, updated_by = $4, updated_at = now()
+		WHERE id = $5`,
+		category.Name, category.ParentID, category.Kind, category.UpdatedBy, category.ID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTransactionCategory(tx.QueryRow(`SELECT `+transactionCategoryColumns+` FROM transaction_categories WHERE id = $1`, category.ID))
+}
+
+func scanTransactionCategory(row rowScanner) (*domain.TransactionCategory, error) {
+	category := &domain.TransactionCategory{}
+	err := row.Scan(
+		&category.ID, &category.Name, &category.UserID, &category.ParentID, &category.Kind, &category.Path, &category.ArchivedAt,
+		&category.CreatedAt, &category.CreatedBy, &category.UpdatedAt, &category.UpdatedBy,
 	)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
 		return nil, err
 	}
 	return category, nil
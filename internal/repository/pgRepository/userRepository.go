@@ -33,11 +33,11 @@ func (u *userPgRepository) UpdateBalance(user *domain.User) (*domain.User, error
 
 func (u *userPgRepository) FindByUsernameOrEmail(username string) (*domain.User, error) {
 	row := u.db.QueryRow(`
-		SELECT id, username, email, password, balance, created_at, 
+		SELECT id, username, email, password, balance, base_currency, created_at, 
 		created_by, updated_at, updated_by FROM users WHERE username = $1 OR email = $1
 	`, username)
 	user := &domain.User{}
-	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Balance, &user.CreatedAt,
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Balance, &user.BaseCurrency, &user.CreatedAt,
 		&user.CreatedBy, &user.UpdatedAt, &user.UpdatedBy)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -51,11 +51,11 @@ func (u *userPgRepository) FindByUsernameOrEmail(username string) (*domain.User,
 
 func (u *userPgRepository) FindByEmail(email string) (*domain.User, error) {
 	row := u.db.QueryRow(`
-		SELECT id, username, email, password, balance, created_at, 
+		SELECT id, username, email, password, balance, base_currency, created_at, 
 		created_by, updated_at, updated_by FROM users WHERE email = $1
 	`, email)
 	user := &domain.User{}
-	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Balance,
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Balance, &user.BaseCurrency,
 		&user.CreatedAt, &user.CreatedBy, &user.UpdatedAt, &user.UpdatedBy)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -76,8 +76,16 @@ func (u *userPgRepository) UpdatePassword(user *domain.User) error {
 }
 
 func (u *userPgRepository) Create(user *domain.User) (*domain.User, error) {
-	err := u.db.QueryRow(`INSERT INTO users (id, username, password, email, created_by) VALUES ($1, $2, $3, $4, $5) Returning id, created_at, created_by`,
-		uuid.New(), user.Username, user.Password, user.Email, "SYSTEM").Scan(&user.ID, &user.CreatedAt, &user.CreatedBy)
+	return createUser(u.db, user)
+}
+
+func (u *userPgRepository) CreateTx(tx *sql.Tx, user *domain.User) (*domain.User, error) {
+	return createUser(tx, user)
+}
+
+func createUser(q rowQueryer, user *domain.User) (*domain.User, error) {
+	err := q.QueryRow(`INSERT INTO users (id, username, password, email, base_currency, created_by) VALUES ($1, $2, $3, $4, $5, $6) Returning id, base_currency, created_at, created_by`,
+		uuid.New(), user.Username, user.Password, user.Email, user.BaseCurrency, "SYSTEM").Scan(&user.ID, &user.BaseCurrency, &user.CreatedAt, &user.CreatedBy)
 	if err != nil {
 		return nil, err
 	}
@@ -85,9 +93,22 @@ func (u *userPgRepository) Create(user *domain.User) (*domain.User, error) {
 }
 
 func (u *userPgRepository) FindById(id string) (*domain.User, error) {
-	row := u.db.QueryRow(`SELECT id, username, email, password, balance, created_at, created_by, updated_at, updated_by FROM users WHERE id = $1`, id)
+	row := u.db.QueryRow(`SELECT id, username, email, password, balance, base_currency, created_at, created_by, updated_at, updated_by FROM users WHERE id = $1`, id)
+	user := &domain.User{}
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Balance, &user.BaseCurrency, &user.CreatedAt, &user.CreatedBy, &user.UpdatedAt, &user.UpdatedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *userPgRepository) FindByIdTx(tx *sql.Tx, id string) (*domain.User, error) {
+	row := tx.QueryRow(`SELECT id, username, email, password, balance, base_currency, created_at, created_by, updated_at, updated_by FROM users WHERE id = $1 FOR UPDATE`, id)
 	user := &domain.User{}
-	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Balance, &user.CreatedAt, &user.CreatedBy, &user.UpdatedAt, &user.UpdatedBy)
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Balance, &user.BaseCurrency, &user.CreatedAt, &user.CreatedBy, &user.UpdatedAt, &user.UpdatedBy)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -120,6 +141,15 @@ func (u *userPgRepository) Update(user *domain.User) (*domain.User, error) {
 	return user, nil
 }
 
+func (u *userPgRepository) UpdateBaseCurrencyTx(tx *sql.Tx, user *domain.User) (*domain.User, error) {
+	err := tx.QueryRow(`UPDATE users SET base_currency = $1, updated_by = $2, updated_at = $3 WHERE id = $4 Returning base_currency, updated_at, updated_by`,
+		user.BaseCurrency, user.ID, time.Now(), user.ID).Scan(&user.BaseCurrency, &user.UpdatedAt, &user.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 func NewUserPgRepository(db *sql.DB) domain.UserRepository {
 	return &userPgRepository{db: db}
 }
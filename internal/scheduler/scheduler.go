@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	pgrepository "github.com/dimas-pramantya/money-management/internal/repository/pgRepository"
+	"github.com/dimas-pramantya/money-management/internal/service"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultRecurringInterval  = time.Hour
+	defaultTrashRetention     = 30 * 24 * time.Hour
+	defaultTrashPurgeInterval = 24 * time.Hour
+)
+
+// Initiator starts the background jobs of the server. Recurring transactions are posted once at
+// startup and then every RECURRING_INTERVAL (a Go duration, default 1h). Deleted transactions and
+// transfers are purged once they have been in the trash for TRASH_RETENTION (default 720h),
+// checked every TRASH_PURGE_INTERVAL (default 24h).
+func Initiator(db *sql.DB) {
+	transactionCategoryRepo := pgrepository.NewTransactionCategoryPgRepository(db)
+	transactionRepo := pgrepository.NewTransactionRepo(db)
+	userRepo := pgrepository.NewUserPgRepository(db)
+	accountRepo := pgrepository.NewAccountPgRepository(db)
+	transferRepo := pgrepository.NewTransferPgRepository(db)
+	ledgerRepo := pgrepository.NewLedgerPgRepository(db)
+	tagRepo := pgrepository.NewTagPgRepository(db)
+	recurringTransactionRepo := pgrepository.NewRecurringTransactionPgRepository(db)
+
+	transferUC := service.NewTransferService(transferRepo, transactionRepo, transactionCategoryRepo, userRepo, accountRepo, ledgerRepo, db)
+	transactionUC := service.NewTransactionService(transactionRepo, transactionCategoryRepo, userRepo, accountRepo, tagRepo, transferUC, ledgerRepo, db)
+	recurringTransactionUC := service.NewRecurringTransactionService(recurringTransactionRepo, transactionRepo, transactionCategoryRepo, accountRepo, transactionUC)
+
+	interval := viper.GetDuration("RECURRING_INTERVAL")
+	if interval <= 0 {
+		interval = defaultRecurringInterval
+	}
+
+	go every(interval, func() {
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		created, err := recurringTransactionUC.RunDue(today)
+		if err != nil {
+			fmt.Println("Failed to run recurring transactions:", err)
+			return
+		}
+		if created > 0 {
+			fmt.Printf("Created %d recurring transactions\n", created)
+		}
+	})
+
+	retention := viper.GetDuration("TRASH_RETENTION")
+	if retention <= 0 {
+		retention = defaultTrashRetention
+	}
+	purgeInterval := viper.GetDuration("TRASH_PURGE_INTERVAL")
+	if purgeInterval <= 0 {
+		purgeInterval = defaultTrashPurgeInterval
+	}
+
+	go every(purgeInterval, func() {
+		purged, err := transactionUC.PurgeDeleted(time.Now().Add(-retention))
+		if err != nil {
+			fmt.Println("Failed to purge deleted transactions:", err)
+			return
+		}
+		if purged > 0 {
+			fmt.Printf("Purged %d deleted transactions\n", purged)
+		}
+
+		purged, err = transferUC.PurgeDeleted(time.Now().Add(-retention))
+		if err != nil {
+			fmt.Println("Failed to purge deleted transfers:", err)
+			return
+		}
+		if purged > 0 {
+			fmt.Printf("Purged %d deleted transfers\n", purged)
+		}
+	})
+}
+
+func every(interval time.Duration, job func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		job()
+		<-ticker.C
+	}
+}
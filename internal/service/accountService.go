@@ -0,0 +1,250 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/internal/money"
+	"github.com/dimas-pramantya/money-management/utils/helper"
+	"github.com/google/uuid"
+)
+
+type AccountService struct {
+	accountRepo domain.AccountRepository
+	ledgerRepo  domain.LedgerRepository
+	balance     balanceUpdater
+	db          *sql.DB
+}
+
+func (a *AccountService) Create(req dto.CreateAccountDto, userID uuid.UUID) (*dto.AccountDto, error) {
+	currency := req.Currency
+	if currency == "" {
+		user, err := a.balance.userRepo.FindById(userID.String())
+		if err != nil {
+			return nil, domain.InternalServerError("Failed to find user", err)
+		}
+		if user == nil {
+			return nil, domain.NotFoundError(fmt.Sprintf("User with id %s not found", userID), nil)
+		}
+		currency = user.BaseCurrency
+	}
+	if !isCurrencyCode(currency) {
+		return nil, domain.BadRequestError("currency must be a 3-letter upper-case code such as IDR", nil)
+	}
+
+	account := &domain.Account{
+		UserID:      userID,
+		Name:        req.Name,
+		AccountType: req.AccountType,
+		Currency:    currency,
+		CreatedBy:   userID.String(),
+	}
+
+	tx, err := a.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	createdAccount, err := a.accountRepo.Create(tx, account)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to create account", err)
+	}
+
+	if req.InitialBalance.Amount != 0 {
+		err = a.balance.post(tx, userID, journal{
+			entryType: domain.LedgerEntryOpening,
+			postings:  equityPostings(createdAccount.ID, domain.LedgerAccountOpeningBalances, req.InitialBalance.Amount),
+		})
+		if err != nil {
+			return nil, err
+		}
+		createdAccount.Balance = req.InitialBalance.Amount
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+
+	return mapAccountToDto(createdAccount), nil
+}
+
+func (a *AccountService) Delete(id int, userID uuid.UUID) error {
+	account, err := a.findOwned(id, userID)
+	if err != nil {
+		return err
+	}
+
+	count, err := a.accountRepo.CountTransactions(id)
+	if err != nil {
+		return domain.InternalServerError("Failed to count account transactions", err)
+	}
+	if count > 0 {
+		return domain.BadRequestError(fmt.Sprintf("Account with id %d still has %d transactions", id, count), nil)
+	}
+	if account.Balance != 0 {
+		return domain.BadRequestError(fmt.Sprintf("Account with id %d still has a non-zero balance", id), nil)
+	}
+
+	err = a.accountRepo.Delete(id)
+	if err != nil {
+		return domain.InternalServerError("Failed to delete account", err)
+	}
+	return nil
+}
+
+func (a *AccountService) FindByID(id int, userID uuid.UUID) (*dto.AccountDto, error) {
+	account, err := a.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return mapAccountToDto(account), nil
+}
+
+func (a *AccountService) FindByUserID(userID uuid.UUID) ([]dto.AccountDto, error) {
+	accounts, err := a.accountRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find accounts", err)
+	}
+	result := make([]dto.AccountDto, len(accounts))
+	for i, account := range accounts {
+		result[i] = *mapAccountToDto(&account)
+	}
+	return result, nil
+}
+
+func (a *AccountService) Update(req dto.UpdateAccountDto, id int, userID uuid.UUID) (*dto.AccountDto, error) {
+	account, err := a.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Currency != "" && req.Currency != account.Currency {
+		if !isCurrencyCode(req.Currency) {
+			return nil, domain.BadRequestError("currency must be a 3-letter upper-case code such as IDR", nil)
+		}
+		count, err := a.accountRepo.CountTransactions(id)
+		if err != nil {
+			return nil, domain.InternalServerError("Failed to count account transactions", err)
+		}
+		if count > 0 || account.Balance != 0 {
+			return nil, domain.BadRequestError("The currency of an account with transactions or a balance cannot change", nil)
+		}
+		account.Currency = req.Currency
+	}
+
+	updatedBy := userID.String()
+	account.Name = req.Name
+	account.AccountType = req.AccountType
+	account.UpdatedBy = &updatedBy
+
+	updatedAccount, err := a.accountRepo.Update(account)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to update account", err)
+	}
+	return mapAccountToDto(updatedAccount), nil
+}
+
+// FindLedgerEntries lists the ledger postings that make up an account balance, newest first.
+func (a *AccountService) FindLedgerEntries(id int, userID uuid.UUID, params dto.GetLedgerParams) (dto.PaginationResponse[dto.LedgerEntryDto], error) {
+	account, err := a.findOwned(id, userID)
+	if err != nil {
+		return dto.PaginationResponse[dto.LedgerEntryDto]{}, err
+	}
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+
+	total, err := a.ledgerRepo.CountByAccountID(id)
+	if err != nil {
+		return dto.PaginationResponse[dto.LedgerEntryDto]{}, domain.InternalServerError("Failed to count ledger entries", err)
+	}
+	entries, err := a.ledgerRepo.FindByAccountID(id, params.Limit, (params.Page-1)*params.Limit)
+	if err != nil {
+		return dto.PaginationResponse[dto.LedgerEntryDto]{}, domain.InternalServerError("Failed to fetch ledger entries", err)
+	}
+
+	records := make([]dto.LedgerEntryDto, len(entries))
+	for i, entry := range entries {
+		records[i] = dto.LedgerEntryDto{
+			ID:            entry.ID,
+			JournalID:     entry.JournalID.String(),
+			AccountID:     entry.AccountID,
+			LedgerAccount: entry.LedgerAccount,
+			EntryType:     entry.EntryType,
+			Amount:        money.Money{Amount: entry.Amount, Currency: account.Currency},
+			TransactionID: entry.TransactionID,
+			TransferID:    entry.TransferID,
+			Description:   entry.Description,
+			CreatedAt:     *helper.TimeToString(&entry.CreatedAt),
+			CreatedBy:     entry.CreatedBy,
+		}
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(params.Limit)))
+	var nextPage *int
+	if params.Page < totalPages {
+		next := params.Page + 1
+		nextPage = &next
+	}
+	var prevPage *int
+	if params.Page > 1 {
+		prev := params.Page - 1
+		prevPage = &prev
+	}
+
+	return dto.PaginationResponse[dto.LedgerEntryDto]{
+		TotalRecords: total,
+		TotalPages:   totalPages,
+		CurrentPage:  params.Page,
+		Limit:        params.Limit,
+		Records:      records,
+		NextPage:     nextPage,
+		PreviousPage: prevPage,
+	}, nil
+}
+
+func (a *AccountService) findOwned(id int, userID uuid.UUID) (*domain.Account, error) {
+	account, err := a.accountRepo.FindByID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find account", err)
+	}
+	if account == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Account with id %d not found", id), nil)
+	}
+	if account.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+	return account, nil
+}
+
+func NewAccountService(accountRepo domain.AccountRepository, userRepo domain.UserRepository, ledgerRepo domain.LedgerRepository, db *sql.DB) domain.AccountUseCase {
+	return &AccountService{
+		accountRepo: accountRepo,
+		ledgerRepo:  ledgerRepo,
+		balance:     balanceUpdater{userRepo: userRepo, accountRepo: accountRepo, ledgerRepo: ledgerRepo},
+		db:          db,
+	}
+}
+
+func mapAccountToDto(account *domain.Account) *dto.AccountDto {
+	return &dto.AccountDto{
+		ID:          account.ID,
+		UserID:      account.UserID.String(),
+		Name:        account.Name,
+		AccountType: account.AccountType,
+		Currency:    account.Currency,
+		Balance:     money.Money{Amount: account.Balance, Currency: account.Currency},
+		CreatedAt:   *helper.TimeToString(&account.CreatedAt),
+		UpdatedAt:   helper.TimeToString(account.UpdatedAt),
+		CreatedBy:   account.CreatedBy,
+		UpdatedBy:   account.UpdatedBy,
+	}
+}
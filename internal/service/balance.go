@@ -0,0 +1,232 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/google/uuid"
+)
+
+// posting is one side of a journal. accountID is set when the posting moves an account balance.
+type posting struct {
+	accountID     *int
+	ledgerAccount string
+	amount        int64
+	transactionID *int
+}
+
+// journal is a set of postings written together; its amounts must sum to zero.
+type journal struct {
+	entryType   string
+	transferID  *int
+	description *string
+	postings    []posting
+}
+
+// balanceUpdater is the only writer of account and user balances. It appends a journal to the
+// ledger, then re-derives each touched account balance from the ledger and keeps users.balance
+// equal to the sum of the user's accounts held in their base currency. Accounts in other
+// currencies cannot be added without a rate; the profile converts them when it is read. Callers
+// own tx; rows are locked until it ends.
+type balanceUpdater struct {
+	userRepo    domain.UserRepository
+	accountRepo domain.AccountRepository
+	ledgerRepo  domain.LedgerRepository
+}
+
+func (b balanceUpdater) post(tx *sql.Tx, userID uuid.UUID, j journal) error {
+	var total int64
+	for _, p := range j.postings {
+		total += p.amount
+	}
+	if total != 0 {
+		return domain.InternalServerError(fmt.Sprintf("Unbalanced %s journal: postings sum to %d", j.entryType, total), nil)
+	}
+	if isZeroJournal(j) {
+		return nil
+	}
+
+	user, err := b.userRepo.FindByIdTx(tx, userID.String())
+	if err != nil {
+		return domain.InternalServerError("Failed to find user", err)
+	}
+	if user == nil {
+		return domain.NotFoundError(fmt.Sprintf("User with id %s not found", userID), nil)
+	}
+
+	journalID := uuid.New()
+	touched := map[int]bool{}
+	for _, p := range j.postings {
+		if p.accountID != nil && !touched[*p.accountID] {
+			account, err := b.lockOwnedAccount(tx, userID, *p.accountID)
+			if err != nil {
+				return err
+			}
+			touched[account.ID] = true
+		}
+
+		entry := &domain.LedgerEntry{
+			JournalID:     journalID,
+			UserID:        userID,
+			AccountID:     p.accountID,
+			LedgerAccount: p.ledgerAccount,
+			EntryType:     j.entryType,
+			Amount:        p.amount,
+			TransactionID: p.transactionID,
+			TransferID:    j.transferID,
+			Description:   j.description,
+			CreatedBy:     userID.String(),
+		}
+		if _, err := b.ledgerRepo.Create(tx, entry); err != nil {
+			return domain.InternalServerError("Failed to write ledger entry", err)
+		}
+	}
+
+	for accountID := range touched {
+		account, err := b.accountRepo.FindByIDTx(tx, accountID)
+		if err != nil {
+			return domain.InternalServerError("Failed to find account", err)
+		}
+		balance, err := b.ledgerRepo.SumByAccountIDTx(tx, accountID)
+		if err != nil {
+			return domain.InternalServerError("Failed to sum ledger entries", err)
+		}
+
+		if account.Currency == user.BaseCurrency {
+			user.Balance += balance - account.Balance
+		}
+		account.Balance = balance
+		if _, err := b.accountRepo.UpdateBalanceTx(tx, account); err != nil {
+			return domain.InternalServerError("Failed to update account balance", err)
+		}
+	}
+
+	if _, err := b.userRepo.UpdateBalanceTx(tx, user); err != nil {
+		return domain.InternalServerError("Failed to update user balance", err)
+	}
+	return nil
+}
+
+// resync rewrites every stored balance of a user from the ledger: each account gets the sum of its
+// entries and users.balance the sum of the accounts held in the base currency.
+func (b balanceUpdater) resync(tx *sql.Tx, userID uuid.UUID) error {
+	user, err := b.userRepo.FindByIdTx(tx, userID.String())
+	if err != nil {
+		return domain.InternalServerError("Failed to find user", err)
+	}
+	if user == nil {
+		return domain.NotFoundError(fmt.Sprintf("User with id %s not found", userID), nil)
+	}
+
+	accounts, err := b.accountRepo.FindByUserIDTx(tx, userID)
+	if err != nil {
+		return domain.InternalServerError("Failed to find accounts", err)
+	}
+
+	user.Balance = 0
+	for i := range accounts {
+		account := &accounts[i]
+		balance, err := b.ledgerRepo.SumByAccountIDTx(tx, account.ID)
+		if err != nil {
+			return domain.InternalServerError("Failed to sum ledger entries", err)
+		}
+		if balance != account.Balance {
+			account.Balance = balance
+			if _, err := b.accountRepo.UpdateBalanceTx(tx, account); err != nil {
+				return domain.InternalServerError("Failed to update account balance", err)
+			}
+		}
+		if account.Currency == user.BaseCurrency {
+			user.Balance += balance
+		}
+	}
+
+	if _, err := b.userRepo.UpdateBalanceTx(tx, user); err != nil {
+		return domain.InternalServerError("Failed to update user balance", err)
+	}
+	return nil
+}
+
+func isZeroJournal(j journal) bool {
+	for _, p := range j.postings {
+		if p.amount != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b balanceUpdater) lockOwnedAccount(tx *sql.Tx, userID uuid.UUID, accountID int) (*domain.Account, error) {
+	account, err := b.accountRepo.FindByIDTx(tx, accountID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find account", err)
+	}
+	if account == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Account with id %d not found", accountID), nil)
+	}
+	if account.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+	return account, nil
+}
+
+// transactionPostings returns the postings a transaction contributes to the ledger, negated when
+// sign is -1. Transfer legs only post to their account; the transfer journal pairs them.
+func transactionPostings(transaction *domain.Transaction, sign int64) []posting {
+	amount := transaction.SignedAmount() * sign
+	accountID := transaction.AccountID
+	transactionID := transaction.ID
+	postings := []posting{{
+		accountID:     &accountID,
+		ledgerAccount: domain.AssetLedgerAccount(accountID),
+		amount:        amount,
+		transactionID: &transactionID,
+	}}
+	if transaction.TransactionType == domain.TransactionTypeTransfer {
+		return postings
+	}
+
+	// A split transaction moves the account once, by the total, and books each split's share on
+	// its own category.
+	if len(transaction.Splits) > 0 {
+		for _, split := range transaction.Splits {
+			share := split.Amount * sign
+			if transaction.TransactionType == domain.TransactionTypeExpense {
+				share = -share
+			}
+			postings = append(postings, posting{
+				ledgerAccount: nominalLedgerAccount(transaction.TransactionType, split.CategoryID),
+				amount:        -share,
+				transactionID: &transactionID,
+			})
+		}
+		return postings
+	}
+
+	categoryID := 0
+	if transaction.CategoryID != nil {
+		categoryID = *transaction.CategoryID
+	}
+	return append(postings, posting{
+		ledgerAccount: nominalLedgerAccount(transaction.TransactionType, categoryID),
+		amount:        -amount,
+		transactionID: &transactionID,
+	})
+}
+
+func nominalLedgerAccount(transactionType string, categoryID int) string {
+	if transactionType == domain.TransactionTypeIncome {
+		return domain.IncomeLedgerAccount(categoryID)
+	}
+	return domain.ExpenseLedgerAccount(categoryID)
+}
+
+// equityPostings moves amount into an account against an equity account, used for opening
+// balances and manual adjustments.
+func equityPostings(accountID int, equityAccount string, amount int64) []posting {
+	return []posting{
+		{accountID: &accountID, ledgerAccount: domain.AssetLedgerAccount(accountID), amount: amount},
+		{ledgerAccount: equityAccount, amount: -amount},
+	}
+}
@@ -0,0 +1,478 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/internal/money"
+	"github.com/dimas-pramantya/money-management/utils/helper"
+	"github.com/google/uuid"
+)
+
+type BudgetService struct {
+	budgetRepo      domain.BudgetRepository
+	trnCategoryRepo domain.TransactionCategoryRepository
+	userRepo        domain.UserRepository
+	reportRepo      domain.ReportRepository
+	db              *sql.DB
+}
+
+func (b *BudgetService) Create(req dto.CreateBudgetDto, userID uuid.UUID) (*dto.BudgetDto, error) {
+	budget := &domain.Budget{
+		UserID:    userID,
+		CreatedBy: userID.String(),
+	}
+	if err := b.apply(budget, dto.UpdateBudgetDto(req), userID); err != nil {
+		return nil, err
+	}
+
+	created, err := b.budgetRepo.Create(budget)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to create budget", err)
+	}
+	return b.progress(created, today())
+}
+
+func (b *BudgetService) Delete(id int, userID uuid.UUID) error {
+	if _, err := b.findOwned(id, userID); err != nil {
+		return err
+	}
+	err := b.budgetRepo.Delete(id)
+	if err != nil {
+		return domain.InternalServerError("Failed to delete budget", err)
+	}
+	return nil
+}
+
+func (b *BudgetService) FindByID(id int, userID uuid.UUID, params dto.GetBudgetParams) (*dto.BudgetDto, error) {
+	date, err := budgetDate(params)
+	if err != nil {
+		return nil, err
+	}
+	budget, err := b.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return b.progress(budget, date)
+}
+
+func (b *BudgetService) FindByUserID(userID uuid.UUID, params dto.GetBudgetParams) ([]dto.BudgetDto, error) {
+	date, err := budgetDate(params)
+	if err != nil {
+		return nil, err
+	}
+	budgets, err := b.budgetRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to fetch budgets", err)
+	}
+
+	result := []dto.BudgetDto{}
+	for i := range budgets {
+		budget, err := b.progress(&budgets[i], date)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *budget)
+	}
+	return result, nil
+}
+
+func (b *BudgetService) Update(req dto.UpdateBudgetDto, id int, userID uuid.UUID) (*dto.BudgetDto, error) {
+	budget, err := b.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.apply(budget, req, userID); err != nil {
+		return nil, err
+	}
+	updatedBy := userID.String()
+	budget.UpdatedBy = &updatedBy
+
+	updated, err := b.budgetRepo.Update(budget)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to update budget", err)
+	}
+	return b.progress(updated, today())
+}
+
+// FindEnvelopes reports every budget as an envelope for the period containing params.Date, along
+// with the unassigned pool: income so far, minus what has been allocated to envelope periods, plus
+// what ended periods released back instead of rolling over, minus what current periods have
+// overspent. Spending itself is never refused, since transactions record what already happened;
+// overspending an envelope draws on the pool right away instead.
+func (b *BudgetService) FindEnvelopes(userID uuid.UUID, params dto.GetBudgetParams) (*dto.EnvelopeSummaryDto, error) {
+	date, err := budgetDate(params)
+	if err != nil {
+		return nil, err
+	}
+	return b.envelopes(userID, date)
+}
+
+// MoveAllocation moves money between two envelopes, or between an envelope and the unassigned
+// pool, for the period containing req.Date. Only money that is actually there can be moved out.
+func (b *BudgetService) MoveAllocation(req dto.MoveAllocationDto, userID uuid.UUID) (*dto.EnvelopeSummaryDto, error) {
+	amount := req.Amount.Amount
+	if !req.Amount.IsPositive() {
+		return nil, domain.BadRequestError("Amount must be greater than zero", nil)
+	}
+	if req.FromBudgetID == nil && req.ToBudgetID == nil {
+		return nil, domain.BadRequestError("At least one of from_budget_id and to_budget_id is required", nil)
+	}
+	if req.FromBudgetID != nil && req.ToBudgetID != nil && *req.FromBudgetID == *req.ToBudgetID {
+		return nil, domain.BadRequestError("Cannot move an allocation to the same budget", nil)
+	}
+	date, err := helper.StringToDate(req.Date)
+	if err != nil {
+		return nil, domain.BadRequestError("Invalid date format", err)
+	}
+
+	tx, err := b.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Moves of the same user are serialized on the user row, so two of them cannot both spend the
+	// same money; the checks below read what earlier moves committed.
+	user, err := b.userRepo.FindByIdTx(tx, userID.String())
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find user", err)
+	}
+	if user == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("User with id %s not found", userID), nil)
+	}
+
+	if req.FromBudgetID == nil {
+		summary, err := b.envelopes(userID, *date)
+		if err != nil {
+			return nil, err
+		}
+		if summary.Unassigned.Amount < amount {
+			return nil, domain.BadRequestError(fmt.Sprintf("Only %d is unassigned", summary.Unassigned.Amount), nil)
+		}
+	} else {
+		from, err := b.findOwned(*req.FromBudgetID, userID)
+		if err != nil {
+			return nil, err
+		}
+		period, err := b.activePeriod(from, *date)
+		if err != nil {
+			return nil, err
+		}
+		if period.Remaining < amount {
+			return nil, domain.BadRequestError(fmt.Sprintf("Only %d is left in budget %d", period.Remaining, from.ID), nil)
+		}
+		if err := b.allocate(tx, from, period.Start, -amount, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.ToBudgetID != nil {
+		to, err := b.findOwned(*req.ToBudgetID, userID)
+		if err != nil {
+			return nil, err
+		}
+		period, err := b.activePeriod(to, *date)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.allocate(tx, to, period.Start, amount, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+	return b.envelopes(userID, *date)
+}
+
+func (b *BudgetService) allocate(tx *sql.Tx, budget *domain.Budget, periodStart time.Time, amount int64, userID uuid.UUID) error {
+	_, err := b.budgetRepo.CreateAllocation(tx, &domain.BudgetAllocation{
+		UserID:      userID,
+		BudgetID:    budget.ID,
+		PeriodStart: periodStart,
+		Amount:      amount,
+		CreatedBy:   userID.String(),
+	})
+	if err != nil {
+		return domain.InternalServerError("Failed to create budget allocation", err)
+	}
+	return nil
+}
+
+func (b *BudgetService) envelopes(userID uuid.UUID, date time.Time) (*dto.EnvelopeSummaryDto, error) {
+	budgets, err := b.budgetRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to fetch budgets", err)
+	}
+	if err := b.checkExchangeRates(userID, time.Time{}, date); err != nil {
+		return nil, err
+	}
+	income, err := b.budgetRepo.SumIncome(userID, date)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to sum income", err)
+	}
+
+	summary := &dto.EnvelopeSummaryDto{
+		Date:      *helper.DateToString(&date),
+		Income:    money.Money{Amount: income},
+		Envelopes: []dto.BudgetDto{},
+	}
+	var assigned, released, overspent int64
+	for i := range budgets {
+		budget := &budgets[i]
+		periods, err := b.timeline(budget, date)
+		if err != nil {
+			return nil, err
+		}
+		for _, period := range periods {
+			assigned += period.Budgeted
+			if period.End.Before(date) {
+				released += period.Released
+			} else if period.Remaining < 0 {
+				overspent -= period.Remaining
+			}
+		}
+
+		envelope, err := b.mapProgress(budget, periodAt(budget, periods, date))
+		if err != nil {
+			return nil, err
+		}
+		summary.Envelopes = append(summary.Envelopes, *envelope)
+	}
+	summary.Assigned = money.Money{Amount: assigned}
+	summary.Released = money.Money{Amount: released}
+	summary.Overspent = money.Money{Amount: overspent}
+	summary.Unassigned = money.Money{Amount: income - assigned + released - overspent}
+	return summary, nil
+}
+
+// progress reports the budget for the period containing date, including what rolled over into it.
+// Spending is only counted from the budget's start date and up to its end date, so a budget
+// created mid-month is not charged for the days before it existed.
+func (b *BudgetService) progress(budget *domain.Budget, date time.Time) (*dto.BudgetDto, error) {
+	periods, err := b.timeline(budget, date)
+	if err != nil {
+		return nil, err
+	}
+	return b.mapProgress(budget, periodAt(budget, periods, date))
+}
+
+// activePeriod returns the period containing date, rejecting dates outside the budget's range.
+func (b *BudgetService) activePeriod(budget *domain.Budget, date time.Time) (*domain.BudgetPeriod, error) {
+	periods, err := b.timeline(budget, date)
+	if err != nil {
+		return nil, err
+	}
+	periodStart, _ := budget.PeriodBounds(date)
+	if len(periods) == 0 || !periods[len(periods)-1].Start.Equal(periodStart) {
+		return nil, domain.BadRequestError(fmt.Sprintf("Budget %d is not active on %s", budget.ID, *helper.DateToString(&date)), nil)
+	}
+	return &periods[len(periods)-1], nil
+}
+
+func (b *BudgetService) timeline(budget *domain.Budget, date time.Time) ([]domain.BudgetPeriod, error) {
+	_, to := budget.PeriodBounds(date)
+	if budget.EndDate != nil && to.After(*budget.EndDate) {
+		to = *budget.EndDate
+	}
+
+	spent := map[time.Time]int64{}
+	if !budget.StartDate.After(to) {
+		if err := b.checkExchangeRates(budget.UserID, budget.StartDate, to); err != nil {
+			return nil, err
+		}
+		var err error
+		spent, err = b.budgetRepo.SumSpentByPeriod(budget, budget.StartDate, to)
+		if err != nil {
+			return nil, domain.InternalServerError("Failed to sum budget spending", err)
+		}
+	}
+	allocated, err := b.budgetRepo.SumAllocatedByPeriod(budget, to)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to sum budget allocations", err)
+	}
+	return budget.Timeline(date, spent, allocated), nil
+}
+
+// checkExchangeRates refuses budget totals over from..to while some transaction in that range has
+// no rate into the user's base currency, rather than leaving it out of the sums.
+func (b *BudgetService) checkExchangeRates(userID uuid.UUID, from time.Time, to time.Time) error {
+	user, err := b.userRepo.FindById(userID.String())
+	if err != nil {
+		return domain.InternalServerError("Failed to find user", err)
+	}
+	if user == nil {
+		return domain.NotFoundError(fmt.Sprintf("User with id %s not found", userID), nil)
+	}
+	return checkExchangeRates(b.reportRepo, domain.ReportFilter{UserID: userID, StartDate: from, EndDate: to}, user.BaseCurrency)
+}
+
+// periodAt picks the period containing date from a timeline. Outside the budget's range nothing is
+// allocated, spent or carried.
+func periodAt(budget *domain.Budget, periods []domain.BudgetPeriod, date time.Time) domain.BudgetPeriod {
+	periodStart, periodEnd := budget.PeriodBounds(date)
+	if len(periods) > 0 && periods[len(periods)-1].Start.Equal(periodStart) {
+		return periods[len(periods)-1]
+	}
+	return domain.BudgetPeriod{Start: periodStart, End: periodEnd}
+}
+
+func (b *BudgetService) mapProgress(budget *domain.Budget, period domain.BudgetPeriod) (*dto.BudgetDto, error) {
+	category, err := ownedCategory(b.trnCategoryRepo, budget.CategoryID, budget.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := mapBudgetToDto(budget, category)
+	result.PeriodStart = *helper.DateToString(&period.Start)
+	result.PeriodEnd = *helper.DateToString(&period.End)
+	result.Allocated = money.Money{Amount: period.Allocated}
+	result.Budgeted = money.Money{Amount: period.Budgeted}
+	result.RolloverIn = money.Money{Amount: period.RolloverIn}
+	result.Spent = money.Money{Amount: period.Spent}
+	result.Remaining = money.Money{Amount: period.Remaining}
+	if toFund := budget.Amount - period.Budgeted - period.RolloverIn; toFund > 0 {
+		result.ToFund = money.Money{Amount: toFund}
+	}
+	result.Percentage = spentPercentage(period.Spent, period.Budgeted+period.RolloverIn)
+	return result, nil
+}
+
+func spentPercentage(spent int64, available int64) float64 {
+	if available <= 0 {
+		if spent > 0 {
+			return 100
+		}
+		return 0
+	}
+	return math.Round(float64(spent)*10000/float64(available)) / 100
+}
+
+// apply validates req and copies it onto budget.
+func (b *BudgetService) apply(budget *domain.Budget, req dto.UpdateBudgetDto, userID uuid.UUID) error {
+	if !req.Amount.IsPositive() {
+		return domain.BadRequestError("Amount must be greater than zero", nil)
+	}
+	rollover := req.Rollover
+	if rollover == "" {
+		rollover = domain.RolloverNone
+	}
+	var rolloverCap *int64
+	if req.RolloverCap != nil {
+		if req.RolloverCap.Amount < 0 {
+			return domain.BadRequestError("rollover_cap cannot be negative", nil)
+		}
+		rolloverCap = &req.RolloverCap.Amount
+	}
+	if rollover == domain.RolloverCapped && rolloverCap == nil {
+		return domain.BadRequestError("rollover_cap is required for capped rollover", nil)
+	}
+	if rollover != domain.RolloverCapped {
+		rolloverCap = nil
+	}
+	if _, err := ownedCategory(b.trnCategoryRepo, req.CategoryID, userID); err != nil {
+		return err
+	}
+
+	startDate, err := helper.StringToDate(req.StartDate)
+	if err != nil {
+		return domain.BadRequestError("Invalid start date format", err)
+	}
+	var endDate *time.Time
+	if req.EndDate != nil {
+		endDate, err = helper.StringToDate(*req.EndDate)
+		if err != nil {
+			return domain.BadRequestError("Invalid end date format", err)
+		}
+		if endDate.Before(*startDate) {
+			return domain.BadRequestError("End date must not be before start date", nil)
+		}
+	}
+
+	budget.CategoryID = req.CategoryID
+	budget.Amount = req.Amount.Amount
+	budget.Period = req.Period
+	budget.StartDate = *startDate
+	budget.EndDate = endDate
+	budget.Rollover = rollover
+	budget.RolloverCap = rolloverCap
+	return nil
+}
+
+func (b *BudgetService) findOwned(id int, userID uuid.UUID) (*domain.Budget, error) {
+	budget, err := b.budgetRepo.FindByID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find budget", err)
+	}
+	if budget == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Budget with id %d not found", id), nil)
+	}
+	if budget.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+	return budget, nil
+}
+
+// budgetDate returns the date whose period should be reported, today unless params.Date is set.
+func budgetDate(params dto.GetBudgetParams) (time.Time, error) {
+	if params.Date == nil {
+		return today(), nil
+	}
+	date, err := helper.StringToDate(*params.Date)
+	if err != nil {
+		return time.Time{}, domain.BadRequestError("Invalid date format", err)
+	}
+	return *date, nil
+}
+
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func NewBudgetService(
+	budgetRepo domain.BudgetRepository,
+	trnCategoryRepo domain.TransactionCategoryRepository,
+	userRepo domain.UserRepository,
+	reportRepo domain.ReportRepository,
+	db *sql.DB,
+) domain.BudgetUseCase {
+	return &BudgetService{
+		budgetRepo:      budgetRepo,
+		trnCategoryRepo: trnCategoryRepo,
+		userRepo:        userRepo,
+		reportRepo:      reportRepo,
+		db:              db,
+	}
+}
+
+func mapBudgetToDto(budget *domain.Budget, category *domain.TransactionCategory) *dto.BudgetDto {
+	var rolloverCap *money.Money
+	if budget.RolloverCap != nil {
+		rolloverCap = &money.Money{Amount: *budget.RolloverCap}
+	}
+	return &dto.BudgetDto{
+		ID:           budget.ID,
+		CategoryID:   budget.CategoryID,
+		Category:     category.Name,
+		CategoryPath: category.Path,
+		Amount:       money.Money{Amount: budget.Amount},
+		Period:       budget.Period,
+		StartDate:    *helper.DateToString(&budget.StartDate),
+		EndDate:      helper.DateToString(budget.EndDate),
+		Rollover:     budget.Rollover,
+		RolloverCap:  rolloverCap,
+		UserID:       budget.UserID.String(),
+		CreatedAt:    *helper.TimeToString(&budget.CreatedAt),
+		UpdatedAt:    helper.TimeToString(budget.UpdatedAt),
+		CreatedBy:    budget.CreatedBy,
+		UpdatedBy:    budget.UpdatedBy,
+	}
+}
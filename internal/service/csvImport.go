@@ -0,0 +1,268 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/internal/money"
+	"github.com/dimas-pramantya/money-management/utils/helper"
+)
+
+// csvColumns holds the resolved position of each mapped column; -1 means not mapped.
+type csvColumns struct {
+	date, amount, transactionType, note, category int
+}
+
+// parseCSVStatement maps every non-empty record of a CSV statement to a create request for
+// account. Problems with the file itself fail the whole import; problems with a record only
+// mark that row.
+func parseCSVStatement(file io.Reader, profile *domain.ImportProfile, categories []domain.TransactionCategory, account *domain.Account) ([]importRow, error) {
+	reader := csv.NewReader(file)
+	reader.Comma = rune(profile.Delimiter[0])
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var header []string
+	if profile.HasHeader {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil, domain.BadRequestError("The file is empty", nil)
+		}
+		if err != nil {
+			return nil, domain.BadRequestError("Invalid CSV file", err.Error())
+		}
+		header = record
+	}
+	columns, err := resolveCSVColumns(profile, header)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIDs := importCategoryIDs(categories)
+	layout := dateLayout(profile.DateFormat)
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, domain.BadRequestError("Invalid CSV file", err.Error())
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		req, err := mapCSVRecord(record, columns, profile, layout, categoryIDs, account)
+		rows = append(rows, importRow{line: line, req: req, err: err})
+	}
+	return rows, nil
+}
+
+func mapCSVRecord(record []string, columns csvColumns, profile *domain.ImportProfile, layout string, categoryIDs map[string]int, account *domain.Account) (dto.CreateTransactionDto, error) {
+	req := dto.CreateTransactionDto{AccountID: account.ID}
+
+	rawDate := csvField(record, columns.date)
+	date, err := time.Parse(layout, rawDate)
+	if err != nil {
+		return req, domain.BadRequestError(fmt.Sprintf("Date %q does not match format %s", rawDate, profile.DateFormat), nil)
+	}
+	req.TransactionDate = *helper.DateToString(&date)
+
+	amount, err := parseStatementAmount(csvField(record, columns.amount), profile.DecimalSeparator, account.Currency)
+	if err != nil {
+		return req, err
+	}
+	if columns.transactionType >= 0 {
+		value := csvField(record, columns.transactionType)
+		switch {
+		case strings.EqualFold(value, *profile.IncomeValue):
+			req.TransactionType = domain.TransactionTypeIncome
+		case strings.EqualFold(value, *profile.ExpenseValue):
+			req.TransactionType = domain.TransactionTypeExpense
+		default:
+			return req, domain.BadRequestError(fmt.Sprintf("Unknown transaction type %q", value), nil)
+		}
+		if amount < 0 {
+			amount = -amount
+		}
+	} else if amount < 0 {
+		req.TransactionType = domain.TransactionTypeExpense
+		amount = -amount
+	} else {
+		req.TransactionType = domain.TransactionTypeIncome
+	}
+	req.Amount = money.Money{Amount: amount}
+
+	if columns.note >= 0 {
+		if note := csvField(record, columns.note); note != "" {
+			req.Note = &note
+		}
+	}
+
+	categoryID, err := importCategory(record, columns, profile, categoryIDs)
+	if err != nil {
+		return req, err
+	}
+	req.CategoryID = categoryID
+	return req, nil
+}
+
+// importCategory matches the category column by path or name, case-insensitively, falling back to
+// the profile's default category for empty or unknown names.
+func importCategory(record []string, columns csvColumns, profile *domain.ImportProfile, categoryIDs map[string]int) (int, error) {
+	name := ""
+	if columns.category >= 0 {
+		name = csvField(record, columns.category)
+	}
+	return matchImportCategory(name, categoryIDs, profile.DefaultCategoryID)
+}
+
+// matchImportCategory looks a category up by path, such as "Food:Groceries", or by name,
+// case-insensitively. An unknown path is matched by its closest known ancestor, "Food" here, and
+// defaultCategoryID is used for empty or unknown names.
+func matchImportCategory(name string, categoryIDs map[string]int, defaultCategoryID *int) (int, error) {
+	for path := name; path != ""; {
+		if id, ok := categoryIDs[strings.ToLower(path)]; ok {
+			return id, nil
+		}
+		separator := strings.LastIndex(path, ":")
+		if separator < 0 {
+			break
+		}
+		path = path[:separator]
+	}
+	if defaultCategoryID != nil {
+		return *defaultCategoryID, nil
+	}
+	if name != "" {
+		return 0, domain.BadRequestError(fmt.Sprintf("Unknown category %q", name), nil)
+	}
+	return 0, domain.BadRequestError("No category for this row and no default category was given", nil)
+}
+
+// importCategoryIDs indexes categories by lower-cased path and name for matchImportCategory. A
+// name shared by several categories goes to the one whose path it is, if any, so a bare name
+// prefers the root category.
+func importCategoryIDs(categories []domain.TransactionCategory) map[string]int {
+	categoryIDs := map[string]int{}
+	for _, category := range categories {
+		categoryIDs[strings.ToLower(category.Path)] = category.ID
+	}
+	for _, category := range categories {
+		if _, ok := categoryIDs[strings.ToLower(category.Name)]; !ok {
+			categoryIDs[strings.ToLower(category.Name)] = category.ID
+		}
+	}
+	return categoryIDs
+}
+
+func resolveCSVColumns(profile *domain.ImportProfile, header []string) (csvColumns, error) {
+	columns := csvColumns{date: -1, amount: -1, transactionType: -1, note: -1, category: -1}
+	var err error
+	if columns.date, err = csvColumnIndex(profile.DateColumn, header); err != nil {
+		return columns, err
+	}
+	if columns.amount, err = csvColumnIndex(profile.AmountColumn, header); err != nil {
+		return columns, err
+	}
+	optional := []struct {
+		reference *string
+		index     *int
+	}{
+		{profile.TypeColumn, &columns.transactionType},
+		{profile.NoteColumn, &columns.note},
+		{profile.CategoryColumn, &columns.category},
+	}
+	for _, column := range optional {
+		if column.reference == nil {
+			continue
+		}
+		if *column.index, err = csvColumnIndex(*column.reference, header); err != nil {
+			return columns, err
+		}
+	}
+	return columns, nil
+}
+
+// csvColumnIndex finds a column by header name, or by 1-based position when there is no header.
+func csvColumnIndex(reference string, header []string) (int, error) {
+	if header != nil {
+		for i, name := range header {
+			if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(reference)) {
+				return i, nil
+			}
+		}
+		return -1, domain.BadRequestError(fmt.Sprintf("Column %q not found in the file header", reference), nil)
+	}
+	position, err := strconv.Atoi(reference)
+	if err != nil || position < 1 {
+		return -1, domain.BadRequestError(fmt.Sprintf("Column %q must be a 1-based position when the file has no header", reference), nil)
+	}
+	return position - 1, nil
+}
+
+func csvField(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// dateLayout turns a pattern such as DD/MM/YYYY into a Go time layout. Patterns without any of
+// the YYYY, YY, MMM, MM or DD tokens are used as Go layouts as they are.
+func dateLayout(format string) string {
+	replacer := strings.NewReplacer("YYYY", "2006", "YY", "06", "MMM", "Jan", "MM", "01", "DD", "02")
+	return replacer.Replace(format)
+}
+
+// parseStatementAmount reads amounts such as "-1.250.000,00", "(45.10)" or "Rp 10,000" into minor
+// units of currency. Grouping characters and currency symbols are ignored; decimals beyond the
+// currency's minor units must be zeros.
+func parseStatementAmount(raw string, decimalSeparator string, currency string) (int64, error) {
+	negative := strings.HasPrefix(raw, "(") && strings.HasSuffix(raw, ")")
+	var whole, fraction strings.Builder
+	inFraction := false
+	for _, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			if inFraction {
+				fraction.WriteRune(r)
+			} else {
+				whole.WriteRune(r)
+			}
+		case string(r) == decimalSeparator && whole.Len() > 0:
+			inFraction = true
+		case r == '-':
+			negative = true
+		}
+	}
+	if whole.Len() == 0 {
+		return 0, domain.BadRequestError(fmt.Sprintf("Amount %q is not a number", raw), nil)
+	}
+
+	parsed, err := money.Parse(whole.String()+"."+fraction.String(), currency)
+	if err != nil {
+		return 0, domain.BadRequestError(fmt.Sprintf("Amount %q is not a valid %s amount", raw, currency), nil)
+	}
+	if negative {
+		return -parsed.Amount, nil
+	}
+	return parsed.Amount, nil
+}
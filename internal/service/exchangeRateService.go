@@ -0,0 +1,269 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/utils/helper"
+	"github.com/google/uuid"
+)
+
+type ExchangeRateService struct {
+	exchangeRateRepo domain.ExchangeRateRepository
+	db               *sql.DB
+}
+
+// exchangeRateCSVHeader is the header a rate file must start with, in any column order.
+var exchangeRateCSVHeader = []string{"effective_date", "from_currency", "to_currency", "rate"}
+
+func (e *ExchangeRateService) Create(req dto.CreateExchangeRateDto, userID uuid.UUID) (*dto.ExchangeRateDto, error) {
+	rate, err := buildExchangeRate(req.FromCurrency, req.ToCurrency, req.Rate, req.EffectiveDate)
+	if err != nil {
+		return nil, err
+	}
+	rate.UserID = userID
+	rate.CreatedBy = userID.String()
+
+	tx, err := e.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	created, err := e.exchangeRateRepo.Upsert(tx, rate)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to save exchange rate", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+	return mapExchangeRateToDto(created), nil
+}
+
+func (e *ExchangeRateService) Delete(id int, userID uuid.UUID) error {
+	if _, err := e.findOwned(id, userID); err != nil {
+		return err
+	}
+	err := e.exchangeRateRepo.Delete(id)
+	if err != nil {
+		return domain.InternalServerError("Failed to delete exchange rate", err)
+	}
+	return nil
+}
+
+func (e *ExchangeRateService) FindByID(id int, userID uuid.UUID) (*dto.ExchangeRateDto, error) {
+	rate, err := e.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return mapExchangeRateToDto(rate), nil
+}
+
+func (e *ExchangeRateService) FindByUserID(params dto.GetExchangeRateParams, userID uuid.UUID) ([]dto.ExchangeRateDto, error) {
+	rates, err := e.exchangeRateRepo.FindByUserID(userID, params.Currency)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to fetch exchange rates", err)
+	}
+	result := []dto.ExchangeRateDto{}
+	for i := range rates {
+		result = append(result, *mapExchangeRateToDto(&rates[i]))
+	}
+	return result, nil
+}
+
+func (e *ExchangeRateService) Update(req dto.UpdateExchangeRateDto, id int, userID uuid.UUID) (*dto.ExchangeRateDto, error) {
+	rate, err := e.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := buildExchangeRate(req.FromCurrency, req.ToCurrency, req.Rate, req.EffectiveDate)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := e.exchangeRateRepo.FindByPair(userID, changes.FromCurrency, changes.ToCurrency, changes.EffectiveDate)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find exchange rate", err)
+	}
+	if existing != nil && existing.ID != id {
+		return nil, domain.BadRequestError(fmt.Sprintf("A %s/%s rate for %s already exists", changes.FromCurrency, changes.ToCurrency, req.EffectiveDate), nil)
+	}
+
+	updatedBy := userID.String()
+	rate.FromCurrency = changes.FromCurrency
+	rate.ToCurrency = changes.ToCurrency
+	rate.Rate = changes.Rate
+	rate.EffectiveDate = changes.EffectiveDate
+	rate.UpdatedBy = &updatedBy
+
+	updated, err := e.exchangeRateRepo.Update(rate)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to update exchange rate", err)
+	}
+	return mapExchangeRateToDto(updated), nil
+}
+
+// ImportCSV records every rate of a CSV file with an effective_date, from_currency, to_currency and
+// rate header, in a single DB transaction. One invalid row keeps the whole file out and every
+// invalid row is reported.
+func (e *ExchangeRateService) ImportCSV(file io.Reader, userID uuid.UUID) (*dto.ExchangeRateImportDto, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, domain.BadRequestError("The file is empty", nil)
+	}
+	if err != nil {
+		return nil, domain.BadRequestError("Invalid CSV file", err.Error())
+	}
+	columns := map[string]int{}
+	for _, name := range exchangeRateCSVHeader {
+		index, err := csvColumnIndex(name, header)
+		if err != nil {
+			return nil, err
+		}
+		columns[name] = index
+	}
+
+	var rates []*domain.ExchangeRate
+	var rowErrors []dto.ExchangeRateImportErrorDto
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, domain.BadRequestError("Invalid CSV file", err.Error())
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		rate, err := buildExchangeRate(
+			strings.ToUpper(csvField(record, columns["from_currency"])),
+			strings.ToUpper(csvField(record, columns["to_currency"])),
+			csvField(record, columns["rate"]),
+			csvField(record, columns["effective_date"]),
+		)
+		if err != nil {
+			rowErrors = append(rowErrors, dto.ExchangeRateImportErrorDto{Line: line, Error: importErrorMessage(err)})
+			continue
+		}
+		rate.UserID = userID
+		rate.CreatedBy = userID.String()
+		rates = append(rates, rate)
+	}
+	if len(rowErrors) > 0 {
+		return nil, domain.BadRequestError(fmt.Sprintf("%d rows are invalid, nothing was imported", len(rowErrors)), rowErrors)
+	}
+	if len(rates) == 0 {
+		return nil, domain.BadRequestError("The file has no exchange rates", nil)
+	}
+
+	tx, err := e.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	for _, rate := range rates {
+		if _, err := e.exchangeRateRepo.Upsert(tx, rate); err != nil {
+			return nil, domain.InternalServerError("Failed to save exchange rate", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+	return &dto.ExchangeRateImportDto{Imported: len(rates)}, nil
+}
+
+// buildExchangeRate validates the fields of a rate. The rate must be a positive decimal; it is
+// kept as written so Postgres stores it exactly.
+func buildExchangeRate(fromCurrency string, toCurrency string, rawRate string, rawDate string) (*domain.ExchangeRate, error) {
+	if !isCurrencyCode(fromCurrency) || !isCurrencyCode(toCurrency) {
+		return nil, domain.BadRequestError("Currencies must be 3-letter upper-case codes such as IDR", nil)
+	}
+	if fromCurrency == toCurrency {
+		return nil, domain.BadRequestError("from_currency and to_currency must differ", nil)
+	}
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(rawRate))
+	if !ok || strings.ContainsAny(rawRate, "/eE") {
+		return nil, domain.BadRequestError(fmt.Sprintf("Rate %q is not a decimal number", rawRate), nil)
+	}
+	if rate.Sign() <= 0 {
+		return nil, domain.BadRequestError("Rate must be greater than zero", nil)
+	}
+	effectiveDate, err := helper.StringToDate(rawDate)
+	if err != nil {
+		return nil, domain.BadRequestError("Invalid effective date format", err)
+	}
+	return &domain.ExchangeRate{
+		FromCurrency:  fromCurrency,
+		ToCurrency:    toCurrency,
+		Rate:          strings.TrimSpace(rawRate),
+		EffectiveDate: *effectiveDate,
+	}, nil
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *ExchangeRateService) findOwned(id int, userID uuid.UUID) (*domain.ExchangeRate, error) {
+	rate, err := e.exchangeRateRepo.FindByID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find exchange rate", err)
+	}
+	if rate == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Exchange rate with id %d not found", id), nil)
+	}
+	if rate.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+	return rate, nil
+}
+
+func NewExchangeRateService(exchangeRateRepo domain.ExchangeRateRepository, db *sql.DB) domain.ExchangeRateUseCase {
+	return &ExchangeRateService{
+		exchangeRateRepo: exchangeRateRepo,
+		db:               db,
+	}
+}
+
+func mapExchangeRateToDto(rate *domain.ExchangeRate) *dto.ExchangeRateDto {
+	return &dto.ExchangeRateDto{
+		ID:            rate.ID,
+		FromCurrency:  rate.FromCurrency,
+		ToCurrency:    rate.ToCurrency,
+		Rate:          trimDecimal(rate.Rate),
+		EffectiveDate: *helper.DateToString(&rate.EffectiveDate),
+		UserID:        rate.UserID.String(),
+		CreatedAt:     *helper.TimeToString(&rate.CreatedAt),
+		UpdatedAt:     helper.TimeToString(rate.UpdatedAt),
+		CreatedBy:     rate.CreatedBy,
+		UpdatedBy:     rate.UpdatedBy,
+	}
+}
+
+// trimDecimal drops the trailing zeros Postgres pads NUMERIC values with, e.g. 15850.250000000000.
+func trimDecimal(value string) string {
+	if !strings.Contains(value, ".") {
+		return value
+	}
+	return strings.TrimSuffix(strings.TrimRight(value, "0"), ".")
+}
@@ -0,0 +1,392 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/internal/money"
+	"github.com/dimas-pramantya/money-management/utils/helper"
+	"github.com/google/uuid"
+)
+
+type ImportService struct {
+	importProfileRepo domain.ImportProfileRepository
+	transactionRepo   domain.TransactionRepository
+	trnCategoryRepo   domain.TransactionCategoryRepository
+	accountRepo       domain.AccountRepository
+	transactionUC     domain.TransactionUseCase
+}
+
+// importRow is one statement entry turned into a create request. err is set when the entry
+// could not even be read, e.g. an unparseable date; duplicate when it was imported before.
+type importRow struct {
+	line      int
+	req       dto.CreateTransactionDto
+	err       error
+	duplicate bool
+}
+
+func (i *ImportService) CreateProfile(req dto.CreateImportProfileDto, userID uuid.UUID) (*dto.ImportProfileDto, error) {
+	profile := &domain.ImportProfile{
+		UserID:    userID,
+		CreatedBy: userID.String(),
+	}
+	if err := i.applyProfile(profile, dto.UpdateImportProfileDto(req), userID); err != nil {
+		return nil, err
+	}
+
+	created, err := i.importProfileRepo.Create(profile)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to create import profile", err)
+	}
+	return mapImportProfileToDto(created), nil
+}
+
+func (i *ImportService) DeleteProfile(id int, userID uuid.UUID) error {
+	if _, err := i.findOwnedProfile(id, userID); err != nil {
+		return err
+	}
+	err := i.importProfileRepo.Delete(id)
+	if err != nil {
+		return domain.InternalServerError("Failed to delete import profile", err)
+	}
+	return nil
+}
+
+func (i *ImportService) FindProfileByID(id int, userID uuid.UUID) (*dto.ImportProfileDto, error) {
+	profile, err := i.findOwnedProfile(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return mapImportProfileToDto(profile), nil
+}
+
+func (i *ImportService) FindProfilesByUserID(userID uuid.UUID) ([]dto.ImportProfileDto, error) {
+	profiles, err := i.importProfileRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to fetch import profiles", err)
+	}
+	result := []dto.ImportProfileDto{}
+	for j := range profiles {
+		result = append(result, *mapImportProfileToDto(&profiles[j]))
+	}
+	return result, nil
+}
+
+func (i *ImportService) UpdateProfile(req dto.UpdateImportProfileDto, id int, userID uuid.UUID) (*dto.ImportProfileDto, error) {
+	profile, err := i.findOwnedProfile(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.applyProfile(profile, req, userID); err != nil {
+		return nil, err
+	}
+	updatedBy := userID.String()
+	profile.UpdatedBy = &updatedBy
+
+	updated, err := i.importProfileRepo.Update(profile)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to update import profile", err)
+	}
+	return mapImportProfileToDto(updated), nil
+}
+
+// ImportCSV reads a CSV statement with the given mapping profile into the chosen account.
+func (i *ImportService) ImportCSV(file io.Reader, req dto.ImportTransactionsDto, userID uuid.UUID) (*dto.ImportReportDto, error) {
+	if req.ProfileID == nil {
+		return nil, domain.BadRequestError("profile_id is required for CSV imports", nil)
+	}
+	profile, err := i.findOwnedProfile(*req.ProfileID, userID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := ownedAccount(i.accountRepo, req.AccountID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := i.trnCategoryRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to fetch transaction categories", err)
+	}
+	rows, err := parseCSVStatement(file, profile, categories, account)
+	if err != nil {
+		return nil, err
+	}
+	return i.previewOrCommit(rows, req, userID)
+}
+
+// ImportOFX reads an OFX statement into the chosen account, skipping entries whose FITID was
+// already imported into it.
+func (i *ImportService) ImportOFX(file io.Reader, req dto.ImportTransactionsDto, userID uuid.UUID) (*dto.ImportReportDto, error) {
+	account, profile, err := i.prepareImport(req, userID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := parseOFXStatement(file, account.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return i.importStatement(entries, profile, req, userID)
+}
+
+// ImportQIF reads a QIF statement into the chosen account. A profile, if given, supplies the
+// date format and the default category.
+func (i *ImportService) ImportQIF(file io.Reader, req dto.ImportTransactionsDto, userID uuid.UUID) (*dto.ImportReportDto, error) {
+	account, profile, err := i.prepareImport(req, userID)
+	if err != nil {
+		return nil, err
+	}
+	dateFormat := ""
+	if profile != nil {
+		dateFormat = profile.DateFormat
+	}
+	entries, err := parseQIFStatement(file, dateFormat, account.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return i.importStatement(entries, profile, req, userID)
+}
+
+// prepareImport checks that the account, the optional profile and the optional fallback category
+// of an OFX or QIF import belong to the user, and returns the account and the profile, if any.
+func (i *ImportService) prepareImport(req dto.ImportTransactionsDto, userID uuid.UUID) (*domain.Account, *domain.ImportProfile, error) {
+	account, err := ownedAccount(i.accountRepo, req.AccountID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if req.CategoryID != nil {
+		if _, err := ownedCategory(i.trnCategoryRepo, *req.CategoryID, userID); err != nil {
+			return nil, nil, err
+		}
+	}
+	if req.ProfileID == nil {
+		return account, nil, nil
+	}
+	profile, err := i.findOwnedProfile(*req.ProfileID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return account, profile, nil
+}
+
+// importStatement turns parsed statement entries into import rows. Entries carrying an external id
+// already seen in the account, or earlier in the same file, are marked as duplicates.
+func (i *ImportService) importStatement(entries []statementEntry, profile *domain.ImportProfile, req dto.ImportTransactionsDto, userID uuid.UUID) (*dto.ImportReportDto, error) {
+	defaultCategoryID := req.CategoryID
+	if defaultCategoryID == nil && profile != nil {
+		defaultCategoryID = profile.DefaultCategoryID
+	}
+	categories, err := i.trnCategoryRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to fetch transaction categories", err)
+	}
+	categoryIDs := importCategoryIDs(categories)
+
+	seen := map[string]bool{}
+	rows := make([]importRow, 0, len(entries))
+	for _, entry := range entries {
+		transaction := entry.transaction
+		row := importRow{
+			line: entry.line,
+			err:  entry.err,
+			req: dto.CreateTransactionDto{
+				Amount:          money.Money{Amount: transaction.Amount},
+				AccountID:       req.AccountID,
+				TransactionType: transaction.TransactionType,
+				Note:            transaction.Notes,
+				ExternalID:      transaction.ExternalID,
+			},
+		}
+		if row.err == nil {
+			row.req.TransactionDate = *helper.DateToString(&transaction.TransactionDate)
+		}
+		if externalID := transaction.ExternalID; externalID != nil {
+			if seen[*externalID] {
+				row.duplicate = true
+			} else {
+				seen[*externalID] = true
+				row.duplicate, err = i.transactionRepo.ExistsByExternalID(req.AccountID, *externalID)
+				if err != nil {
+					return nil, domain.InternalServerError("Failed to check imported transactions", err)
+				}
+			}
+		}
+		if row.err == nil && !row.duplicate {
+			row.req.CategoryID, row.err = matchImportCategory(entry.category, categoryIDs, defaultCategoryID)
+		}
+		rows = append(rows, row)
+	}
+	return i.previewOrCommit(rows, req, userID)
+}
+
+// previewOrCommit validates every row with the rules of TransactionService.Create and reports the
+// outcome per row. Duplicates are reported as skipped and never block a commit. When the request
+// asks to commit, the valid rows are created in a single DB transaction; unless SkipInvalid is
+// set, one invalid row keeps the whole file out.
+func (i *ImportService) previewOrCommit(rows []importRow, req dto.ImportTransactionsDto, userID uuid.UUID) (*dto.ImportReportDto, error) {
+	report := &dto.ImportReportDto{
+		TotalRows: len(rows),
+		Rows:      []dto.ImportRowDto{},
+	}
+	var valid []dto.CreateTransactionDto
+	var validRows []int
+	for _, row := range rows {
+		result := dto.ImportRowDto{
+			Line:            row.line,
+			TransactionDate: row.req.TransactionDate,
+			TransactionType: row.req.TransactionType,
+			Amount:          row.req.Amount,
+			CategoryID:      row.req.CategoryID,
+			Note:            row.req.Note,
+			ExternalID:      row.req.ExternalID,
+		}
+		if row.duplicate {
+			result.Skipped = true
+			report.SkippedRows++
+			report.Rows = append(report.Rows, result)
+			continue
+		}
+		err := row.err
+		if err == nil {
+			err = i.transactionUC.ValidateCreate(row.req, userID)
+		}
+		if err != nil {
+			message := importErrorMessage(err)
+			result.Error = &message
+			report.InvalidRows++
+		} else {
+			valid = append(valid, row.req)
+			validRows = append(validRows, len(report.Rows))
+			report.ValidRows++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	if !req.Commit {
+		return report, nil
+	}
+	if report.InvalidRows > 0 && !req.SkipInvalid {
+		return nil, domain.BadRequestError(fmt.Sprintf("%d rows are invalid, nothing was imported", report.InvalidRows), report)
+	}
+	if len(valid) == 0 {
+		if report.SkippedRows > 0 && report.InvalidRows == 0 {
+			report.Committed = true
+			return report, nil
+		}
+		return nil, domain.BadRequestError("There are no valid rows to import", report)
+	}
+
+	created, err := i.transactionUC.CreateBatch(valid, userID)
+	if err != nil {
+		return nil, err
+	}
+	for j, transaction := range created {
+		id := transaction.ID
+		report.Rows[validRows[j]].TransactionID = &id
+	}
+	report.Committed = true
+	report.Imported = len(created)
+	return report, nil
+}
+
+func importErrorMessage(err error) string {
+	var customErr *domain.CustomError
+	if errors.As(err, &customErr) {
+		return customErr.Message
+	}
+	return err.Error()
+}
+
+// applyProfile validates req and copies it onto profile, filling in defaults.
+func (i *ImportService) applyProfile(profile *domain.ImportProfile, req dto.UpdateImportProfileDto, userID uuid.UUID) error {
+	if req.TypeColumn != nil && (req.IncomeValue == nil || req.ExpenseValue == nil) {
+		return domain.BadRequestError("income_value and expense_value are required with type_column", nil)
+	}
+	if req.DefaultCategoryID != nil {
+		if _, err := ownedCategory(i.trnCategoryRepo, *req.DefaultCategoryID, userID); err != nil {
+			return err
+		}
+	}
+
+	profile.Name = req.Name
+	profile.Delimiter = req.Delimiter
+	if profile.Delimiter == "" {
+		profile.Delimiter = ","
+	}
+	profile.HasHeader = req.HasHeader == nil || *req.HasHeader
+	profile.DateColumn = req.DateColumn
+	profile.DateFormat = req.DateFormat
+	if profile.DateFormat == "" {
+		profile.DateFormat = "YYYY-MM-DD"
+	}
+	profile.AmountColumn = req.AmountColumn
+	profile.DecimalSeparator = req.DecimalSeparator
+	if profile.DecimalSeparator == "" {
+		profile.DecimalSeparator = "."
+	}
+	profile.TypeColumn = req.TypeColumn
+	profile.IncomeValue = req.IncomeValue
+	profile.ExpenseValue = req.ExpenseValue
+	profile.NoteColumn = req.NoteColumn
+	profile.CategoryColumn = req.CategoryColumn
+	profile.DefaultCategoryID = req.DefaultCategoryID
+	return nil
+}
+
+func (i *ImportService) findOwnedProfile(id int, userID uuid.UUID) (*domain.ImportProfile, error) {
+	profile, err := i.importProfileRepo.FindByID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find import profile", err)
+	}
+	if profile == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Import profile with id %d not found", id), nil)
+	}
+	if profile.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+	return profile, nil
+}
+
+func NewImportService(
+	importProfileRepo domain.ImportProfileRepository,
+	transactionRepo domain.TransactionRepository,
+	trnCategoryRepo domain.TransactionCategoryRepository,
+	accountRepo domain.AccountRepository,
+	transactionUC domain.TransactionUseCase,
+) domain.ImportUseCase {
+	return &ImportService{
+		importProfileRepo: importProfileRepo,
+		transactionRepo:   transactionRepo,
+		trnCategoryRepo:   trnCategoryRepo,
+		accountRepo:       accountRepo,
+		transactionUC:     transactionUC,
+	}
+}
+
+func mapImportProfileToDto(profile *domain.ImportProfile) *dto.ImportProfileDto {
+	return &dto.ImportProfileDto{
+		ID:                profile.ID,
+		Name:              profile.Name,
+		Delimiter:         profile.Delimiter,
+		HasHeader:         profile.HasHeader,
+		DateColumn:        profile.DateColumn,
+		DateFormat:        profile.DateFormat,
+		AmountColumn:      profile.AmountColumn,
+		DecimalSeparator:  profile.DecimalSeparator,
+		TypeColumn:        profile.TypeColumn,
+		IncomeValue:       profile.IncomeValue,
+		ExpenseValue:      profile.ExpenseValue,
+		NoteColumn:        profile.NoteColumn,
+		CategoryColumn:    profile.CategoryColumn,
+		DefaultCategoryID: profile.DefaultCategoryID,
+		UserID:            profile.UserID.String(),
+		CreatedAt:         *helper.TimeToString(&profile.CreatedAt),
+		UpdatedAt:         helper.TimeToString(profile.UpdatedAt),
+		CreatedBy:         profile.CreatedBy,
+		UpdatedBy:         profile.UpdatedBy,
+	}
+}
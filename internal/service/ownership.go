@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ownedAccount loads an account, rejecting ids that belong to another user.
+func ownedAccount(accountRepo domain.AccountRepository, id int, userID uuid.UUID) (*domain.Account, error) {
+	account, err := accountRepo.FindByID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find account", err)
+	}
+	if account == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Account with id %d not found", id), nil)
+	}
+	if account.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+	return account, nil
+}
+
+// ownedCategory loads a category, rejecting ids that belong to another user.
+func ownedCategory(trnCategoryRepo domain.TransactionCategoryRepository, id int, userID uuid.UUID) (*domain.TransactionCategory, error) {
+	category, err := trnCategoryRepo.FindByID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction category", err)
+	}
+	if category == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Category with id %d not found", id), nil)
+	}
+	if category.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+	return category, nil
+}
+
+// ownedTags loads the tags with the given ids, ignoring repeats. An id that does not exist or
+// belongs to another user is reported as not found.
+func ownedTags(tagRepo domain.TagRepository, ids []int, userID uuid.UUID) ([]domain.Tag, error) {
+	if len(ids) == 0 {
+		return []domain.Tag{}, nil
+	}
+	tags, err := tagRepo.FindByIDs(userID, ids)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find tags", err)
+	}
+	found := map[int]bool{}
+	for _, tag := range tags {
+		found[tag.ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return nil, domain.NotFoundError(fmt.Sprintf("Tag with id %d not found", id), nil)
+		}
+	}
+	return tags, nil
+}
@@ -0,0 +1,414 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/internal/money"
+	"github.com/google/uuid"
+)
+
+// beancountOpenDate opens every account. Transactions can be backdated before the account or
+// category was created, and beancount rejects postings to accounts that are not open yet.
+const beancountOpenDate = "1970-01-01"
+
+// plainTextWriter renders journal entries in ledger, hledger or beancount syntax. Money accounts
+// become Assets (or Liabilities for credit cards) and categories become Income and Expenses trees
+// following the category tree, e.g. Expenses:Food:Groceries. Postings use the usual
+// plain-text accounting signs: income is credited (negative), expenses are debited (positive),
+// and are written in the currency of their money account.
+type plainTextWriter struct {
+	w      *bufio.Writer
+	format string
+
+	accounts          map[int]string
+	accountCurrencies map[int]string
+	categories        map[int]string
+	categoryKinds     map[int]string
+}
+
+type plainTextPosting struct {
+	account  string
+	amount   int64
+	currency string
+}
+
+// ExportPlainText writes the user's accounts, categories and the transactions matching params to w
+// as a ledger, hledger or beancount journal. Opening balances and adjustments are included unless
+// params filters by category or type, since those entries have neither.
+func (t *TransactionService) ExportPlainText(params dto.GetTransactionParams, format string, w io.Writer) error {
+	if format != domain.ExportFormatLedger && format != domain.ExportFormatHledger && format != domain.ExportFormatBeancount {
+		return domain.BadRequestError("Unsupported plain-text format, expected ledger, hledger or beancount", nil)
+	}
+	userID, err := uuid.Parse(*params.UserId)
+	if err != nil {
+		return domain.BadRequestError("Invalid user ID", err)
+	}
+
+	writer, err := t.newPlainTextWriter(userID, format, w)
+	if err != nil {
+		return err
+	}
+	writer.writeHeader()
+
+	if params.CategoryID == nil && params.TransactionType == nil {
+		entries, err := t.balance.ledgerRepo.FindEquityEntriesByUserID(userID)
+		if err != nil {
+			return domain.InternalServerError("Failed to fetch ledger entries", err)
+		}
+		for _, entry := range entries {
+			if inPlainTextRange(entry, params) {
+				writer.writeEquityEntry(entry)
+			}
+		}
+	}
+
+	// Transfer legs are paired into one entry; a leg whose partner is filtered out is booked
+	// against Equity:Transfers so the journal still balances.
+	pendingLegs := map[int]*dto.TransactionDto{}
+	err = t.transactionRepo.StreamByFilter(params, func(transaction *dto.TransactionDto) error {
+		if transaction.TransactionType != domain.TransactionTypeTransfer || transaction.TransferID == nil {
+			writer.writeTransaction(transaction)
+			return nil
+		}
+		other, ok := pendingLegs[*transaction.TransferID]
+		if !ok {
+			pendingLegs[*transaction.TransferID] = transaction
+			return nil
+		}
+		delete(pendingLegs, *transaction.TransferID)
+		writer.writeTransfer(other, transaction)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	transferIDs := make([]int, 0, len(pendingLegs))
+	for transferID := range pendingLegs {
+		transferIDs = append(transferIDs, transferID)
+	}
+	sort.Ints(transferIDs)
+	for _, transferID := range transferIDs {
+		writer.writeTransfer(pendingLegs[transferID], nil)
+	}
+	return writer.w.Flush()
+}
+
+func (t *TransactionService) newPlainTextWriter(userID uuid.UUID, format string, w io.Writer) (*plainTextWriter, error) {
+	writer := &plainTextWriter{
+		w:                 bufio.NewWriter(w),
+		format:            format,
+		accounts:          map[int]string{},
+		accountCurrencies: map[int]string{},
+		categories:        map[int]string{},
+		categoryKinds:     map[int]string{},
+	}
+
+	accounts, err := t.accountRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to fetch accounts", err)
+	}
+	used := map[string]bool{}
+	for _, account := range accounts {
+		root := "Assets"
+		if account.AccountType == domain.AccountTypeCreditCard {
+			root = "Liabilities"
+		}
+		writer.accounts[account.ID] = uniqueSegment(used, root+":", writer.segment(account.Name), account.ID)
+		writer.accountCurrencies[account.ID] = account.Currency
+	}
+
+	categories, err := t.trnCategoryRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to fetch transaction categories", err)
+	}
+	// Reserved for transactions without a category.
+	used["Uncategorized"] = true
+	// A category's account sits below its parent's, so parents are named first.
+	children := map[int][]domain.TransactionCategory{}
+	var roots []domain.TransactionCategory
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+		} else {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		}
+	}
+	var name func(prefix string, nodes []domain.TransactionCategory)
+	name = func(prefix string, nodes []domain.TransactionCategory) {
+		for _, category := range nodes {
+			writer.categories[category.ID] = uniqueSegment(used, prefix, writer.segment(category.Name), category.ID)
+			writer.categoryKinds[category.ID] = category.Kind
+			name(writer.categories[category.ID]+":", children[category.ID])
+		}
+	}
+	name("", roots)
+	return writer, nil
+}
+
+// uniqueSegment returns prefix+segment, suffixed with id when two names clean up to the same
+// account.
+func uniqueSegment(used map[string]bool, prefix string, segment string, id int) string {
+	name := prefix + segment
+	if used[name] {
+		name = fmt.Sprintf("%s-%d", name, id)
+	}
+	used[name] = true
+	return name
+}
+
+func (p *plainTextWriter) writeHeader() {
+	fmt.Fprintf(p.w, "; Exported on %s\n\n", time.Now().UTC().Format("2006-01-02"))
+	currencies := map[string]bool{}
+	for _, currency := range sortedNames(p.accountCurrencies) {
+		if currencies[currency] {
+			continue
+		}
+		currencies[currency] = true
+		if p.format == domain.ExportFormatBeancount {
+			fmt.Fprintf(p.w, "%s commodity %s\n", beancountOpenDate, currency)
+		} else {
+			fmt.Fprintf(p.w, "commodity %s\n", currency)
+		}
+	}
+	p.w.WriteString("\n")
+
+	for _, name := range sortedNames(p.accounts) {
+		kind := "A"
+		if strings.HasPrefix(name, "Liabilities:") {
+			kind = "L"
+		}
+		p.declare(name, kind)
+	}
+	for _, name := range []string{"Equity:Opening-Balances", "Equity:Adjustments", "Equity:Reconciliation", "Equity:Transfers"} {
+		p.declare(name, "E")
+	}
+
+	// Each category is declared on the side its kind takes transactions for, or on both.
+	income, expenses := map[int]string{}, map[int]string{}
+	for id, name := range p.categories {
+		if p.categoryKinds[id] != domain.CategoryKindExpense {
+			income[id] = name
+		}
+		if p.categoryKinds[id] != domain.CategoryKindIncome {
+			expenses[id] = name
+		}
+	}
+	for _, name := range sortedNames(income) {
+		p.declare("Income:"+name, "R")
+	}
+	p.declare("Income:Uncategorized", "R")
+	for _, name := range sortedNames(expenses) {
+		p.declare("Expenses:"+name, "X")
+	}
+	p.declare("Expenses:Uncategorized", "X")
+	p.w.WriteString("\n")
+}
+
+func sortedNames(names map[int]string) []string {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// declare opens an account. hledger additionally gets its account type: A(ssets), L(iabilities),
+// E(quity), R(evenue) or X (expenses).
+func (p *plainTextWriter) declare(account string, kind string) {
+	switch p.format {
+	case domain.ExportFormatBeancount:
+		fmt.Fprintf(p.w, "%s open %s\n", beancountOpenDate, account)
+	case domain.ExportFormatHledger:
+		fmt.Fprintf(p.w, "account %s  ; type: %s\n", account, kind)
+	default:
+		fmt.Fprintf(p.w, "account %s\n", account)
+	}
+}
+
+func (p *plainTextWriter) writeEquityEntry(entry domain.LedgerEntry) {
+	equity, description := "Equity:Adjustments", "Balance adjustment"
+	switch entry.EntryType {
+	case domain.LedgerEntryOpening:
+		equity, description = "Equity:Opening-Balances", "Opening balance"
+	case domain.LedgerEntryReconciliation:
+		equity, description = "Equity:Reconciliation", "Reconciliation"
+	}
+	if entry.Description != nil && *entry.Description != "" {
+		description += " - " + *entry.Description
+	}
+	account, currency := p.account(*entry.AccountID), p.currency(*entry.AccountID)
+	p.writeEntry(entry.CreatedAt.Format("2006-01-02"), description, "", []plainTextPosting{
+		{account: account, amount: entry.Amount, currency: currency},
+		{account: equity, amount: -entry.Amount, currency: currency},
+	})
+}
+
+func (p *plainTextWriter) writeTransaction(transaction *dto.TransactionDto) {
+	amount := transaction.Amount.Amount
+	root := "Income:"
+	if transaction.TransactionType == domain.TransactionTypeExpense {
+		amount = -amount
+		root = "Expenses:"
+	}
+	description := "Uncategorized"
+	if transaction.Category != nil {
+		description = *transaction.Category
+	} else if len(transaction.Splits) > 0 {
+		description = "Split"
+	}
+	if transaction.Notes != nil && *transaction.Notes != "" {
+		description = *transaction.Notes
+	}
+
+	// A split transaction books each split on its own account; the shares add up to the total.
+	var postings []plainTextPosting
+	if len(transaction.Splits) > 0 {
+		for _, split := range transaction.Splits {
+			share := split.Amount.Amount
+			if amount < 0 {
+				share = -share
+			}
+			postings = append(postings, plainTextPosting{
+				account: p.nominal(root, &split.CategoryID), amount: -share, currency: transaction.Currency,
+			})
+		}
+	} else {
+		postings = append(postings, plainTextPosting{
+			account: p.nominal(root, transaction.CategoryID), amount: -amount, currency: transaction.Currency,
+		})
+	}
+	postings = append(postings, plainTextPosting{account: p.account(transaction.AccountID), amount: amount, currency: transaction.Currency})
+
+	p.writeEntry(formatExportDate(transaction.TransactionDate, "2006-01-02"), description, strconv.Itoa(transaction.ID), postings)
+}
+
+// nominal names the income or expense account of a category.
+func (p *plainTextWriter) nominal(root string, categoryID *int) string {
+	if categoryID != nil && p.categories[*categoryID] != "" {
+		return root + p.categories[*categoryID]
+	}
+	return root + "Uncategorized"
+}
+
+// writeTransfer writes both legs of a transfer as one entry; in is nil when only one leg was
+// exported.
+func (p *plainTextWriter) writeTransfer(out *dto.TransactionDto, in *dto.TransactionDto) {
+	if in != nil && out.TransferDirection != nil && *out.TransferDirection == domain.TransferDirectionIn {
+		out, in = in, out
+	}
+	description := "Transfer"
+	if out.Notes != nil && *out.Notes != "" {
+		description += " - " + *out.Notes
+	}
+
+	// Transfers are only allowed between accounts of the same currency.
+	postings := []plainTextPosting{{account: p.account(out.AccountID), amount: transferLegAmount(out), currency: out.Currency}}
+	if in != nil {
+		postings = append(postings, plainTextPosting{account: p.account(in.AccountID), amount: transferLegAmount(in), currency: in.Currency})
+	} else {
+		postings = append(postings, plainTextPosting{account: "Equity:Transfers", amount: -transferLegAmount(out), currency: out.Currency})
+	}
+	p.writeEntry(formatExportDate(out.TransactionDate, "2006-01-02"), description, "transfer-"+strconv.Itoa(*out.TransferID), postings)
+}
+
+func transferLegAmount(leg *dto.TransactionDto) int64 {
+	if leg.TransferDirection != nil && *leg.TransferDirection == domain.TransferDirectionOut {
+		return -leg.Amount.Amount
+	}
+	return leg.Amount.Amount
+}
+
+func (p *plainTextWriter) writeEntry(date string, description string, id string, postings []plainTextPosting) {
+	description = strings.Join(strings.Fields(description), " ")
+	if p.format == domain.ExportFormatBeancount {
+		fmt.Fprintf(p.w, "%s * %s\n", date, beancountString(description))
+		if id != "" {
+			fmt.Fprintf(p.w, "  id: %s\n", beancountString(id))
+		}
+		for _, posting := range postings {
+			fmt.Fprintf(p.w, "  %s  %s\n", posting.account, money.Money{Amount: posting.amount, Currency: posting.currency})
+		}
+	} else {
+		fmt.Fprintf(p.w, "%s * %s\n", date, description)
+		if id != "" {
+			fmt.Fprintf(p.w, "    ; id: %s\n", id)
+		}
+		for _, posting := range postings {
+			fmt.Fprintf(p.w, "    %s  %s\n", posting.account, money.Money{Amount: posting.amount, Currency: posting.currency})
+		}
+	}
+	p.w.WriteString("\n")
+}
+
+func (p *plainTextWriter) account(accountID int) string {
+	if name, ok := p.accounts[accountID]; ok {
+		return name
+	}
+	return fmt.Sprintf("Assets:Account-%d", accountID)
+}
+
+func (p *plainTextWriter) currency(accountID int) string {
+	if currency, ok := p.accountCurrencies[accountID]; ok {
+		return currency
+	}
+	return defaultBaseCurrency
+}
+
+// segment turns a name into one account name component. Beancount only allows letters, digits
+// and dashes, starting with a capital or digit; ledger and hledger only reserve the colon and
+// runs of spaces.
+func (p *plainTextWriter) segment(name string) string {
+	if p.format != domain.ExportFormatBeancount {
+		cleaned := strings.Join(strings.Fields(strings.ReplaceAll(name, ":", " ")), " ")
+		if cleaned == "" {
+			return "Unnamed"
+		}
+		return cleaned
+	}
+
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	cleaned := strings.Join(words, "-")
+	if cleaned == "" {
+		return "Unnamed"
+	}
+	if first := []rune(cleaned)[0]; !unicode.IsUpper(first) && !unicode.IsDigit(first) {
+		cleaned = "X-" + cleaned
+	}
+	return cleaned
+}
+
+func beancountString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+func inPlainTextRange(entry domain.LedgerEntry, params dto.GetTransactionParams) bool {
+	if params.AccountID != nil && (entry.AccountID == nil || *entry.AccountID != *params.AccountID) {
+		return false
+	}
+	date := entry.CreatedAt.Format("2006-01-02")
+	if params.StartDate != nil && date < *params.StartDate {
+		return false
+	}
+	if params.EndDate != nil && date > *params.EndDate {
+		return false
+	}
+	return true
+}
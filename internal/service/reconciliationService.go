@@ -0,0 +1,142 @@
+package service
+
+import (
+	"database/sql"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/internal/money"
+	"github.com/google/uuid"
+)
+
+type ReconciliationService struct {
+	reconciliationRepo domain.ReconciliationRepository
+	balance            balanceUpdater
+	db                 *sql.DB
+}
+
+// Run recomputes every account balance from transaction history (plus opening balances and manual
+// adjustments) and reports where the stored balance, the ledger or users.balance, the sum of the
+// accounts in the base currency, disagree. With fix
+// set, all corrections are applied in a single DB transaction: the ledger receives a reconciliation
+// entry for the difference and stored balances are rewritten from it.
+func (r *ReconciliationService) Run(userID *uuid.UUID, fix bool) (*dto.ReconciliationReportDto, error) {
+	if !fix {
+		accountChecks, err := r.reconciliationRepo.FindAccountChecks(userID)
+		if err != nil {
+			return nil, domain.InternalServerError("Failed to check account balances", err)
+		}
+		userChecks, err := r.reconciliationRepo.FindUserChecks(userID)
+		if err != nil {
+			return nil, domain.InternalServerError("Failed to check user balances", err)
+		}
+		return buildReconciliationReport(userChecks, accountChecks), nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Users are locked before accounts, the same order balanceUpdater uses.
+	userChecks, err := r.reconciliationRepo.FindUserChecksTx(tx, userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to check user balances", err)
+	}
+	accountChecks, err := r.reconciliationRepo.FindAccountChecksTx(tx, userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to check account balances", err)
+	}
+	report := buildReconciliationReport(userChecks, accountChecks)
+
+	for _, check := range accountChecks {
+		if check.LedgerBalance == check.ExpectedBalance {
+			continue
+		}
+		err = r.balance.post(tx, check.UserID, journal{
+			entryType: domain.LedgerEntryReconciliation,
+			postings:  equityPostings(check.AccountID, domain.LedgerAccountReconciliation, check.ExpectedBalance-check.LedgerBalance),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mismatchedUsers := map[string]bool{}
+	for _, mismatch := range report.Mismatches {
+		mismatchedUsers[mismatch.UserID] = true
+	}
+	for _, check := range userChecks {
+		if !mismatchedUsers[check.UserID.String()] {
+			continue
+		}
+		if err := r.balance.resync(tx, check.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+
+	report.Fixed = true
+	return report, nil
+}
+
+func buildReconciliationReport(userChecks []domain.UserBalanceCheck, accountChecks []domain.AccountBalanceCheck) *dto.ReconciliationReportDto {
+	report := &dto.ReconciliationReportDto{
+		CheckedUsers:    len(userChecks),
+		CheckedAccounts: len(accountChecks),
+		Mismatches:      []dto.BalanceMismatchDto{},
+	}
+
+	expectedByUser := map[uuid.UUID]int64{}
+	for _, check := range accountChecks {
+		if check.InBaseCurrency {
+			expectedByUser[check.UserID] += check.ExpectedBalance
+		}
+		if check.StoredBalance == check.ExpectedBalance && check.LedgerBalance == check.ExpectedBalance {
+			continue
+		}
+		accountID := check.AccountID
+		accountName := check.AccountName
+		ledgerBalance := money.Money{Amount: check.LedgerBalance}
+		report.Mismatches = append(report.Mismatches, dto.BalanceMismatchDto{
+			UserID:          check.UserID.String(),
+			AccountID:       &accountID,
+			AccountName:     &accountName,
+			StoredBalance:   money.Money{Amount: check.StoredBalance},
+			LedgerBalance:   &ledgerBalance,
+			ExpectedBalance: money.Money{Amount: check.ExpectedBalance},
+		})
+	}
+
+	for _, check := range userChecks {
+		expected := expectedByUser[check.UserID]
+		if check.StoredBalance == expected {
+			continue
+		}
+		report.Mismatches = append(report.Mismatches, dto.BalanceMismatchDto{
+			UserID:          check.UserID.String(),
+			StoredBalance:   money.Money{Amount: check.StoredBalance},
+			ExpectedBalance: money.Money{Amount: expected},
+		})
+	}
+	return report
+}
+
+func NewReconciliationService(
+	reconciliationRepo domain.ReconciliationRepository,
+	userRepo domain.UserRepository,
+	accountRepo domain.AccountRepository,
+	ledgerRepo domain.LedgerRepository,
+	db *sql.DB,
+) domain.ReconciliationUseCase {
+	return &ReconciliationService{
+		reconciliationRepo: reconciliationRepo,
+		balance:            balanceUpdater{userRepo: userRepo, accountRepo: accountRepo, ledgerRepo: ledgerRepo},
+		db:                 db,
+	}
+}
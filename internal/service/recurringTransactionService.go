@@ -0,0 +1,262 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/internal/money"
+	"github.com/dimas-pramantya/money-management/utils/helper"
+	"github.com/google/uuid"
+)
+
+type RecurringTransactionService struct {
+	recurringRepo   domain.RecurringTransactionRepository
+	transactionRepo domain.TransactionRepository
+	trnCategoryRepo domain.TransactionCategoryRepository
+	accountRepo     domain.AccountRepository
+	transactionUC   domain.TransactionUseCase
+}
+
+func (r *RecurringTransactionService) Create(req dto.CreateRecurringTransactionDto, userID uuid.UUID) (*dto.RecurringTransactionDto, error) {
+	recurring := &domain.RecurringTransaction{
+		UserID:    userID,
+		CreatedBy: userID.String(),
+	}
+	if err := r.apply(recurring, dto.UpdateRecurringTransactionDto(req), userID); err != nil {
+		return nil, err
+	}
+	recurring.Reschedule(recurring.StartDate)
+
+	created, err := r.recurringRepo.Create(recurring)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to create recurring transaction", err)
+	}
+	return mapRecurringTransactionToDto(created), nil
+}
+
+func (r *RecurringTransactionService) Delete(id int, userID uuid.UUID) error {
+	if _, err := r.findOwned(id, userID); err != nil {
+		return err
+	}
+	err := r.recurringRepo.Delete(id)
+	if err != nil {
+		return domain.InternalServerError("Failed to delete recurring transaction", err)
+	}
+	return nil
+}
+
+func (r *RecurringTransactionService) FindByID(id int, userID uuid.UUID) (*dto.RecurringTransactionDto, error) {
+	recurring, err := r.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return mapRecurringTransactionToDto(recurring), nil
+}
+
+func (r *RecurringTransactionService) FindByUserID(userID uuid.UUID) ([]dto.RecurringTransactionDto, error) {
+	recurrings, err := r.recurringRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to fetch recurring transactions", err)
+	}
+	result := []dto.RecurringTransactionDto{}
+	for i := range recurrings {
+		result = append(result, *mapRecurringTransactionToDto(&recurrings[i]))
+	}
+	return result, nil
+}
+
+// Update replaces the template and schedule. Occurrences before the current next run date are not
+// posted again, so moving the start date back never backfills.
+func (r *RecurringTransactionService) Update(req dto.UpdateRecurringTransactionDto, id int, userID uuid.UUID) (*dto.RecurringTransactionDto, error) {
+	recurring, err := r.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.apply(recurring, req, userID); err != nil {
+		return nil, err
+	}
+	recurring.Reschedule(recurring.NextRunDate)
+	updatedBy := userID.String()
+	recurring.UpdatedBy = &updatedBy
+
+	updated, err := r.recurringRepo.Update(recurring)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to update recurring transaction", err)
+	}
+	return mapRecurringTransactionToDto(updated), nil
+}
+
+// RunDue posts every occurrence due on or before today, catching up on any the server missed while
+// it was down. Each occurrence goes through TransactionService.Create and is skipped if a
+// transaction for it already exists, so running twice never posts twice. A failing rule is
+// reported and retried on the next run without blocking the others.
+func (r *RecurringTransactionService) RunDue(today time.Time) (int, error) {
+	recurrings, err := r.recurringRepo.FindDue(today)
+	if err != nil {
+		return 0, domain.InternalServerError("Failed to fetch due recurring transactions", err)
+	}
+
+	created := 0
+	for i := range recurrings {
+		n, err := r.runRecurring(&recurrings[i], today)
+		created += n
+		if err != nil {
+			fmt.Printf("Failed to run recurring transaction %d: %v\n", recurrings[i].ID, err)
+		}
+	}
+	return created, nil
+}
+
+func (r *RecurringTransactionService) runRecurring(recurring *domain.RecurringTransaction, today time.Time) (int, error) {
+	created := 0
+	for !recurring.NextRunDate.After(today) && !recurring.Finished() {
+		exists, err := r.transactionRepo.ExistsByRecurrence(recurring.ID, recurring.NextRunDate)
+		if err != nil {
+			return created, err
+		}
+		if !exists {
+			occurrence := helper.DateToString(&recurring.NextRunDate)
+			_, err := r.transactionUC.Create(dto.CreateTransactionDto{
+				Amount:                 money.Money{Amount: recurring.Amount},
+				AccountID:              recurring.AccountID,
+				CategoryID:             recurring.CategoryID,
+				TransactionDate:        *occurrence,
+				TransactionType:        recurring.TransactionType,
+				Note:                   recurring.Notes,
+				RecurringTransactionID: &recurring.ID,
+				RecurringDate:          occurrence,
+			}, recurring.UserID)
+			if err != nil {
+				return created, err
+			}
+			created++
+		}
+
+		recurring.Occurrences++
+		recurring.NextRunDate = recurring.OccurrenceDate(recurring.Occurrences)
+		if err := r.recurringRepo.UpdateSchedule(recurring); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// apply validates req and copies it onto recurring without touching the schedule position.
+func (r *RecurringTransactionService) apply(recurring *domain.RecurringTransaction, req dto.UpdateRecurringTransactionDto, userID uuid.UUID) error {
+	if !req.Amount.IsPositive() {
+		return domain.BadRequestError("Amount must be greater than zero", nil)
+	}
+	if err := validateTransactionType(req.TransactionType); err != nil {
+		return err
+	}
+	if req.DayOfMonth != nil && req.Frequency != domain.FrequencyMonthly {
+		return domain.BadRequestError("day_of_month is only allowed for monthly recurring transactions", nil)
+	}
+	if err := r.checkOwnership(req.AccountID, req.CategoryID, req.TransactionType, userID); err != nil {
+		return err
+	}
+
+	startDate, err := helper.StringToDate(req.StartDate)
+	if err != nil {
+		return domain.BadRequestError("Invalid start date format", err)
+	}
+	var endDate *time.Time
+	if req.EndDate != nil {
+		endDate, err = helper.StringToDate(*req.EndDate)
+		if err != nil {
+			return domain.BadRequestError("Invalid end date format", err)
+		}
+		if endDate.Before(*startDate) {
+			return domain.BadRequestError("End date must not be before start date", nil)
+		}
+	}
+
+	intervalCount := req.IntervalCount
+	if intervalCount == 0 {
+		intervalCount = 1
+	}
+
+	recurring.AccountID = req.AccountID
+	recurring.CategoryID = req.CategoryID
+	recurring.TransactionType = req.TransactionType
+	recurring.Amount = req.Amount.Amount
+	recurring.Notes = req.Note
+	recurring.Frequency = req.Frequency
+	recurring.IntervalCount = intervalCount
+	recurring.DayOfMonth = req.DayOfMonth
+	recurring.StartDate = *startDate
+	recurring.EndDate = endDate
+	return nil
+}
+
+// checkOwnership rejects templates pointing at another user's account or category, or at a category
+// that does not take transactionType, so a rule can never fail on every run for a reason the user
+// could have been told up front.
+func (r *RecurringTransactionService) checkOwnership(accountID int, categoryID int, transactionType string, userID uuid.UUID) error {
+	if _, err := ownedAccount(r.accountRepo, accountID, userID); err != nil {
+		return err
+	}
+	category, err := ownedCategory(r.trnCategoryRepo, categoryID, userID)
+	if err != nil {
+		return err
+	}
+	return checkCategoryKind(category, transactionType)
+}
+
+func (r *RecurringTransactionService) findOwned(id int, userID uuid.UUID) (*domain.RecurringTransaction, error) {
+	recurring, err := r.recurringRepo.FindByID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find recurring transaction", err)
+	}
+	if recurring == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Recurring transaction with id %d not found", id), nil)
+	}
+	if recurring.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+	return recurring, nil
+}
+
+func NewRecurringTransactionService(
+	recurringRepo domain.RecurringTransactionRepository,
+	transactionRepo domain.TransactionRepository,
+	trnCategoryRepo domain.TransactionCategoryRepository,
+	accountRepo domain.AccountRepository,
+	transactionUC domain.TransactionUseCase,
+) domain.RecurringTransactionUseCase {
+	return &RecurringTransactionService{
+		recurringRepo:   recurringRepo,
+		transactionRepo: transactionRepo,
+		trnCategoryRepo: trnCategoryRepo,
+		accountRepo:     accountRepo,
+		transactionUC:   transactionUC,
+	}
+}
+
+func mapRecurringTransactionToDto(recurring *domain.RecurringTransaction) *dto.RecurringTransactionDto {
+	var nextRunDate *string
+	if !recurring.Finished() {
+		nextRunDate = helper.DateToString(&recurring.NextRunDate)
+	}
+	return &dto.RecurringTransactionDto{
+		ID:              recurring.ID,
+		AccountID:       recurring.AccountID,
+		CategoryID:      recurring.CategoryID,
+		TransactionType: recurring.TransactionType,
+		Amount:          money.Money{Amount: recurring.Amount},
+		Notes:           recurring.Notes,
+		Frequency:       recurring.Frequency,
+		IntervalCount:   recurring.IntervalCount,
+		DayOfMonth:      recurring.DayOfMonth,
+		StartDate:       *helper.DateToString(&recurring.StartDate),
+		EndDate:         helper.DateToString(recurring.EndDate),
+		NextRunDate:     nextRunDate,
+		UserID:          recurring.UserID.String(),
+		CreatedAt:       *helper.TimeToString(&recurring.CreatedAt),
+		UpdatedAt:       helper.TimeToString(recurring.UpdatedAt),
+		CreatedBy:       recurring.CreatedBy,
+		UpdatedBy:       recurring.UpdatedBy,
+	}
+}
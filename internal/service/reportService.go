@@ -0,0 +1,204 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/internal/money"
+	"github.com/dimas-pramantya/money-management/utils/helper"
+	"github.com/google/uuid"
+)
+
+type ReportService struct {
+	reportRepo domain.ReportRepository
+	userRepo   domain.UserRepository
+}
+
+// Summary totals income and expense over the requested range, per bucket and per category, rolled
+// up through the category tree. Both aggregations run in the database, in the user's base currency;
+// the summary is refused while some transaction in range has no exchange rate for its date.
+func (r *ReportService) Summary(params dto.GetReportSummaryParams, userID uuid.UUID) (*dto.ReportSummaryDto, error) {
+	bucket := params.Bucket
+	if bucket == "" {
+		bucket = domain.ReportBucketMonth
+	}
+	filter, user, err := r.prepareFilter(params.StartDate, params.EndDate, params.AccountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	filter.Bucket = bucket
+
+	bucketTotals, err := r.reportRepo.SumByBucket(filter)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to summarize transactions by period", err)
+	}
+	categoryTotals, err := r.reportRepo.SumByCategory(filter)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to summarize transactions by category", err)
+	}
+
+	summary := &dto.ReportSummaryDto{
+		StartDate:  params.StartDate,
+		EndDate:    params.EndDate,
+		Bucket:     bucket,
+		Currency:   user.BaseCurrency,
+		Buckets:    []dto.ReportBucketDto{},
+		Categories: []dto.ReportCategoryDto{},
+	}
+	var totalIncome, totalExpense int64
+	for _, total := range bucketTotals {
+		totalIncome += total.Income
+		totalExpense += total.Expense
+		summary.Buckets = append(summary.Buckets, dto.ReportBucketDto{
+			PeriodStart: *helper.DateToString(&total.PeriodStart),
+			Income:      money.Money{Amount: total.Income, Currency: user.BaseCurrency},
+			Expense:     money.Money{Amount: total.Expense, Currency: user.BaseCurrency},
+			Net:         money.Money{Amount: total.Income - total.Expense, Currency: user.BaseCurrency},
+		})
+	}
+	summary.TotalIncome = money.Money{Amount: totalIncome, Currency: user.BaseCurrency}
+	summary.TotalExpense = money.Money{Amount: totalExpense, Currency: user.BaseCurrency}
+	summary.Net = money.Money{Amount: totalIncome - totalExpense, Currency: user.BaseCurrency}
+	summary.Categories = rollUpCategoryTotals(categoryTotals)
+	return summary, nil
+}
+
+// ByTag totals income and expense per tag over the requested range, in the user's base currency.
+func (r *ReportService) ByTag(params dto.GetReportByTagParams, userID uuid.UUID) (*dto.ReportTagSummaryDto, error) {
+	filter, user, err := r.prepareFilter(params.StartDate, params.EndDate, params.AccountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := r.reportRepo.SumByTag(filter)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to summarize transactions by tag", err)
+	}
+
+	report := &dto.ReportTagSummaryDto{
+		StartDate: params.StartDate,
+		EndDate:   params.EndDate,
+		Currency:  user.BaseCurrency,
+		Tags:      []dto.ReportTagDto{},
+	}
+	for _, total := range totals {
+		report.Tags = append(report.Tags, dto.ReportTagDto{
+			TagID:            total.TagID,
+			Tag:              total.Tag,
+			Income:           money.Money{Amount: total.Income, Currency: user.BaseCurrency},
+			Expense:          money.Money{Amount: total.Expense, Currency: user.BaseCurrency},
+			Net:              money.Money{Amount: total.Income - total.Expense, Currency: user.BaseCurrency},
+			TransactionCount: total.TransactionCount,
+		})
+	}
+	return report, nil
+}
+
+// prepareFilter parses the date range of a report and loads the user whose base currency it is
+// in. The report is refused while some transaction in range has no exchange rate for its date.
+func (r *ReportService) prepareFilter(start string, end string, accountID *int, userID uuid.UUID) (domain.ReportFilter, *domain.User, error) {
+	startDate, err := helper.StringToDate(start)
+	if err != nil {
+		return domain.ReportFilter{}, nil, domain.BadRequestError("Invalid start date format", err)
+	}
+	endDate, err := helper.StringToDate(end)
+	if err != nil {
+		return domain.ReportFilter{}, nil, domain.BadRequestError("Invalid end date format", err)
+	}
+	if endDate.Before(*startDate) {
+		return domain.ReportFilter{}, nil, domain.BadRequestError("End date must not be before start date", nil)
+	}
+
+	filter := domain.ReportFilter{
+		UserID:    userID,
+		StartDate: *startDate,
+		EndDate:   *endDate,
+		AccountID: accountID,
+	}
+	user, err := r.userRepo.FindById(userID.String())
+	if err != nil {
+		return filter, nil, domain.InternalServerError("Failed to find user", err)
+	}
+	if user == nil {
+		return filter, nil, domain.NotFoundError(fmt.Sprintf("User with id %s not found", userID), nil)
+	}
+	if err := checkExchangeRates(r.reportRepo, filter, user.BaseCurrency); err != nil {
+		return filter, nil, err
+	}
+	return filter, user, nil
+}
+
+// checkExchangeRates refuses a total over filter while some transaction in it has no exchange rate
+// into baseCurrency for its date, listing the rates to record.
+func checkExchangeRates(reportRepo domain.ReportRepository, filter domain.ReportFilter, baseCurrency string) error {
+	missing, err := reportRepo.FindMissingRates(filter)
+	if err != nil {
+		return domain.InternalServerError("Failed to check exchange rates", err)
+	}
+	if len(missing) > 0 {
+		rates := make([]dto.MissingExchangeRateDto, len(missing))
+		for i, rate := range missing {
+			rates[i] = dto.MissingExchangeRateDto{
+				Currency:     rate.Currency,
+				BaseCurrency: baseCurrency,
+				Date:         *helper.DateToString(&rate.FirstDate),
+			}
+		}
+		return domain.BadRequestError("Exchange rates are missing for some transactions in this range", rates)
+	}
+	return nil
+}
+
+// rollUpCategoryTotals arranges per-category rows into the category tree. Each category's totals
+// take in those of its descendants, and categories with no activity anywhere below them are left out.
+func rollUpCategoryTotals(totals []domain.ReportCategoryTotal) []dto.ReportCategoryDto {
+	children := map[int][]domain.ReportCategoryTotal{}
+	var roots []domain.ReportCategoryTotal
+	for _, total := range totals {
+		if total.ParentID == nil {
+			roots = append(roots, total)
+		} else {
+			children[*total.ParentID] = append(children[*total.ParentID], total)
+		}
+	}
+
+	// build returns the node for total and the number of entries under it.
+	var build func(total domain.ReportCategoryTotal) (dto.ReportCategoryDto, int)
+	build = func(total domain.ReportCategoryTotal) (dto.ReportCategoryDto, int) {
+		node := dto.ReportCategoryDto{
+			CategoryID: total.CategoryID,
+			Category:   total.Category,
+			Income:     money.Money{Amount: total.Income},
+			Expense:    money.Money{Amount: total.Expense},
+			Children:   []dto.ReportCategoryDto{},
+		}
+		entries := total.EntryCount
+		for _, child := range children[total.CategoryID] {
+			childNode, childEntries := build(child)
+			if childEntries == 0 {
+				continue
+			}
+			node.Income.Amount += childNode.Income.Amount
+			node.Expense.Amount += childNode.Expense.Amount
+			node.Children = append(node.Children, childNode)
+			entries += childEntries
+		}
+		node.Net = money.Money{Amount: node.Income.Amount - node.Expense.Amount}
+		return node, entries
+	}
+
+	categories := []dto.ReportCategoryDto{}
+	for _, root := range roots {
+		if node, entries := build(root); entries > 0 {
+			categories = append(categories, node)
+		}
+	}
+	return categories
+}
+
+func NewReportService(reportRepo domain.ReportRepository, userRepo domain.UserRepository) domain.ReportUseCase {
+	return &ReportService{
+		reportRepo: reportRepo,
+		userRepo:   userRepo,
+	}
+}
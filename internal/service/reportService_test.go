@@ -0,0 +1,117 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/internal/money"
+)
+
+func TestRollUpCategoryTotals(t *testing.T) {
+	parent := func(id int) *int { return &id }
+	total := func(id int, parentID *int, income, expense int64, entries int) domain.ReportCategoryTotal {
+		return domain.ReportCategoryTotal{
+			CategoryID: id,
+			Category:   categoryName(id),
+			ParentID:   parentID,
+			Income:     income,
+			Expense:    expense,
+			EntryCount: entries,
+		}
+	}
+	node := func(id int, income, expense int64, children ...dto.ReportCategoryDto) dto.ReportCategoryDto {
+		if children == nil {
+			children = []dto.ReportCategoryDto{}
+		}
+		return dto.ReportCategoryDto{
+			CategoryID: id,
+			Category:   categoryName(id),
+			Income:     money.Money{Amount: income},
+			Expense:    money.Money{Amount: expense},
+			Net:        money.Money{Amount: income - expense},
+			Children:   children,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		totals []domain.ReportCategoryTotal
+		want   []dto.ReportCategoryDto
+	}{
+		{
+			name:   "no categories",
+			totals: nil,
+			want:   []dto.ReportCategoryDto{},
+		},
+		{
+			name: "flat categories keep their order",
+			totals: []domain.ReportCategoryTotal{
+				total(2, nil, 0, 300, 2),
+				total(1, nil, 5000, 0, 1),
+			},
+			want: []dto.ReportCategoryDto{node(2, 0, 300), node(1, 5000, 0)},
+		},
+		{
+			name: "parents take in their descendants",
+			totals: []domain.ReportCategoryTotal{
+				total(1, nil, 0, 100, 1),
+				total(2, parent(1), 0, 200, 1),
+				total(3, parent(2), 50, 400, 2),
+				total(4, parent(1), 0, 30, 1),
+			},
+			want: []dto.ReportCategoryDto{
+				node(1, 50, 730, node(2, 50, 600, node(3, 50, 400)), node(4, 0, 30)),
+			},
+		},
+		{
+			name: "a parent without entries of its own is kept for its children",
+			totals: []domain.ReportCategoryTotal{
+				total(1, nil, 0, 0, 0),
+				total(2, parent(1), 0, 200, 1),
+			},
+			want: []dto.ReportCategoryDto{node(1, 0, 200, node(2, 0, 200))},
+		},
+		{
+			name: "categories without activity anywhere below them are left out",
+			totals: []domain.ReportCategoryTotal{
+				total(1, nil, 0, 0, 0),
+				total(2, parent(1), 0, 0, 0),
+				total(3, parent(2), 0, 0, 0),
+				total(4, nil, 0, 100, 1),
+				total(5, parent(4), 0, 0, 0),
+			},
+			want: []dto.ReportCategoryDto{node(4, 0, 100)},
+		},
+		{
+			name: "entries that net to zero still count as activity",
+			totals: []domain.ReportCategoryTotal{
+				total(1, nil, 0, 0, 0),
+				total(2, parent(1), 100, 100, 2),
+			},
+			want: []dto.ReportCategoryDto{node(1, 100, 100, node(2, 100, 100))},
+		},
+		{
+			name: "children listed before their parent",
+			totals: []domain.ReportCategoryTotal{
+				total(3, parent(2), 0, 10, 1),
+				total(2, parent(1), 0, 20, 1),
+				total(1, nil, 0, 0, 0),
+			},
+			want: []dto.ReportCategoryDto{node(1, 0, 30, node(2, 0, 30, node(3, 0, 10)))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rollUpCategoryTotals(tt.totals)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func categoryName(id int) string {
+	return string(rune('A' + id - 1))
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/internal/domain"
+)
+
+// statementEntry is one entry of an OFX or QIF statement mapped to a transaction. The category is
+// resolved later from category, the name the statement gives, if any. err is set when the entry
+// could not be read.
+type statementEntry struct {
+	line        int
+	transaction domain.Transaction
+	category    string
+	err         error
+}
+
+// qifDateLayouts are tried in order for QIF dates when no profile gives a format. Quicken writes
+// dates month first, padded with spaces and with an apostrophe before two-digit years after 1999.
+var qifDateLayouts = []string{"1/2/2006", "1/2/06", "2006-1-2", "1-2-2006", "1-2-06"}
+
+// parseOFXStatement reads the STMTTRN entries of an OFX file, either SGML (OFX 1.x, where leaf
+// elements have no closing tag) or XML (OFX 2.x). The sign of TRNAMT gives the transaction type
+// and FITID becomes the external id used to skip entries imported before.
+func parseOFXStatement(file io.Reader, currency string) ([]statementEntry, error) {
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, domain.BadRequestError("Failed to read file", err.Error())
+	}
+	text := string(content)
+	start := strings.Index(text, "<")
+	if start < 0 || !strings.Contains(strings.ToUpper(text), "<OFX>") {
+		return nil, domain.BadRequestError("Invalid OFX file", nil)
+	}
+	line := 1 + strings.Count(text[:start], "\n")
+
+	var entries []statementEntry
+	var fields map[string]string
+	entryLine := 0
+	for _, token := range strings.Split(text[start+1:], "<") {
+		tokenLine := line
+		line += strings.Count(token, "\n")
+
+		name, value, found := strings.Cut(token, ">")
+		if !found || strings.HasPrefix(name, "?") || strings.HasPrefix(name, "!") {
+			continue
+		}
+		name = strings.ToUpper(strings.TrimSpace(name))
+		switch name {
+		case "STMTTRN":
+			fields = map[string]string{}
+			entryLine = tokenLine
+		case "/STMTTRN":
+			if fields != nil {
+				entries = append(entries, mapOFXEntry(fields, entryLine, currency))
+				fields = nil
+			}
+		default:
+			if fields != nil && !strings.HasPrefix(name, "/") {
+				fields[name] = html.UnescapeString(strings.TrimSpace(value))
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return nil, domain.BadRequestError("The file has no statement transactions", nil)
+	}
+	return entries, nil
+}
+
+func mapOFXEntry(fields map[string]string, line int, currency string) statementEntry {
+	entry := statementEntry{line: line}
+	if fitID := fields["FITID"]; fitID != "" {
+		entry.transaction.ExternalID = &fitID
+	}
+	if note := joinStatementNote(fields["NAME"], fields["MEMO"]); note != "" {
+		entry.transaction.Notes = &note
+	}
+
+	// DTPOSTED is YYYYMMDD optionally followed by a time and a zone; only the date is kept.
+	posted := fields["DTPOSTED"]
+	if len(posted) < 8 {
+		entry.err = domain.BadRequestError(fmt.Sprintf("Date %q is not an OFX date", posted), nil)
+		return entry
+	}
+	date, err := time.Parse("20060102", posted[:8])
+	if err != nil {
+		entry.err = domain.BadRequestError(fmt.Sprintf("Date %q is not an OFX date", posted), nil)
+		return entry
+	}
+	entry.transaction.TransactionDate = date
+
+	amount, err := parseStatementAmount(fields["TRNAMT"], ".", currency)
+	if err != nil {
+		entry.err = err
+		return entry
+	}
+	setStatementAmount(&entry.transaction, amount)
+	return entry
+}
+
+// parseQIFStatement reads the entries of the bank, cash, credit card and asset or liability
+// sections of a QIF file; other sections such as investments or category lists are skipped.
+// dateFormat is a profile date format, or empty to try the usual QIF layouts. Amounts are read in
+// currency, the currency of the account imported into.
+func parseQIFStatement(file io.Reader, dateFormat string, currency string) ([]statementEntry, error) {
+	scanner := bufio.NewScanner(file)
+	var entries []statementEntry
+	var fields map[byte]string
+	inTransactions := false
+	line, entryLine := 0, 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if strings.HasPrefix(text, "!") {
+			header := strings.ToLower(strings.TrimSpace(text))
+			if strings.HasPrefix(header, "!type:") {
+				switch strings.TrimSpace(strings.TrimPrefix(header, "!type:")) {
+				case "bank", "cash", "ccard", "oth a", "oth l":
+					inTransactions = true
+				default:
+					inTransactions = false
+				}
+			}
+			fields = nil
+			continue
+		}
+		if !inTransactions {
+			continue
+		}
+		if text[0] == '^' {
+			if fields != nil {
+				entries = append(entries, mapQIFEntry(fields, entryLine, dateFormat, currency))
+			}
+			fields = nil
+			continue
+		}
+		if fields == nil {
+			fields = map[byte]string{}
+			entryLine = line
+		}
+		// Split lines (S, E, $) repeat per split; only the first value of a field is kept.
+		if _, seen := fields[text[0]]; !seen {
+			fields[text[0]] = strings.TrimSpace(text[1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, domain.BadRequestError("Invalid QIF file", err.Error())
+	}
+	if fields != nil {
+		entries = append(entries, mapQIFEntry(fields, entryLine, dateFormat, currency))
+	}
+	if len(entries) == 0 {
+		return nil, domain.BadRequestError("The file has no statement transactions", nil)
+	}
+	return entries, nil
+}
+
+func mapQIFEntry(fields map[byte]string, line int, dateFormat string, currency string) statementEntry {
+	entry := statementEntry{line: line}
+	if note := joinStatementNote(fields['P'], fields['M']); note != "" {
+		entry.transaction.Notes = &note
+	}
+	// L holds a category path such as "Food:Groceries", or "[Account]" for a transfer, which has no
+	// category here.
+	if category := fields['L']; category != "" && !strings.HasPrefix(category, "[") {
+		entry.category = category
+	}
+
+	date, err := parseQIFDate(fields['D'], dateFormat)
+	if err != nil {
+		entry.err = err
+		return entry
+	}
+	entry.transaction.TransactionDate = date
+
+	rawAmount := fields['T']
+	if rawAmount == "" {
+		rawAmount = fields['U']
+	}
+	amount, err := parseStatementAmount(rawAmount, ".", currency)
+	if err != nil {
+		entry.err = err
+		return entry
+	}
+	setStatementAmount(&entry.transaction, amount)
+	return entry
+}
+
+func parseQIFDate(raw string, dateFormat string) (time.Time, error) {
+	if dateFormat != "" {
+		if date, err := time.Parse(dateLayout(dateFormat), raw); err == nil {
+			return date, nil
+		}
+		return time.Time{}, domain.BadRequestError(fmt.Sprintf("Date %q does not match format %s", raw, dateFormat), nil)
+	}
+	normalized := strings.ReplaceAll(strings.ReplaceAll(raw, " ", ""), "'", "/")
+	for _, layout := range qifDateLayouts {
+		if date, err := time.Parse(layout, normalized); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, domain.BadRequestError(fmt.Sprintf("Date %q is not a QIF date", raw), nil)
+}
+
+// setStatementAmount stores a signed statement amount: negative amounts are expenses.
+func setStatementAmount(transaction *domain.Transaction, amount int64) {
+	transaction.TransactionType = domain.TransactionTypeIncome
+	if amount < 0 {
+		transaction.TransactionType = domain.TransactionTypeExpense
+		amount = -amount
+	}
+	transaction.Amount = amount
+}
+
+func joinStatementNote(name, memo string) string {
+	switch {
+	case name == "" || strings.EqualFold(name, memo):
+		return memo
+	case memo == "":
+		return name
+	default:
+		return name + " - " + memo
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/utils/helper"
+	"github.com/google/uuid"
+)
+
+type TagService struct {
+	tagRepo domain.TagRepository
+}
+
+func (t *TagService) Create(req dto.CreateTagDto, userID uuid.UUID) (*dto.TagDto, error) {
+	name, err := t.checkName(req.Name, 0, userID)
+	if err != nil {
+		return nil, err
+	}
+	tag := &domain.Tag{
+		UserID:    userID,
+		Name:      name,
+		CreatedBy: userID.String(),
+	}
+
+	created, err := t.tagRepo.Create(tag)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to create tag", err)
+	}
+	return mapTagToDto(created), nil
+}
+
+// Delete removes the tag from every transaction carrying it; the transactions themselves stay.
+func (t *TagService) Delete(id int, userID uuid.UUID) error {
+	if _, err := t.findOwned(id, userID); err != nil {
+		return err
+	}
+	err := t.tagRepo.Delete(id)
+	if err != nil {
+		return domain.InternalServerError("Failed to delete tag", err)
+	}
+	return nil
+}
+
+func (t *TagService) FindByID(id int, userID uuid.UUID) (*dto.TagDto, error) {
+	tag, err := t.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return mapTagToDto(tag), nil
+}
+
+func (t *TagService) FindByUserID(userID uuid.UUID) ([]dto.TagDto, error) {
+	tags, err := t.tagRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to fetch tags", err)
+	}
+	result := []dto.TagDto{}
+	for i := range tags {
+		result = append(result, *mapTagToDto(&tags[i]))
+	}
+	return result, nil
+}
+
+func (t *TagService) Update(req dto.UpdateTagDto, id int, userID uuid.UUID) (*dto.TagDto, error) {
+	tag, err := t.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	name, err := t.checkName(req.Name, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedBy := userID.String()
+	tag.Name = name
+	tag.UpdatedBy = &updatedBy
+
+	updated, err := t.tagRepo.Update(tag)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to update tag", err)
+	}
+	return mapTagToDto(updated), nil
+}
+
+// checkName trims name and rejects it when empty or already used by another of the user's tags;
+// id is the tag being renamed, or 0 for a new one.
+func (t *TagService) checkName(name string, id int, userID uuid.UUID) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", domain.BadRequestError("Tag name is required", nil)
+	}
+	existing, err := t.tagRepo.FindByName(userID, name)
+	if err != nil {
+		return "", domain.InternalServerError("Failed to find tag", err)
+	}
+	if existing != nil && existing.ID != id {
+		return "", domain.BadRequestError(fmt.Sprintf("Tag %q already exists", existing.Name), nil)
+	}
+	return name, nil
+}
+
+func (t *TagService) findOwned(id int, userID uuid.UUID) (*domain.Tag, error) {
+	tag, err := t.tagRepo.FindByID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find tag", err)
+	}
+	if tag == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Tag with id %d not found", id), nil)
+	}
+	if tag.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+	return tag, nil
+}
+
+func NewTagService(tagRepo domain.TagRepository) domain.TagUseCase {
+	return &TagService{
+		tagRepo: tagRepo,
+	}
+}
+
+func mapTagToDto(tag *domain.Tag) *dto.TagDto {
+	return &dto.TagDto{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		UserID:    tag.UserID.String(),
+		CreatedAt: *helper.TimeToString(&tag.CreatedAt),
+		UpdatedAt: helper.TimeToString(tag.UpdatedAt),
+		CreatedBy: tag.CreatedBy,
+		UpdatedBy: tag.UpdatedBy,
+	}
+}
+
+// mapTransactionTagsToDto lists tags the way a transaction shows them, never as null.
+func mapTransactionTagsToDto(tags []domain.Tag) []dto.TransactionTagDto {
+	result := make([]dto.TransactionTagDto, len(tags))
+	for i, tag := range tags {
+		result[i] = dto.TransactionTagDto{ID: tag.ID, Name: tag.Name}
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/money"
+)
+
+var transactionCSVHeader = []string{
+	"id", "transaction_date", "transaction_type", "amount", "currency", "account", "category", "note", "tags", "splits", "transfer_id", "created_at",
+}
+
+// ExportCSV writes every transaction matching params to w as CSV, one row at a time as they are
+// read from the database. Amounts are written in major units, e.g. 10.50 for USD. Paging
+// parameters are ignored.
+func (t *TransactionService) ExportCSV(params dto.GetTransactionParams, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(transactionCSVHeader); err != nil {
+		return err
+	}
+
+	err := t.transactionRepo.StreamByFilter(params, func(transaction *dto.TransactionDto) error {
+		return writer.Write([]string{
+			strconv.Itoa(transaction.ID),
+			formatExportDate(transaction.TransactionDate, "2006-01-02"),
+			transaction.TransactionType,
+			money.Money{Amount: transaction.Amount.Amount, Currency: transaction.Currency}.Decimal(),
+			transaction.Currency,
+			transaction.Account,
+			stringOrEmpty(transaction.CategoryPath),
+			stringOrEmpty(transaction.Notes),
+			joinTagNames(transaction.Tags),
+			joinSplits(transaction.Splits, transaction.Currency),
+			intOrEmpty(transaction.TransferID),
+			formatExportDate(transaction.CreatedAt, "2006-01-02 15:04:05"),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatExportDate reformats a timestamp as scanned from the database into layout, leaving values
+// it cannot parse untouched.
+func formatExportDate(value string, layout string) string {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return value
+	}
+	return parsed.Format(layout)
+}
+
+// joinTagNames lists tag names separated by semicolons, which tag names rarely contain.
+func joinTagNames(tags []dto.TransactionTagDto) string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return strings.Join(names, ";")
+}
+
+// joinSplits lists the splits of a transaction as category path=amount separated by semicolons,
+// e.g. "Food:Groceries=12.00;Household=3.50".
+func joinSplits(splits []dto.TransactionSplitDto, currency string) string {
+	parts := make([]string, len(splits))
+	for i, split := range splits {
+		parts[i] = split.CategoryPath + "=" + money.Money{Amount: split.Amount.Amount, Currency: currency}.Decimal()
+	}
+	return strings.Join(parts, ";")
+}
+
+func stringOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func intOrEmpty(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
@@ -4,91 +4,338 @@ import (
 	"database/sql"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/dimas-pramantya/money-management/dto"
 	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/internal/money"
 	"github.com/dimas-pramantya/money-management/utils/helper"
 	"github.com/google/uuid"
 )
 
 type TransactionService struct {
-	transactionRepo    domain.TransactionRepository
-	trnCategoryRepo    domain.TransactionCategoryRepository
-	trnSubCategoryRepo domain.TransactionSubCategoryRepository
-	userRepo           domain.UserRepository
+	transactionRepo domain.TransactionRepository
+	trnCategoryRepo domain.TransactionCategoryRepository
+	accountRepo     domain.AccountRepository
+	tagRepo         domain.TagRepository
+	transferUC      domain.TransferUseCase
+	balance         balanceUpdater
 	db 				   *sql.DB
 }
 
 func (t *TransactionService) Create(req dto.CreateTransactionDto, userID uuid.UUID) (*dto.TransactionDto, error) {
-	category, err := t.trnCategoryRepo.FindByID(req.CategoryID)
+	prepared, err := t.prepareCreate(req, userID)
 	if err != nil {
-		return nil, domain.InternalServerError("Failed to find transaction category", err)
+		return nil, err
 	}
-	if category == nil {
-		return nil, domain.NotFoundError(fmt.Sprintf("Category with id %d not found", req.CategoryID), nil)
+
+	tx, err := t.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	created, err := t.insert(tx, prepared, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	subCategory := &domain.TransactionSubCategory{}
-	if req.SubCategoryID != nil {
-		subCategory, err = t.trnSubCategoryRepo.FindByID(*req.SubCategoryID)
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+	return created, nil
+}
+
+// ValidateCreate applies the checks of Create without writing anything.
+func (t *TransactionService) ValidateCreate(req dto.CreateTransactionDto, userID uuid.UUID) error {
+	_, err := t.prepareCreate(req, userID)
+	return err
+}
+
+// CreateBatch creates every transaction in reqs inside a single DB transaction: either all of
+// them are recorded or, on the first invalid one, none.
+func (t *TransactionService) CreateBatch(reqs []dto.CreateTransactionDto, userID uuid.UUID) ([]dto.TransactionDto, error) {
+	prepared := make([]*preparedTransaction, 0, len(reqs))
+	for _, req := range reqs {
+		p, err := t.prepareCreate(req, userID)
 		if err != nil {
-			return nil, domain.InternalServerError("Failed to find transaction sub-category", err)
-		}
-		if subCategory == nil {
-			return nil, domain.NotFoundError(fmt.Sprintf("Sub-category with id %d not found", *req.SubCategoryID), nil)
+			return nil, err
 		}
+		prepared = append(prepared, p)
 	}
 
 	tx, err := t.db.Begin()
-
-	user, err := t.userRepo.FindById(userID.String())
 	if err != nil {
-		return nil, domain.InternalServerError("Failed to find user", err)
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
 	}
-	if user == nil {
-		return nil, domain.NotFoundError(fmt.Sprintf("User with id %s not found", userID), nil)
+	defer tx.Rollback()
+
+	created := make([]dto.TransactionDto, 0, len(prepared))
+	for _, p := range prepared {
+		transaction, err := t.insert(tx, p, userID)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, *transaction)
 	}
-	ammount := req.Amount
-	if req.TransactionType == "income" {
-		ammount = req.Amount
-	}else if req.TransactionType == "expense" {
-		ammount = -req.Amount
-	}else{
-		return nil, domain.BadRequestError("Invalid transaction type", nil)
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
 	}
-	user.Balance += ammount
-	t.userRepo.UpdateBalanceTx(tx, user)
+	return created, nil
+}
+
+// preparedTransaction is a validated create request along with the names and tags its response
+// needs.
+type preparedTransaction struct {
+	transaction     *domain.Transaction
+	account         string
+	categories      *transactionCategories
+	tags            []domain.Tag
+}
+
+// transactionCategories is where a validated request books its amount: either a single category
+// or a set of splits, along with the names the response shows.
+type transactionCategories struct {
+	categoryID *int
+	category   *domain.TransactionCategory
+	splits     []domain.TransactionSplit
+	splitDtos  []dto.TransactionSplitDto
+}
 
+func (t *TransactionService) prepareCreate(req dto.CreateTransactionDto, userID uuid.UUID) (*preparedTransaction, error) {
+	if !req.Amount.IsPositive() {
+		return nil, domain.BadRequestError("Amount must be greater than zero", nil)
+	}
+	account, err := t.findAccount(req.AccountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTransactionCurrency(req.Currency, account); err != nil {
+		return nil, err
+	}
+	if err := validateTransactionType(req.TransactionType); err != nil {
+		return nil, err
+	}
+	categories, err := t.prepareCategories(req.CategoryID, req.Splits, req.Amount, req.TransactionType, userID)
+	if err != nil {
+		return nil, err
+	}
 	transactionDate, err := helper.StringToDate(req.TransactionDate)
 	if err != nil {
 		return nil, domain.BadRequestError("Invalid transaction date format", err)
 	}
+	tags, err := ownedTags(t.tagRepo, req.TagIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	var recurringDate *time.Time
+	if req.RecurringDate != nil {
+		recurringDate, err = helper.StringToDate(*req.RecurringDate)
+		if err != nil {
+			return nil, domain.BadRequestError("Invalid recurring date format", err)
+		}
+	}
+
 	transaction := &domain.Transaction{
-		Ammount:          req.Amount,
-		CategoryID:      req.CategoryID,
-		SubCategoryID:   req.SubCategoryID,
+		Amount:         req.Amount.Amount,
+		AccountID:       req.AccountID,
+		CategoryID:      categories.categoryID,
 		TransactionDate: *transactionDate,
 		TransactionType: req.TransactionType,
-		Notes:            req.Note,
+		Notes:           req.Note,
+		RecurringTransactionID: req.RecurringTransactionID,
+		RecurringDate:   recurringDate,
+		ExternalID:      req.ExternalID,
 		UserID:          userID,
+		CreatedBy:       userID.String(),
 	}
+	return &preparedTransaction{
+		transaction:     transaction,
+		account:         account.Name,
+		categories:      categories,
+		tags:            tags,
+	}, nil
+}
 
-	createdTransaction, err := t.transactionRepo.Create(tx, transaction)
+// insert records a prepared transaction and posts it to the ledger inside tx.
+func (t *TransactionService) insert(tx *sql.Tx, prepared *preparedTransaction, userID uuid.UUID) (*dto.TransactionDto, error) {
+	createdTransaction, err := t.transactionRepo.Create(tx, prepared.transaction)
 	if err != nil {
 		return nil, domain.InternalServerError("Failed to create transaction", err)
 	}
+	if len(prepared.tags) > 0 {
+		if err := t.tagRepo.SetTransactionTags(tx, createdTransaction.ID, tagIDs(prepared.tags)); err != nil {
+			return nil, domain.InternalServerError("Failed to tag transaction", err)
+		}
+	}
+	if len(prepared.categories.splits) > 0 {
+		createdTransaction.Splits, err = t.transactionRepo.ReplaceSplits(tx, createdTransaction.ID, prepared.categories.splits)
+		if err != nil {
+			return nil, domain.InternalServerError("Failed to split transaction", err)
+		}
+	}
 
-	err = tx.Commit()
+	err = t.balance.post(tx, userID, journal{
+		entryType:   domain.LedgerEntryTransaction,
+		description: createdTransaction.Notes,
+		postings:    transactionPostings(createdTransaction, 1),
+	})
 	if err != nil {
-		return nil, domain.InternalServerError("Failed to commit transaction", err)
+		return nil, err
 	}
 
-	return mapTransactionToDto(createdTransaction, category.Name, &subCategory.Name), nil
+	categories := prepared.categories
+	return mapTransactionToDto(createdTransaction, prepared.account, categories.category, prepared.tags, categories.splitDtos), nil
 }
 
-// Delete implements domain.TransactionUseCase.
+// Delete moves the transaction to the trash and reverses it in the ledger, so balances no longer
+// include it. A transfer leg takes the whole transfer and its other legs to the trash with it.
 func (t *TransactionService) Delete(id int, userID uuid.UUID) error {
-	panic("unimplemented")
+	existing, err := t.findOwned(id, userID)
+	if err != nil {
+		return err
+	}
+	if existing.TransferID != nil {
+		return t.transferUC.Delete(*existing.TransferID, userID)
+	}
+
+	tx, err := t.db.Begin()
+	if err != nil {
+		return domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	transaction, err := t.findOwnedTx(tx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	err = t.balance.post(tx, userID, journal{
+		entryType: domain.LedgerEntryReversal,
+		postings:  transactionPostings(transaction, -1),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = t.transactionRepo.SoftDelete(tx, id, userID)
+	if err != nil {
+		return domain.InternalServerError("Failed to delete transaction", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.InternalServerError("Failed to commit transaction", err)
+	}
+	return nil
+}
+
+// FindDeleted lists the user's trash, most recently deleted first.
+func (t *TransactionService) FindDeleted(userID uuid.UUID, params dto.GetDeletedTransactionParams) (dto.PaginationResponse[dto.TransactionDto], error) {
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+
+	total, err := t.transactionRepo.CountDeleted(userID)
+	if err != nil {
+		return dto.PaginationResponse[dto.TransactionDto]{}, domain.InternalServerError("Failed to count deleted transactions", err)
+	}
+	records, err := t.transactionRepo.FindDeleted(userID, params.Limit, (params.Page-1)*params.Limit)
+	if err != nil {
+		return dto.PaginationResponse[dto.TransactionDto]{}, domain.InternalServerError("Failed to fetch deleted transactions", err)
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(params.Limit)))
+	var nextPage *int
+	if params.Page < totalPages {
+		next := params.Page + 1
+		nextPage = &next
+	}
+	var prevPage *int
+	if params.Page > 1 {
+		prev := params.Page - 1
+		prevPage = &prev
+	}
+
+	return dto.PaginationResponse[dto.TransactionDto]{
+		TotalRecords: total,
+		TotalPages:   totalPages,
+		CurrentPage:  params.Page,
+		Limit:        params.Limit,
+		Records:      records,
+		NextPage:     nextPage,
+		PreviousPage: prevPage,
+	}, nil
+}
+
+// Restore takes a transaction out of the trash and posts it to the ledger again. A transfer leg
+// brings back the whole transfer.
+func (t *TransactionService) Restore(id int, userID uuid.UUID) (*dto.TransactionDto, error) {
+	existing, err := t.transactionRepo.FindByID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction", err)
+	}
+	if existing != nil && existing.UserID == userID && existing.TransferID != nil {
+		if _, err := t.transferUC.Restore(*existing.TransferID, userID); err != nil {
+			return nil, err
+		}
+		return t.FindByID(id, userID)
+	}
+
+	tx, err := t.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	transaction, err := t.transactionRepo.FindByIDTx(tx, id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction", err)
+	}
+	if transaction == nil || transaction.DeletedAt == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Deleted transaction with id %d not found", id), nil)
+	}
+	if transaction.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+
+	restored, err := t.transactionRepo.Restore(tx, id, userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to restore transaction", err)
+	}
+	restored.Splits, err = t.transactionRepo.FindSplitsByTransactionIDTx(tx, id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction splits", err)
+	}
+	err = t.balance.post(tx, userID, journal{
+		entryType: domain.LedgerEntryTransaction,
+		postings:  transactionPostings(restored, 1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+	return t.FindByID(id, userID)
+}
+
+// PurgeDeleted removes for good the transactions that have been in the trash since before before.
+// Transfer legs are left to TransferService.PurgeDeleted, which removes them with their transfer.
+func (t *TransactionService) PurgeDeleted(before time.Time) (int64, error) {
+	purged, err := t.transactionRepo.PurgeDeleted(before)
+	if err != nil {
+		return 0, domain.InternalServerError("Failed to purge deleted transactions", err)
+	}
+	return purged, nil
 }
 
 func (t *TransactionService) FindByFilter(params dto.GetTransactionParams) (dto.PaginationResponse[dto.TransactionDto], error) {
@@ -125,45 +372,475 @@ func (t *TransactionService) FindByFilter(params dto.GetTransactionParams) (dto.
 	}, nil
 }
 
+// FindByCursor implements domain.TransactionUseCase.
+func (t *TransactionService) FindByCursor(params dto.GetTransactionParams) (dto.CursorResponse[dto.TransactionDto], error) {
+	descending, err := cursorDirection(params.Sort)
+	if err != nil {
+		return dto.CursorResponse[dto.TransactionDto]{}, err
+	}
+	var after *domain.TransactionCursor
+	if params.Cursor != nil && *params.Cursor != "" {
+		after, err = domain.DecodeTransactionCursor(*params.Cursor)
+		if err != nil {
+			return dto.CursorResponse[dto.TransactionDto]{}, err
+		}
+	}
+
+	// One row more than the page tells whether another page follows.
+	limit := params.Limit
+	params.Limit = limit + 1
+	records, err := t.transactionRepo.FindByCursor(params, after, descending)
+	if err != nil {
+		return dto.CursorResponse[dto.TransactionDto]{}, domain.InternalServerError("Failed to fetch transactions", err)
+	}
+	var nextCursor *string
+	if len(records) > limit {
+		records = records[:limit]
+		last := records[limit-1]
+		cursor := domain.TransactionCursor{Date: last.TransactionDate, ID: last.ID}.Encode()
+		nextCursor = &cursor
+	}
+	if records == nil {
+		records = []dto.TransactionDto{}
+	}
+
+	var total *int
+	if params.WithCount {
+		count, err := t.transactionRepo.CountByFilter(params)
+		if err != nil {
+			return dto.CursorResponse[dto.TransactionDto]{}, domain.InternalServerError("Failed to count transactions", err)
+		}
+		total = &count
+	}
+
+	return dto.CursorResponse[dto.TransactionDto]{
+		Limit:        limit,
+		Records:      records,
+		NextCursor:   nextCursor,
+		TotalRecords: total,
+	}, nil
+}
+
+// cursorDirection reports whether a cursor listing runs newest first. Cursors page on the
+// transaction date, so that is the only field it can be sorted by.
+func cursorDirection(sort *string) (bool, error) {
+	if sort == nil || *sort == "" {
+		return false, nil
+	}
+	keys, err := domain.ParseTransactionSort(*sort)
+	if err != nil {
+		return false, err
+	}
+	if len(keys) != 1 || keys[0].Field != domain.TransactionSortDate {
+		return false, domain.BadRequestError("Cursor pagination can only sort by transaction_date:asc or transaction_date:desc", nil)
+	}
+	return keys[0].Descending, nil
+}
+
 // FindByID implements domain.TransactionUseCase.
-func (t *TransactionService) FindByID(id int) (*dto.TransactionDto, error) {
-	panic("unimplemented")
+func (t *TransactionService) FindByID(id int, userID uuid.UUID) (*dto.TransactionDto, error) {
+	transaction, err := t.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := t.accountRepo.FindByID(transaction.AccountID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find account", err)
+	}
+	tags, err := t.tagRepo.FindByTransactionID(transaction.ID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction tags", err)
+	}
+	splits, err := t.mapSplitsToDto(transaction.Splits, userID)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.CategoryID == nil {
+		return mapTransactionToDto(transaction, account.Name, nil, tags, splits), nil
+	}
+	category, err := ownedCategory(t.trnCategoryRepo, *transaction.CategoryID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapTransactionToDto(transaction, account.Name, category, tags, splits), nil
 }
 
 // Update implements domain.TransactionUseCase.
 func (t *TransactionService) Update(req dto.UpdateTransactionDto, id int, userID uuid.UUID) (*dto.TransactionDto, error) {
-	panic("unimplemented")
+	existing, err := t.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.TransferID != nil {
+		if len(req.Splits) > 0 {
+			return nil, domain.BadRequestError("Transfers cannot be split", nil)
+		}
+		return t.updateTransferLeg(existing, req, userID)
+	}
+	if !req.Amount.IsPositive() {
+		return nil, domain.BadRequestError("Amount must be greater than zero", nil)
+	}
+
+	account, err := t.findAccount(req.AccountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTransactionCurrency(req.Currency, account); err != nil {
+		return nil, err
+	}
+	if err := validateTransactionType(req.TransactionType); err != nil {
+		return nil, err
+	}
+	categories, err := t.prepareCategories(req.CategoryID, req.Splits, req.Amount, req.TransactionType, userID)
+	if err != nil {
+		return nil, err
+	}
+	transactionDate, err := helper.StringToDate(req.TransactionDate)
+	if err != nil {
+		return nil, domain.BadRequestError("Invalid transaction date format", err)
+	}
+	tags, err := t.requestedTags(req.TagIDs, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := t.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	transaction, err := t.findOwnedTx(tx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.balance.post(tx, userID, journal{
+		entryType: domain.LedgerEntryReversal,
+		postings:  transactionPostings(transaction, -1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updatedBy := userID.String()
+	transaction.Amount = req.Amount.Amount
+	transaction.AccountID = req.AccountID
+	transaction.CategoryID = categories.categoryID
+	transaction.TransactionDate = *transactionDate
+	transaction.TransactionType = req.TransactionType
+	transaction.Notes = req.Note
+	transaction.UpdatedBy = &updatedBy
+
+	updatedTransaction, err := t.transactionRepo.Update(tx, transaction)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to update transaction", err)
+	}
+	if req.TagIDs != nil {
+		if err := t.tagRepo.SetTransactionTags(tx, id, tagIDs(tags)); err != nil {
+			return nil, domain.InternalServerError("Failed to tag transaction", err)
+		}
+	}
+	updatedTransaction.Splits, err = t.transactionRepo.ReplaceSplits(tx, id, categories.splits)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to split transaction", err)
+	}
+
+	err = t.balance.post(tx, userID, journal{
+		entryType:   domain.LedgerEntryTransaction,
+		description: updatedTransaction.Notes,
+		postings:    transactionPostings(updatedTransaction, 1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+
+	return mapTransactionToDto(updatedTransaction, account.Name, categories.category, tags, categories.splitDtos), nil
+}
+
+// updateTransferLeg applies an edit made on one leg of a transfer to the whole transfer, so the
+// outgoing and incoming legs never disagree. Editing the fee leg changes the fee.
+func (t *TransactionService) updateTransferLeg(leg *domain.Transaction, req dto.UpdateTransactionDto, userID uuid.UUID) (*dto.TransactionDto, error) {
+	transfer, err := t.transferUC.FindByID(*leg.TransferID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	update := dto.UpdateTransferDto{
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		Fee:           transfer.Fee,
+		FeeCategoryID: transfer.FeeCategoryID,
+		TransferDate:  req.TransactionDate,
+		Note:          req.Note,
+	}
+	switch {
+	case leg.TransferDirection == nil:
+		update.FromAccountID = req.AccountID
+		update.Fee = req.Amount
+		update.FeeCategoryID = &req.CategoryID
+	case *leg.TransferDirection == domain.TransferDirectionOut:
+		update.FromAccountID = req.AccountID
+		update.Amount = req.Amount
+	default:
+		update.ToAccountID = req.AccountID
+		update.Amount = req.Amount
+	}
+
+	tags, err := t.requestedTags(req.TagIDs, leg.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The transfer and the leg's tags are written together, so a failed tag write leaves the
+	// transfer as it was.
+	tx, err := t.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := t.transferUC.UpdateTx(tx, update, transfer.ID, userID); err != nil {
+		return nil, err
+	}
+	// Tags belong to the edited leg only; a fee leg is gone once the fee is set to zero.
+	if req.TagIDs != nil && (leg.TransferDirection != nil || req.Amount.IsPositive()) {
+		if err := t.tagRepo.SetTransactionTags(tx, leg.ID, tagIDs(tags)); err != nil {
+			return nil, domain.InternalServerError("Failed to tag transaction", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+	return t.FindByID(leg.ID, userID)
+}
+
+// requestedTags returns the tags an update asks for, or the current tags of the transaction when
+// the request leaves them out.
+func (t *TransactionService) requestedTags(ids *[]int, transactionID int, userID uuid.UUID) ([]domain.Tag, error) {
+	if ids == nil {
+		tags, err := t.tagRepo.FindByTransactionID(transactionID)
+		if err != nil {
+			return nil, domain.InternalServerError("Failed to find transaction tags", err)
+		}
+		return tags, nil
+	}
+	return ownedTags(t.tagRepo, *ids, userID)
+}
+
+func tagIDs(tags []domain.Tag) []int {
+	ids := make([]int, len(tags))
+	for i, tag := range tags {
+		ids[i] = tag.ID
+	}
+	return ids
+}
+
+func (t *TransactionService) findAccount(id int, userID uuid.UUID) (*domain.Account, error) {
+	return ownedAccount(t.accountRepo, id, userID)
+}
+
+func (t *TransactionService) findOwned(id int, userID uuid.UUID) (*domain.Transaction, error) {
+	transaction, err := t.transactionRepo.FindByID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction", err)
+	}
+	if transaction == nil || transaction.DeletedAt != nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Transaction with id %d not found", id), nil)
+	}
+	if transaction.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+	transaction.Splits, err = t.transactionRepo.FindSplitsByTransactionID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction splits", err)
+	}
+	return transaction, nil
+}
+
+// findOwnedTx loads and locks a transaction inside tx, rejecting ids that belong to another user.
+func (t *TransactionService) findOwnedTx(tx *sql.Tx, id int, userID uuid.UUID) (*domain.Transaction, error) {
+	transaction, err := t.transactionRepo.FindByIDTx(tx, id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction", err)
+	}
+	if transaction == nil || transaction.DeletedAt != nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Transaction with id %d not found", id), nil)
+	}
+	if transaction.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+	transaction.Splits, err = t.transactionRepo.FindSplitsByTransactionIDTx(tx, id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction splits", err)
+	}
+	return transaction, nil
+}
+
+// prepareCategories checks where a request books its amount. Without splits that is categoryID;
+// with splits the transaction has no category of its own and the split amounts must add up to
+// amount. Every category used must accept transactionType.
+func (t *TransactionService) prepareCategories(categoryID int, splits []dto.CreateTransactionSplitDto, amount money.Money, transactionType string, userID uuid.UUID) (*transactionCategories, error) {
+	if len(splits) == 0 {
+		if categoryID == 0 {
+			return nil, domain.BadRequestError("category_id is required unless the transaction is split", nil)
+		}
+		category, err := ownedCategory(t.trnCategoryRepo, categoryID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCategoryKind(category, transactionType); err != nil {
+			return nil, err
+		}
+		return &transactionCategories{categoryID: &categoryID, category: category}, nil
+	}
+
+	if categoryID != 0 {
+		return nil, domain.BadRequestError("A split transaction takes its categories from its splits; leave category_id out", nil)
+	}
+	if len(splits) < 2 {
+		return nil, domain.BadRequestError("A split transaction needs at least two splits", nil)
+	}
+	result := &transactionCategories{splits: make([]domain.TransactionSplit, 0, len(splits))}
+	var total int64
+	for _, split := range splits {
+		if !split.Amount.IsPositive() {
+			return nil, domain.BadRequestError("Split amounts must be greater than zero", nil)
+		}
+		total += split.Amount.Amount
+		result.splits = append(result.splits, domain.TransactionSplit{
+			CategoryID: split.CategoryID,
+			Amount:     split.Amount.Amount,
+			Notes:      split.Note,
+		})
+	}
+	if total != amount.Amount {
+		return nil, domain.BadRequestError(fmt.Sprintf("Splits add up to %d but the transaction amount is %d", total, amount.Amount), nil)
+	}
+	for _, split := range result.splits {
+		category, err := ownedCategory(t.trnCategoryRepo, split.CategoryID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCategoryKind(category, transactionType); err != nil {
+			return nil, err
+		}
+	}
+	splitDtos, err := t.mapSplitsToDto(result.splits, userID)
+	if err != nil {
+		return nil, err
+	}
+	result.splitDtos = splitDtos
+	return result, nil
+}
+
+// mapSplitsToDto looks up the category names of splits, checking that they belong to the user.
+func (t *TransactionService) mapSplitsToDto(splits []domain.TransactionSplit, userID uuid.UUID) ([]dto.TransactionSplitDto, error) {
+	result := make([]dto.TransactionSplitDto, 0, len(splits))
+	for _, split := range splits {
+		category, err := ownedCategory(t.trnCategoryRepo, split.CategoryID, userID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, dto.TransactionSplitDto{
+			CategoryID:   split.CategoryID,
+			Category:     category.Name,
+			CategoryPath: category.Path,
+			Amount:       money.Money{Amount: split.Amount},
+			Notes:        split.Notes,
+		})
+	}
+	return result, nil
+}
+
+// checkCategoryKind rejects filing a transaction of transactionType under a category whose kind
+// excludes it.
+func checkCategoryKind(category *domain.TransactionCategory, transactionType string) error {
+	if !category.Accepts(transactionType) {
+		return domain.BadRequestError(fmt.Sprintf("Category %q only takes %s transactions", category.Path, category.Kind), nil)
+	}
+	return nil
+}
+
+func validateTransactionType(transactionType string) error {
+	if transactionType == domain.TransactionTypeTransfer {
+		return domain.BadRequestError("Transfers must be recorded through /transfers", nil)
+	}
+	if transactionType != domain.TransactionTypeIncome && transactionType != domain.TransactionTypeExpense {
+		return domain.BadRequestError("Invalid transaction type", nil)
+	}
+	return nil
 }
 
 func NewTransactionService(
 	transactionRepo domain.TransactionRepository,
 	trnCategoryRepo domain.TransactionCategoryRepository,
-	trnSubCategoryRepo domain.TransactionSubCategoryRepository,
 	userRepo domain.UserRepository,
+	accountRepo domain.AccountRepository,
+	tagRepo domain.TagRepository,
+	transferUC domain.TransferUseCase,
+	ledgerRepo domain.LedgerRepository,
 	db *sql.DB,
 ) domain.TransactionUseCase {
 	return &TransactionService{
-		transactionRepo:    transactionRepo,
-		trnCategoryRepo:    trnCategoryRepo,
-		trnSubCategoryRepo: trnSubCategoryRepo,
-		userRepo:           userRepo,
+		transactionRepo: transactionRepo,
+		trnCategoryRepo: trnCategoryRepo,
+		accountRepo:     accountRepo,
+		tagRepo:         tagRepo,
+		transferUC:      transferUC,
+		balance:         balanceUpdater{userRepo: userRepo, accountRepo: accountRepo, ledgerRepo: ledgerRepo},
 		db: 				db,
 	}
 }
 
-func mapTransactionToDto(transaction *domain.Transaction, category string, subCategory *string) *dto.TransactionDto {
-	return &dto.TransactionDto{
+// checkTransactionCurrency rejects a requested currency other than the account's; amounts are
+// always kept in the currency of the account they move.
+func checkTransactionCurrency(currency *string, account *domain.Account) error {
+	if currency != nil && *currency != account.Currency {
+		return domain.BadRequestError(fmt.Sprintf("Transaction currency %s does not match the account currency %s", *currency, account.Currency), nil)
+	}
+	return nil
+}
+
+// mapTransactionToDto builds the response for a transaction; category is nil for a split one.
+func mapTransactionToDto(transaction *domain.Transaction, account string, category *domain.TransactionCategory, tags []domain.Tag, splits []dto.TransactionSplitDto) *dto.TransactionDto {
+	if splits == nil {
+		splits = []dto.TransactionSplitDto{}
+	}
+	result := &dto.TransactionDto{
 		ID:              transaction.ID,
-		Ammount:          transaction.Ammount,
+		Amount:          money.Money{Amount: transaction.Amount, Currency: transaction.Currency},
+		Currency:        transaction.Currency,
+		AccountID:       transaction.AccountID,
+		Account:         account,
 		CategoryID:      transaction.CategoryID,
-		SubCategoryID:   transaction.SubCategoryID,
 		TransactionDate: *helper.DateToString(&transaction.TransactionDate),
 		TransactionType: transaction.TransactionType,
 		Notes:            transaction.Notes,
+		TransferID:      transaction.TransferID,
+		TransferDirection: transaction.TransferDirection,
+		RecurringTransactionID: transaction.RecurringTransactionID,
+		Tags:            mapTransactionTagsToDto(tags),
+		Splits:          splits,
 		UserID:          transaction.UserID.String(),
 		CreatedAt:       *helper.TimeToString(&transaction.CreatedAt),
 		UpdatedAt:       helper.TimeToString(transaction.UpdatedAt),
 		CreatedBy:       transaction.CreatedBy,
 		UpdatedBy:       transaction.UpdatedBy,
 	}
+	if category != nil {
+		result.Category = &category.Name
+		result.CategoryPath = &category.Path
+	}
+	return result
 }
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/utils/helper"
+	"github.com/google/uuid"
+)
+
+type TransactionViewService struct {
+	viewRepo domain.TransactionViewRepository
+}
+
+func (t *TransactionViewService) Create(req dto.CreateTransactionViewDto, userID uuid.UUID) (*dto.TransactionViewDto, error) {
+	name, err := t.checkName(req.Name, 0, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTransactionFilter(req.Filter); err != nil {
+		return nil, err
+	}
+	view := &domain.TransactionView{
+		UserID:    userID,
+		Name:      name,
+		Filter:    req.Filter,
+		CreatedBy: userID.String(),
+	}
+
+	created, err := t.viewRepo.Create(view)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to create transaction view", err)
+	}
+	return mapTransactionViewToDto(created), nil
+}
+
+func (t *TransactionViewService) Delete(id int, userID uuid.UUID) error {
+	if _, err := t.findOwned(id, userID); err != nil {
+		return err
+	}
+	err := t.viewRepo.Delete(id)
+	if err != nil {
+		return domain.InternalServerError("Failed to delete transaction view", err)
+	}
+	return nil
+}
+
+func (t *TransactionViewService) FindByID(id int, userID uuid.UUID) (*dto.TransactionViewDto, error) {
+	view, err := t.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return mapTransactionViewToDto(view), nil
+}
+
+func (t *TransactionViewService) FindByUserID(userID uuid.UUID) ([]dto.TransactionViewDto, error) {
+	views, err := t.viewRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to fetch transaction views", err)
+	}
+	result := []dto.TransactionViewDto{}
+	for i := range views {
+		result = append(result, *mapTransactionViewToDto(&views[i]))
+	}
+	return result, nil
+}
+
+func (t *TransactionViewService) Update(req dto.UpdateTransactionViewDto, id int, userID uuid.UUID) (*dto.TransactionViewDto, error) {
+	view, err := t.findOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	name, err := t.checkName(req.Name, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTransactionFilter(req.Filter); err != nil {
+		return nil, err
+	}
+
+	updatedBy := userID.String()
+	view.Name = name
+	view.Filter = req.Filter
+	view.UpdatedBy = &updatedBy
+
+	updated, err := t.viewRepo.Update(view)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to update transaction view", err)
+	}
+	return mapTransactionViewToDto(updated), nil
+}
+
+// FindParams implements domain.TransactionViewUseCase. The user id and paging are left to the
+// caller.
+func (t *TransactionViewService) FindParams(id int, userID uuid.UUID) (dto.GetTransactionParams, error) {
+	view, err := t.findOwned(id, userID)
+	if err != nil {
+		return dto.GetTransactionParams{}, err
+	}
+	filter := view.Filter
+	params := dto.GetTransactionParams{
+		AccountID:          filter.AccountID,
+		CategoryID:         filter.CategoryID,
+		CategoryIDs:        filter.CategoryIDs,
+		ExcludeCategoryIDs: filter.ExcludeCategoryIDs,
+		StartDate:          filter.StartDate,
+		EndDate:            filter.EndDate,
+		TransactionType:    filter.TransactionType,
+		MinAmount:          filter.MinAmount,
+		MaxAmount:          filter.MaxAmount,
+		NoteContains:       filter.NoteContains,
+		CreatedFrom:        filter.CreatedFrom,
+		CreatedTo:          filter.CreatedTo,
+		UpdatedFrom:        filter.UpdatedFrom,
+		UpdatedTo:          filter.UpdatedTo,
+		Query:              filter.Query,
+		TagIDs:             filter.TagIDs,
+		TagMatch:           filter.TagMatch,
+		Sort:               filter.Sort,
+	}
+	if filter.Period != nil {
+		if start, end, ok := domain.ViewPeriodRange(*filter.Period, time.Now()); ok {
+			if params.StartDate == nil {
+				params.StartDate = helper.DateToString(&start)
+			}
+			if params.EndDate == nil {
+				params.EndDate = helper.DateToString(&end)
+			}
+		}
+	}
+	return params, nil
+}
+
+// checkTransactionFilter rejects filters GET /transactions would refuse, so a saved view always
+// runs.
+func checkTransactionFilter(filter dto.TransactionFilterDto) error {
+	if filter.Period != nil {
+		if _, _, ok := domain.ViewPeriodRange(*filter.Period, time.Now()); !ok {
+			return domain.BadRequestError(fmt.Sprintf("Unknown period %q", *filter.Period), nil)
+		}
+	}
+	for _, date := range []*string{filter.StartDate, filter.EndDate, filter.CreatedFrom, filter.CreatedTo, filter.UpdatedFrom, filter.UpdatedTo} {
+		if date == nil {
+			continue
+		}
+		if _, err := helper.StringToDate(*date); err != nil {
+			return domain.BadRequestError(fmt.Sprintf("Invalid date %q, expected YYYY-MM-DD", *date), nil)
+		}
+	}
+	if filter.TransactionType != nil && *filter.TransactionType != domain.TransactionTypeIncome &&
+		*filter.TransactionType != domain.TransactionTypeExpense && *filter.TransactionType != domain.TransactionTypeTransfer {
+		return domain.BadRequestError("Invalid transaction type", nil)
+	}
+	if filter.TagMatch != nil && *filter.TagMatch != domain.TagMatchAny && *filter.TagMatch != domain.TagMatchAll {
+		return domain.BadRequestError("tag_match must be any or all", nil)
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return domain.BadRequestError("min_amount cannot be greater than max_amount", nil)
+	}
+	if filter.Sort != nil && *filter.Sort != "" {
+		if _, err := domain.ParseTransactionSort(*filter.Sort); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkName trims name and rejects it when empty or already used by another of the user's views;
+// id is the view being renamed, or 0 for a new one.
+func (t *TransactionViewService) checkName(name string, id int, userID uuid.UUID) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", domain.BadRequestError("View name is required", nil)
+	}
+	existing, err := t.viewRepo.FindByName(userID, name)
+	if err != nil {
+		return "", domain.InternalServerError("Failed to find transaction view", err)
+	}
+	if existing != nil && existing.ID != id {
+		return "", domain.BadRequestError(fmt.Sprintf("View %q already exists", existing.Name), nil)
+	}
+	return name, nil
+}
+
+func (t *TransactionViewService) findOwned(id int, userID uuid.UUID) (*domain.TransactionView, error) {
+	view, err := t.viewRepo.FindByID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction view", err)
+	}
+	if view == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Transaction view with id %d not found", id), nil)
+	}
+	if view.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+	return view, nil
+}
+
+func NewTransactionViewService(viewRepo domain.TransactionViewRepository) domain.TransactionViewUseCase {
+	return &TransactionViewService{
+		viewRepo: viewRepo,
+	}
+}
+
+func mapTransactionViewToDto(view *domain.TransactionView) *dto.TransactionViewDto {
+	return &dto.TransactionViewDto{
+		ID:        view.ID,
+		Name:      view.Name,
+		Filter:    view.Filter,
+		UserID:    view.UserID.String(),
+		CreatedAt: *helper.TimeToString(&view.CreatedAt),
+		UpdatedAt: helper.TimeToString(view.UpdatedAt),
+		CreatedBy: view.CreatedBy,
+		UpdatedBy: view.UpdatedBy,
+	}
+}
@@ -0,0 +1,522 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dimas-pramantya/money-management/dto"
+	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/internal/money"
+	"github.com/dimas-pramantya/money-management/utils/helper"
+	"github.com/google/uuid"
+)
+
+type TransferService struct {
+	transferRepo    domain.TransferRepository
+	transactionRepo domain.TransactionRepository
+	trnCategoryRepo domain.TransactionCategoryRepository
+	balance         balanceUpdater
+	db              *sql.DB
+}
+
+func (t *TransferService) Create(req dto.CreateTransferDto, userID uuid.UUID) (*dto.TransferDto, error) {
+	transfer, err := t.validate(req, userID)
+	if err != nil {
+		return nil, err
+	}
+	transfer.UserID = userID
+	transfer.CreatedBy = userID.String()
+
+	tx, err := t.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	createdTransfer, err := t.transferRepo.Create(tx, transfer)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to create transfer", err)
+	}
+
+	legs, err := t.syncLegs(tx, createdTransfer, req.FeeCategoryID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+
+	return mapTransferToDto(createdTransfer, legs), nil
+}
+
+// Delete moves the transfer and its legs to the trash and reverses them in the ledger, so balances
+// no longer include them.
+func (t *TransferService) Delete(id int, userID uuid.UUID) error {
+	tx, err := t.db.Begin()
+	if err != nil {
+		return domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	transfer, legs, err := t.findOwnedTx(tx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	var reversal []posting
+	for i := range legs {
+		reversal = append(reversal, transactionPostings(&legs[i], -1)...)
+	}
+	err = t.balance.post(tx, userID, journal{
+		entryType:  domain.LedgerEntryReversal,
+		transferID: &transfer.ID,
+		postings:   reversal,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = t.transferRepo.SoftDelete(tx, transfer.ID, userID)
+	if err != nil {
+		return domain.InternalServerError("Failed to delete transfer", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.InternalServerError("Failed to commit transaction", err)
+	}
+	return nil
+}
+
+// FindDeleted lists the user's transfers in the trash, most recently deleted first.
+func (t *TransferService) FindDeleted(userID uuid.UUID, params dto.GetDeletedTransferParams) (dto.PaginationResponse[dto.TransferDto], error) {
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+
+	total, err := t.transferRepo.CountDeleted(userID)
+	if err != nil {
+		return dto.PaginationResponse[dto.TransferDto]{}, domain.InternalServerError("Failed to count deleted transfers", err)
+	}
+	transfers, err := t.transferRepo.FindDeleted(userID, params.Limit, (params.Page-1)*params.Limit)
+	if err != nil {
+		return dto.PaginationResponse[dto.TransferDto]{}, domain.InternalServerError("Failed to fetch deleted transfers", err)
+	}
+	records := make([]dto.TransferDto, len(transfers))
+	for i, transfer := range transfers {
+		legs, err := t.transactionRepo.FindByTransferID(transfer.ID)
+		if err != nil {
+			return dto.PaginationResponse[dto.TransferDto]{}, domain.InternalServerError("Failed to find transfer transactions", err)
+		}
+		records[i] = *mapTransferToDto(&transfer, legs)
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(params.Limit)))
+	var nextPage *int
+	if params.Page < totalPages {
+		next := params.Page + 1
+		nextPage = &next
+	}
+	var prevPage *int
+	if params.Page > 1 {
+		prev := params.Page - 1
+		prevPage = &prev
+	}
+
+	return dto.PaginationResponse[dto.TransferDto]{
+		TotalRecords: total,
+		TotalPages:   totalPages,
+		CurrentPage:  params.Page,
+		Limit:        params.Limit,
+		Records:      records,
+		NextPage:     nextPage,
+		PreviousPage: prevPage,
+	}, nil
+}
+
+// Restore takes the transfer and its legs out of the trash and posts them to the ledger again.
+func (t *TransferService) Restore(id int, userID uuid.UUID) (*dto.TransferDto, error) {
+	tx, err := t.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	transfer, err := t.transferRepo.FindByIDTx(tx, id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transfer", err)
+	}
+	if transfer == nil || transfer.DeletedAt == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Deleted transfer with id %d not found", id), nil)
+	}
+	if transfer.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+
+	restored, err := t.transferRepo.Restore(tx, id, userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to restore transfer", err)
+	}
+	legs, err := t.transactionRepo.FindByTransferIDTx(tx, id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transfer transactions", err)
+	}
+	var postings []posting
+	for i := range legs {
+		postings = append(postings, transactionPostings(&legs[i], 1)...)
+	}
+	err = t.balance.post(tx, userID, journal{
+		entryType:   domain.LedgerEntryTransfer,
+		transferID:  &restored.ID,
+		description: restored.Notes,
+		postings:    postings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+	return mapTransferToDto(restored, legs), nil
+}
+
+// PurgeDeleted removes for good the transfers, with their legs, that have been in the trash since
+// before before.
+func (t *TransferService) PurgeDeleted(before time.Time) (int64, error) {
+	purged, err := t.transferRepo.PurgeDeleted(before)
+	if err != nil {
+		return 0, domain.InternalServerError("Failed to purge deleted transfers", err)
+	}
+	return purged, nil
+}
+
+func (t *TransferService) FindByID(id int, userID uuid.UUID) (*dto.TransferDto, error) {
+	transfer, err := t.transferRepo.FindByID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transfer", err)
+	}
+	if transfer == nil || transfer.DeletedAt != nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("Transfer with id %d not found", id), nil)
+	}
+	if transfer.UserID != userID {
+		return nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+
+	legs, err := t.transactionRepo.FindByTransferID(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transfer transactions", err)
+	}
+	return mapTransferToDto(transfer, legs), nil
+}
+
+func (t *TransferService) FindByUserID(userID uuid.UUID) ([]dto.TransferDto, error) {
+	transfers, err := t.transferRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transfers", err)
+	}
+
+	result := make([]dto.TransferDto, len(transfers))
+	for i, transfer := range transfers {
+		legs, err := t.transactionRepo.FindByTransferID(transfer.ID)
+		if err != nil {
+			return nil, domain.InternalServerError("Failed to find transfer transactions", err)
+		}
+		result[i] = *mapTransferToDto(&transfer, legs)
+	}
+	return result, nil
+}
+
+func (t *TransferService) Update(req dto.UpdateTransferDto, id int, userID uuid.UUID) (*dto.TransferDto, error) {
+	tx, err := t.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	updated, err := t.UpdateTx(tx, req, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+	return updated, nil
+}
+
+// UpdateTx is Update within tx, for callers that have more to write in the same transaction. The
+// caller commits.
+func (t *TransferService) UpdateTx(tx *sql.Tx, req dto.UpdateTransferDto, id int, userID uuid.UUID) (*dto.TransferDto, error) {
+	changes, err := t.validate(dto.CreateTransferDto(req), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, legs, err := t.findOwnedTx(tx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedBy := userID.String()
+	transfer.FromAccountID = changes.FromAccountID
+	transfer.ToAccountID = changes.ToAccountID
+	transfer.Amount = changes.Amount
+	transfer.Fee = changes.Fee
+	transfer.TransferDate = changes.TransferDate
+	transfer.Notes = changes.Notes
+	transfer.UpdatedBy = &updatedBy
+
+	updatedTransfer, err := t.transferRepo.Update(tx, transfer)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to update transfer", err)
+	}
+
+	legs, err = t.syncLegs(tx, updatedTransfer, req.FeeCategoryID, legs)
+	if err != nil {
+		return nil, err
+	}
+	return mapTransferToDto(updatedTransfer, legs), nil
+}
+
+// validate checks the request and returns the transfer it describes; accounts are checked for
+// ownership later when balances are applied.
+func (t *TransferService) validate(req dto.CreateTransferDto, userID uuid.UUID) (*domain.Transfer, error) {
+	if req.FromAccountID == req.ToAccountID {
+		return nil, domain.BadRequestError("Cannot transfer to the same account", nil)
+	}
+	if !req.Amount.IsPositive() {
+		return nil, domain.BadRequestError("Transfer amount must be greater than zero", nil)
+	}
+	if err := t.checkSameCurrency(req.FromAccountID, req.ToAccountID, userID); err != nil {
+		return nil, err
+	}
+	if req.Fee.Amount < 0 {
+		return nil, domain.BadRequestError("Transfer fee cannot be negative", nil)
+	}
+	if req.Fee.IsPositive() {
+		if req.FeeCategoryID == nil {
+			return nil, domain.BadRequestError("fee_category_id is required when a fee is charged", nil)
+		}
+		category, err := t.trnCategoryRepo.FindByID(*req.FeeCategoryID)
+		if err != nil {
+			return nil, domain.InternalServerError("Failed to find transaction category", err)
+		}
+		if category == nil {
+			return nil, domain.NotFoundError(fmt.Sprintf("Category with id %d not found", *req.FeeCategoryID), nil)
+		}
+		if category.UserID != userID {
+			return nil, domain.UnauthorizedError("Unauthorized", nil)
+		}
+		if err := checkCategoryKind(category, domain.TransactionTypeExpense); err != nil {
+			return nil, err
+		}
+	}
+
+	transferDate, err := helper.StringToDate(req.TransferDate)
+	if err != nil {
+		return nil, domain.BadRequestError("Invalid transfer date format", err)
+	}
+
+	return &domain.Transfer{
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount.Amount,
+		Fee:           req.Fee.Amount,
+		TransferDate:  *transferDate,
+		Notes:         req.Note,
+	}, nil
+}
+
+// checkSameCurrency rejects transfers between accounts holding different currencies; a transfer
+// moves one amount, so both legs must be in the same currency.
+func (t *TransferService) checkSameCurrency(fromAccountID int, toAccountID int, userID uuid.UUID) error {
+	from, err := ownedAccount(t.balance.accountRepo, fromAccountID, userID)
+	if err != nil {
+		return err
+	}
+	to, err := ownedAccount(t.balance.accountRepo, toAccountID, userID)
+	if err != nil {
+		return err
+	}
+	if from.Currency != to.Currency {
+		return domain.BadRequestError(fmt.Sprintf("Cannot transfer between accounts in different currencies (%s and %s)", from.Currency, to.Currency), nil)
+	}
+	return nil
+}
+
+func (t *TransferService) findOwnedTx(tx *sql.Tx, id int, userID uuid.UUID) (*domain.Transfer, []domain.Transaction, error) {
+	transfer, err := t.transferRepo.FindByIDTx(tx, id)
+	if err != nil {
+		return nil, nil, domain.InternalServerError("Failed to find transfer", err)
+	}
+	if transfer == nil || transfer.DeletedAt != nil {
+		return nil, nil, domain.NotFoundError(fmt.Sprintf("Transfer with id %d not found", id), nil)
+	}
+	if transfer.UserID != userID {
+		return nil, nil, domain.UnauthorizedError("Unauthorized", nil)
+	}
+
+	legs, err := t.transactionRepo.FindByTransferIDTx(tx, id)
+	if err != nil {
+		return nil, nil, domain.InternalServerError("Failed to find transfer transactions", err)
+	}
+	return transfer, legs, nil
+}
+
+// syncLegs reverses the existing legs in the ledger, then writes the outgoing, incoming and
+// optional fee legs for transfer and posts them as one journal. Legs are updated in place so their
+// transaction ids stay stable across edits.
+func (t *TransferService) syncLegs(tx *sql.Tx, transfer *domain.Transfer, feeCategoryID *int, existing []domain.Transaction) ([]domain.Transaction, error) {
+	var reversal []posting
+	var outLeg, inLeg, feeLeg *domain.Transaction
+	for i := range existing {
+		leg := &existing[i]
+		reversal = append(reversal, transactionPostings(leg, -1)...)
+		switch {
+		case leg.TransferDirection == nil:
+			feeLeg = leg
+		case *leg.TransferDirection == domain.TransferDirectionOut:
+			outLeg = leg
+		default:
+			inLeg = leg
+		}
+	}
+
+	err := t.balance.post(tx, transfer.UserID, journal{
+		entryType:  domain.LedgerEntryReversal,
+		transferID: &transfer.ID,
+		postings:   reversal,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type plannedLeg struct {
+		current *domain.Transaction
+		leg     domain.Transaction
+	}
+
+	outDirection := domain.TransferDirectionOut
+	inDirection := domain.TransferDirectionIn
+	desired := []plannedLeg{
+		{outLeg, domain.Transaction{
+			Amount:            transfer.Amount,
+			AccountID:         transfer.FromAccountID,
+			TransactionType:   domain.TransactionTypeTransfer,
+			TransferDirection: &outDirection,
+		}},
+		{inLeg, domain.Transaction{
+			Amount:            transfer.Amount,
+			AccountID:         transfer.ToAccountID,
+			TransactionType:   domain.TransactionTypeTransfer,
+			TransferDirection: &inDirection,
+		}},
+	}
+	if transfer.Fee > 0 {
+		desired = append(desired, plannedLeg{feeLeg, domain.Transaction{
+			Amount:          transfer.Fee,
+			AccountID:       transfer.FromAccountID,
+			CategoryID:      feeCategoryID,
+			TransactionType: domain.TransactionTypeExpense,
+		}})
+	} else if feeLeg != nil {
+		if err := t.transactionRepo.Delete(tx, feeLeg.ID, transfer.UserID); err != nil {
+			return nil, domain.InternalServerError("Failed to delete transfer fee", err)
+		}
+	}
+
+	legs := make([]domain.Transaction, 0, len(desired))
+	var postings []posting
+	for _, d := range desired {
+		leg := d.leg
+		leg.TransactionDate = transfer.TransferDate
+		leg.Notes = transfer.Notes
+		leg.TransferID = &transfer.ID
+		leg.UserID = transfer.UserID
+
+		var saved *domain.Transaction
+		if d.current != nil {
+			leg.ID = d.current.ID
+			leg.UpdatedBy = transfer.UpdatedBy
+			saved, err = t.transactionRepo.Update(tx, &leg)
+		} else {
+			leg.CreatedBy = transfer.UserID.String()
+			saved, err = t.transactionRepo.Create(tx, &leg)
+		}
+		if err != nil {
+			return nil, domain.InternalServerError("Failed to save transfer transaction", err)
+		}
+		legs = append(legs, *saved)
+		postings = append(postings, transactionPostings(saved, 1)...)
+	}
+
+	err = t.balance.post(tx, transfer.UserID, journal{
+		entryType:   domain.LedgerEntryTransfer,
+		transferID:  &transfer.ID,
+		description: transfer.Notes,
+		postings:    postings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return legs, nil
+}
+
+func NewTransferService(
+	transferRepo domain.TransferRepository,
+	transactionRepo domain.TransactionRepository,
+	trnCategoryRepo domain.TransactionCategoryRepository,
+	userRepo domain.UserRepository,
+	accountRepo domain.AccountRepository,
+	ledgerRepo domain.LedgerRepository,
+	db *sql.DB,
+) domain.TransferUseCase {
+	return &TransferService{
+		transferRepo:    transferRepo,
+		transactionRepo: transactionRepo,
+		trnCategoryRepo: trnCategoryRepo,
+		balance:         balanceUpdater{userRepo: userRepo, accountRepo: accountRepo, ledgerRepo: ledgerRepo},
+		db:              db,
+	}
+}
+
+func mapTransferToDto(transfer *domain.Transfer, legs []domain.Transaction) *dto.TransferDto {
+	res := &dto.TransferDto{
+		ID:            transfer.ID,
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		Amount:        money.Money{Amount: transfer.Amount},
+		Fee:           money.Money{Amount: transfer.Fee},
+		TransferDate:  *helper.DateToString(&transfer.TransferDate),
+		Notes:         transfer.Notes,
+		UserID:        transfer.UserID.String(),
+		CreatedAt:     *helper.TimeToString(&transfer.CreatedAt),
+		UpdatedAt:     helper.TimeToString(transfer.UpdatedAt),
+		CreatedBy:     transfer.CreatedBy,
+		UpdatedBy:     transfer.UpdatedBy,
+		DeletedAt:     helper.TimeToString(transfer.DeletedAt),
+	}
+	for _, leg := range legs {
+		switch {
+		case leg.TransferDirection == nil:
+			id := leg.ID
+			res.FeeTransactionID = &id
+			res.FeeCategoryID = leg.CategoryID
+		case *leg.TransferDirection == domain.TransferDirectionOut:
+			res.OutTransactionID = leg.ID
+		default:
+			res.InTransactionID = leg.ID
+		}
+	}
+	return res
+}
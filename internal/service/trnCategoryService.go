@@ -1,7 +1,10 @@
 package service
 
 import (
+	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/dimas-pramantya/money-management/dto"
 	"github.com/dimas-pramantya/money-management/internal/domain"
@@ -11,12 +14,29 @@ import (
 
 type TransactionCategoryService struct {
 	transactionCategoryRepo domain.TransactionCategoryRepository
+	balance                 balanceUpdater
+	db                      *sql.DB
 }
 
 func (t *TransactionCategoryService) Create(req dto.CreateTransactionCategoryDto, userID uuid.UUID) (*dto.TransactionCategoryDto, error) {
+	if req.ParentID != nil {
+		parent, err := ownedCategory(t.transactionCategoryRepo, *req.ParentID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkNotArchived(parent); err != nil {
+			return nil, err
+		}
+	}
+	kind := req.Kind
+	if kind == "" {
+		kind = domain.CategoryKindBoth
+	}
 	category := &domain.TransactionCategory{
-		Name:   req.Name,
-		UserID: userID,
+		Name:      req.Name,
+		UserID:    userID,
+		ParentID:  req.ParentID,
+		Kind:      kind,
 		CreatedBy: userID.String(),
 	}
 
@@ -28,24 +48,182 @@ func (t *TransactionCategoryService) Create(req dto.CreateTransactionCategoryDto
 	return mapTransactionCategoryToDto(createdCategory), nil
 }
 
-func (t *TransactionCategoryService) Delete(id int, userId uuid.UUID) error {
-	category, err := t.transactionCategoryRepo.FindByID(id)
+// Delete removes the category together with every category below it. While transactions, splits,
+// recurring templates or budgets are filed anywhere in that subtree the delete is refused, unless
+// req.ReassignTo names a category outside it to take them over.
+func (t *TransactionCategoryService) Delete(id int, req dto.DeleteTransactionCategoryDto, userId uuid.UUID) error {
+	if _, err := ownedCategory(t.transactionCategoryRepo, id, userId); err != nil {
+		return err
+	}
+
+	tx, err := t.db.Begin()
 	if err != nil {
-		return domain.InternalServerError("Failed to find transaction category", err)
+		return domain.InternalServerError("Failed to begin transaction", err)
 	}
-	if category == nil {
-		return domain.NotFoundError(fmt.Sprintf("Transaction category with id %d not found", id), nil)
+	defer tx.Rollback()
+
+	subtree, err := t.lockedSubtree(tx, id, userId)
+	if err != nil {
+		return err
 	}
-	if category.UserID != userId {
-		return domain.UnauthorizedError("Unauthorized", nil)
+
+	var target *domain.TransactionCategory
+	if req.ReassignTo != nil {
+		target, err = t.reassignmentTarget(*req.ReassignTo, subtree, userId)
+		if err != nil {
+			return err
+		}
 	}
-	err = t.transactionCategoryRepo.Delete(id)
+	usage, err := t.usage(subtree)
 	if err != nil {
+		return err
+	}
+	budgets, err := t.transactionCategoryRepo.CountBudgets(subtree)
+	if err != nil {
+		return domain.InternalServerError("Failed to count category budgets", err)
+	}
+	if target == nil && (len(usage) > 0 || budgets > 0) {
+		return domain.BadRequestError(fmt.Sprintf("Category %d still has transactions or budgets; pass reassign_to to move them to another category", id), nil)
+	}
+
+	if target != nil {
+		if err := t.reassign(tx, subtree, target, usage, userId); err != nil {
+			return err
+		}
+	}
+	if err := t.transactionCategoryRepo.DeleteTx(tx, id); err != nil {
 		return domain.InternalServerError("Failed to delete transaction category", err)
 	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.InternalServerError("Failed to commit transaction", err)
+	}
 	return nil
 }
 
+// Merge folds category id into req.TargetID: its entries are filed under the target, its child
+// categories move below the target and the category itself is deleted, all or nothing.
+func (t *TransactionCategoryService) Merge(id int, req dto.MergeTransactionCategoryDto, userID uuid.UUID) (*dto.TransactionCategoryDto, error) {
+	if _, err := ownedCategory(t.transactionCategoryRepo, id, userID); err != nil {
+		return nil, err
+	}
+
+	tx, err := t.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	subtree, err := t.lockedSubtree(tx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := t.reassignmentTarget(req.TargetID, subtree, userID)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := t.usage([]int{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.reassign(tx, []int{id}, target, usage, userID); err != nil {
+		return nil, err
+	}
+	if err := t.transactionCategoryRepo.MoveChildrenTx(tx, id, target.ID, userID.String()); err != nil {
+		return nil, domain.InternalServerError("Failed to move child categories", err)
+	}
+	if err := t.transactionCategoryRepo.DeleteTx(tx, id); err != nil {
+		return nil, domain.InternalServerError("Failed to delete transaction category", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+	return t.FindByID(target.ID)
+}
+
+// reassignmentTarget loads the category that takes over the entries of subtree. It may not lie in
+// subtree, which is about to be deleted, and may not be archived, since a merge moves child
+// categories below it.
+func (t *TransactionCategoryService) reassignmentTarget(targetID int, subtree []int, userID uuid.UUID) (*domain.TransactionCategory, error) {
+	target, err := ownedCategory(t.transactionCategoryRepo, targetID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNotArchived(target); err != nil {
+		return nil, err
+	}
+	for _, subtreeID := range subtree {
+		if subtreeID == targetID {
+			return nil, domain.BadRequestError(fmt.Sprintf("Category %d is being removed and cannot take over its entries", targetID), nil)
+		}
+	}
+	return target, nil
+}
+
+// usage returns the transaction types filed under the categories ids, mapped to their entry count.
+func (t *TransactionCategoryService) usage(ids []int) (map[string]int, error) {
+	usage := map[string]int{}
+	for _, transactionType := range []string{domain.TransactionTypeIncome, domain.TransactionTypeExpense} {
+		count, err := t.transactionCategoryRepo.CountUsage(ids, transactionType)
+		if err != nil {
+			return nil, domain.InternalServerError("Failed to count category usage", err)
+		}
+		if count > 0 {
+			usage[transactionType] = count
+		}
+	}
+	return usage, nil
+}
+
+// reassign files the entries of fromIDs under target and moves their income and expense totals
+// to the target's nominal ledger accounts, so the ledger keeps matching the transactions. Account
+// balances are unaffected. It is refused when the target would end up with two budgets for the
+// same period, which would count its spending twice.
+func (t *TransactionCategoryService) reassign(tx *sql.Tx, fromIDs []int, target *domain.TransactionCategory, usage map[string]int, userID uuid.UUID) error {
+	for transactionType := range usage {
+		if err := checkCategoryKind(target, transactionType); err != nil {
+			return err
+		}
+	}
+	conflicts, err := t.transactionCategoryRepo.FindBudgetPeriodConflictsTx(tx, fromIDs, target.ID)
+	if err != nil {
+		return domain.InternalServerError("Failed to check category budgets", err)
+	}
+	if len(conflicts) > 0 {
+		return domain.BadRequestError(fmt.Sprintf("Category %q would get more than one %s budget; delete the extra budgets first", target.Path, strings.Join(conflicts, " and ")), nil)
+	}
+	if err := t.transactionCategoryRepo.ReassignTx(tx, userID, fromIDs, target.ID); err != nil {
+		return domain.InternalServerError("Failed to reassign category entries", err)
+	}
+
+	var postings []posting
+	for _, transactionType := range []string{domain.TransactionTypeIncome, domain.TransactionTypeExpense} {
+		for _, id := range fromIDs {
+			balance, err := t.balance.ledgerRepo.SumByLedgerAccountTx(tx, userID, nominalLedgerAccount(transactionType, id))
+			if err != nil {
+				return domain.InternalServerError("Failed to sum ledger entries", err)
+			}
+			if balance == 0 {
+				continue
+			}
+			postings = append(postings,
+				posting{ledgerAccount: nominalLedgerAccount(transactionType, id), amount: -balance},
+				posting{ledgerAccount: nominalLedgerAccount(transactionType, target.ID), amount: balance},
+			)
+		}
+	}
+	description := fmt.Sprintf("Category entries reassigned to %s", target.Path)
+	return t.balance.post(tx, userID, journal{
+		entryType:   domain.LedgerEntryAdjustment,
+		description: &description,
+		postings:    postings,
+	})
+}
+
 func (t *TransactionCategoryService) FindByID(id int) (*dto.TransactionCategoryDto, error) {
 	category, err := t.transactionCategoryRepo.FindByID(id)
 	if err != nil {
@@ -57,10 +235,10 @@ func (t *TransactionCategoryService) FindByID(id int) (*dto.TransactionCategoryD
 	return mapTransactionCategoryToDto(category), nil
 }
 
-func (t *TransactionCategoryService) FindByUserID(userID uuid.UUID) ([]dto.TransactionCategoryDto, error) {
-	categories, err := t.transactionCategoryRepo.FindByUserID(userID)
+func (t *TransactionCategoryService) FindByUserID(userID uuid.UUID, params dto.GetTransactionCategoryParams) ([]dto.TransactionCategoryDto, error) {
+	categories, err := t.findListed(userID, params)
 	if err != nil {
-		return nil, domain.InternalServerError("Failed to find transaction categories", err)
+		return nil, err
 	}
 	result := make([]dto.TransactionCategoryDto, len(categories))
 	for i, category := range categories {
@@ -69,42 +247,242 @@ func (t *TransactionCategoryService) FindByUserID(userID uuid.UUID) ([]dto.Trans
 	return result, nil
 }
 
+// findListed returns the user's categories, leaving out archived ones unless asked for.
+func (t *TransactionCategoryService) findListed(userID uuid.UUID, params dto.GetTransactionCategoryParams) ([]domain.TransactionCategory, error) {
+	categories, err := t.transactionCategoryRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction categories", err)
+	}
+	if params.IncludeArchived {
+		return categories, nil
+	}
+	listed := make([]domain.TransactionCategory, 0, len(categories))
+	for _, category := range categories {
+		if category.ArchivedAt == nil {
+			listed = append(listed, category)
+		}
+	}
+	return listed, nil
+}
+
+// FindTree lists the user's root categories, each with its descendants nested under it.
+func (t *TransactionCategoryService) FindTree(userID uuid.UUID, params dto.GetTransactionCategoryParams) ([]dto.TransactionCategoryTreeDto, error) {
+	categories, err := t.findListed(userID, params)
+	if err != nil {
+		return nil, err
+	}
+	children := map[int][]domain.TransactionCategory{}
+	var roots []domain.TransactionCategory
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+		} else {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		}
+	}
+
+	var build func(nodes []domain.TransactionCategory) []dto.TransactionCategoryTreeDto
+	build = func(nodes []domain.TransactionCategory) []dto.TransactionCategoryTreeDto {
+		result := make([]dto.TransactionCategoryTreeDto, len(nodes))
+		for i := range nodes {
+			result[i] = dto.TransactionCategoryTreeDto{
+				TransactionCategoryDto: *mapTransactionCategoryToDto(&nodes[i]),
+				Children:               build(children[nodes[i].ID]),
+			}
+		}
+		return result
+	}
+	return build(roots), nil
+}
+
+// Update renames the category and moves it, with everything below it, under req.ParentID or to the
+// top level when req.MoveToRoot is set; otherwise it keeps its parent. The new parent must belong
+// to the same user, may not be archived and may not be the category itself or one of its
+// descendants. The kind may only be narrowed when no entries of the other transaction type use the
+// category. The user's categories stay locked from the check to the write, so two concurrent moves
+// cannot close a cycle between them.
 func (t *TransactionCategoryService) Update(req dto.UpdateTransactionCategoryDto, id int, userID uuid.UUID) (*dto.TransactionCategoryDto, error) {
-	category, err := t.transactionCategoryRepo.FindByID(id)
+	tx, err := t.db.Begin()
 	if err != nil {
-		return nil, domain.InternalServerError("Failed to find transaction category", err)
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
 	}
-	if category == nil {
-		return nil, domain.NotFoundError(fmt.Sprintf("Transaction category with id %d not found", id), nil)
+	defer tx.Rollback()
+
+	if err := t.transactionCategoryRepo.LockByUserIDTx(tx, userID); err != nil {
+		return nil, domain.InternalServerError("Failed to lock transaction categories", err)
+	}
+	category, err := ownedCategory(t.transactionCategoryRepo, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	moved := req.ParentID != nil && (category.ParentID == nil || *category.ParentID != *req.ParentID)
+	if moved {
+		if err := t.checkParent(tx, id, *req.ParentID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Kind != nil && *req.Kind != category.Kind {
+		if err := t.checkKind(category, *req.Kind); err != nil {
+			return nil, err
+		}
+		category.Kind = *req.Kind
 	}
 
 	category.Name = req.Name
-	category.UserID = userID
+	if moved {
+		category.ParentID = req.ParentID
+	} else if req.MoveToRoot {
+		category.ParentID = nil
+	}
 	updatedBy := userID.String()
 	category.UpdatedBy = &updatedBy
 
-	updatedCategory, err := t.transactionCategoryRepo.Update(category)
+	updatedCategory, err := t.transactionCategoryRepo.UpdateTx(tx, category)
 	if err != nil {
 		return nil, domain.InternalServerError("Failed to update transaction category", err)
 	}
 
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+
 	return mapTransactionCategoryToDto(updatedCategory), nil
 }
 
-func NewTransactionCategoryService(transactionCategoryRepo domain.TransactionCategoryRepository) domain.TransactionCategoryUseCase {
+// Archive hides the category and every category below it from listings. Their transactions,
+// budgets and reports are left as they are.
+func (t *TransactionCategoryService) Archive(id int, userID uuid.UUID) (*dto.TransactionCategoryDto, error) {
+	if _, err := ownedCategory(t.transactionCategoryRepo, id, userID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return t.setArchived(id, &now, userID)
+}
+
+// Unarchive lists the category and every category below it again. The parent has to be
+// unarchived first, so a listed category never hangs below a hidden one.
+func (t *TransactionCategoryService) Unarchive(id int, userID uuid.UUID) (*dto.TransactionCategoryDto, error) {
+	category, err := ownedCategory(t.transactionCategoryRepo, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if category.ParentID != nil {
+		parent, err := ownedCategory(t.transactionCategoryRepo, *category.ParentID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkNotArchived(parent); err != nil {
+			return nil, err
+		}
+	}
+	return t.setArchived(id, nil, userID)
+}
+
+// checkNotArchived rejects placing a category below parent while parent is archived.
+func checkNotArchived(parent *domain.TransactionCategory) error {
+	if parent.ArchivedAt != nil {
+		return domain.BadRequestError(fmt.Sprintf("Category %q is archived; unarchive it first", parent.Path), nil)
+	}
+	return nil
+}
+
+func (t *TransactionCategoryService) setArchived(id int, archivedAt *time.Time, userID uuid.UUID) (*dto.TransactionCategoryDto, error) {
+	subtree, err := t.transactionCategoryRepo.FindSubtreeIDs(id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction categories", err)
+	}
+	if err := t.transactionCategoryRepo.SetArchived(subtree, archivedAt, userID.String()); err != nil {
+		return nil, domain.InternalServerError("Failed to archive transaction category", err)
+	}
+	return t.FindByID(id)
+}
+
+// checkParent rejects parentID as the new parent of category id when it belongs to another user, is
+// archived or lies in the subtree of id, which would close a cycle. tx must hold the lock taken by
+// LockByUserIDTx.
+func (t *TransactionCategoryService) checkParent(tx *sql.Tx, id int, parentID int, userID uuid.UUID) error {
+	parent, err := ownedCategory(t.transactionCategoryRepo, parentID, userID)
+	if err != nil {
+		return err
+	}
+	if err := checkNotArchived(parent); err != nil {
+		return err
+	}
+	subtree, err := t.transactionCategoryRepo.FindSubtreeIDsTx(tx, id)
+	if err != nil {
+		return domain.InternalServerError("Failed to find transaction categories", err)
+	}
+	for _, subtreeID := range subtree {
+		if subtreeID == parentID {
+			return domain.BadRequestError(fmt.Sprintf("Category %d cannot be moved under itself or one of its descendants", id), nil)
+		}
+	}
+	return nil
+}
+
+// lockedSubtree locks the user's categories in tx and returns category id with every category below
+// it, as seen under that lock.
+func (t *TransactionCategoryService) lockedSubtree(tx *sql.Tx, id int, userID uuid.UUID) ([]int, error) {
+	if err := t.transactionCategoryRepo.LockByUserIDTx(tx, userID); err != nil {
+		return nil, domain.InternalServerError("Failed to lock transaction categories", err)
+	}
+	subtree, err := t.transactionCategoryRepo.FindSubtreeIDsTx(tx, id)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find transaction categories", err)
+	}
+	return subtree, nil
+}
+
+// checkKind rejects kind for the category while transactions, splits or recurring templates of the
+// type it would exclude are still filed under it.
+func (t *TransactionCategoryService) checkKind(category *domain.TransactionCategory, kind string) error {
+	var excluded string
+	switch kind {
+	case domain.CategoryKindIncome:
+		excluded = domain.CategoryKindExpense
+	case domain.CategoryKindExpense:
+		excluded = domain.CategoryKindIncome
+	default:
+		return nil
+	}
+	count, err := t.transactionCategoryRepo.CountUsage([]int{category.ID}, excluded)
+	if err != nil {
+		return domain.InternalServerError("Failed to count category usage", err)
+	}
+	if count > 0 {
+		return domain.BadRequestError(fmt.Sprintf("Category %q still has %d %s entries and cannot become %s only", category.Name, count, excluded, kind), nil)
+	}
+	return nil
+}
+
+func NewTransactionCategoryService(
+	transactionCategoryRepo domain.TransactionCategoryRepository,
+	userRepo domain.UserRepository,
+	accountRepo domain.AccountRepository,
+	ledgerRepo domain.LedgerRepository,
+	db *sql.DB,
+) domain.TransactionCategoryUseCase {
 	return &TransactionCategoryService{
 		transactionCategoryRepo: transactionCategoryRepo,
+		balance:                 balanceUpdater{userRepo: userRepo, accountRepo: accountRepo, ledgerRepo: ledgerRepo},
+		db:                      db,
 	}
 }
 
 func mapTransactionCategoryToDto(category *domain.TransactionCategory) *dto.TransactionCategoryDto {
 	return &dto.TransactionCategoryDto{
-		ID:        category.ID,
-		Name:      category.Name,
-		UserID:    category.UserID.String(),
-		CreatedAt: *helper.TimeToString(&category.CreatedAt),
-		UpdatedAt: helper.TimeToString(category.UpdatedAt),
-		CreatedBy: category.CreatedBy,
-		UpdatedBy: category.UpdatedBy,
-	}
-}
\ No newline at end of file
+		ID:         category.ID,
+		Name:       category.Name,
+		UserID:     category.UserID.String(),
+		ParentID:   category.ParentID,
+		Kind:       category.Kind,
+		Path:       category.Path,
+		ArchivedAt: helper.TimeToString(category.ArchivedAt),
+		CreatedAt:  *helper.TimeToString(&category.CreatedAt),
+		UpdatedAt:  helper.TimeToString(category.UpdatedAt),
+		CreatedBy:  category.CreatedBy,
+		UpdatedBy:  category.UpdatedBy,
+	}
+}
@@ -1,49 +1,146 @@
 package service
 
 import (
+	"database/sql"
 	"fmt"
 
 	"github.com/dimas-pramantya/money-management/dto"
 	"github.com/dimas-pramantya/money-management/internal/api/middleware"
 	"github.com/dimas-pramantya/money-management/internal/domain"
+	"github.com/dimas-pramantya/money-management/internal/money"
 	"github.com/dimas-pramantya/money-management/utils/helper"
 )
 
 type UserService struct {
-	userRepo domain.UserRepository
+	userRepo          domain.UserRepository
+	accountRepo       domain.AccountRepository
+	exchangeRateRepo  domain.ExchangeRateRepository
+	trnCategoryRepo   domain.TransactionCategoryRepository
+	defaultCategories domain.DefaultCategorySet
+	balance           balanceUpdater
+	db                *sql.DB
 }
 
+// defaultBaseCurrency is used for users who register without choosing a base currency.
+const defaultBaseCurrency = "IDR"
+
 func (u *UserService) UpdateBalance(id string, req dto.ReqUpdateUserBalanceDto) (*dto.ResUserDto, error) {
 	user, err := u.userRepo.FindById(id)
 	if err != nil {
-		return nil, domain.InternalServerError(fmt.Sprintf("Failed to find user with id %d", id), err)
+		return nil, domain.InternalServerError(fmt.Sprintf("Failed to find user with id %s", id), err)
 	}
 
 	if user == nil {
-		return nil, domain.NotFoundError(fmt.Sprintf("User with id %d not found.", id), nil)
+		return nil, domain.NotFoundError(fmt.Sprintf("User with id %s not found.", id), nil)
 	}
 
-	user.Balance = req.Balance
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
 
-	updatedUser, err := u.userRepo.UpdateBalance(user)
+	account, err := u.balance.lockOwnedAccount(tx, user.ID, req.AccountID)
 	if err != nil {
-		return nil, domain.InternalServerError("Failed to update user balance", err)
+		return nil, err
 	}
 
-	return mapUserToResUserDto(updatedUser), nil
+	err = u.balance.post(tx, user.ID, journal{
+		entryType:   domain.LedgerEntryAdjustment,
+		description: req.Note,
+		postings:    equityPostings(account.ID, domain.LedgerAccountAdjustments, req.Balance.Amount-account.Balance),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+
+	return u.FindById(id)
 }
 
 func (u *UserService) FindById(id string) (*dto.ResUserDto, error) {
 	user, err := u.userRepo.FindById(id)
 	if err != nil {
-		return nil, domain.InternalServerError(fmt.Sprintf("Failed to find user with id %d", id), err)
+		return nil, domain.InternalServerError(fmt.Sprintf("Failed to find user with id %s", id), err)
 	}
 
 	if user == nil {
-		return nil, domain.NotFoundError(fmt.Sprintf("User with id %d not found.", id), nil)
+		return nil, domain.NotFoundError(fmt.Sprintf("User with id %s not found.", id), nil)
 	}
 
-	return mapUserToResUserDto(user), nil
+	accounts, err := u.accountRepo.FindByUserID(user.ID)
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to find user accounts", err)
+	}
+
+	res := mapUserToResUserDto(user)
+	res.TotalBalance = money.Money{Currency: user.BaseCurrency}
+	res.Accounts = make([]dto.AccountDto, len(accounts))
+	unconverted := map[string]bool{}
+	for i, account := range accounts {
+		res.Accounts[i] = *mapAccountToDto(&account)
+		if account.Currency == user.BaseCurrency {
+			res.TotalBalance.Amount += account.Balance
+			continue
+		}
+		converted, err := u.exchangeRateRepo.Convert(user.ID, account.Balance, account.Currency, user.BaseCurrency, today())
+		if err != nil {
+			return nil, domain.InternalServerError("Failed to convert account balance", err)
+		}
+		if converted == nil {
+			if !unconverted[account.Currency] {
+				unconverted[account.Currency] = true
+				res.UnconvertedCurrencies = append(res.UnconvertedCurrencies, account.Currency)
+			}
+			continue
+		}
+		res.TotalBalance.Amount += *converted
+	}
+
+	return res, nil
+}
+
+// UpdateBaseCurrency changes the currency reports and the profile total are converted into.
+// Stored amounts are untouched apart from users.balance, which is re-summed over the accounts in
+// the new base currency.
+func (u *UserService) UpdateBaseCurrency(id string, req dto.ReqUpdateBaseCurrencyDto) (*dto.ResUserDto, error) {
+	if !isCurrencyCode(req.BaseCurrency) {
+		return nil, domain.BadRequestError("base_currency must be a 3-letter upper-case code such as IDR", nil)
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	user, err := u.userRepo.FindByIdTx(tx, id)
+	if err != nil {
+		return nil, domain.InternalServerError(fmt.Sprintf("Failed to find user with id %s", id), err)
+	}
+
+	if user == nil {
+		return nil, domain.NotFoundError(fmt.Sprintf("User with id %s not found.", id), nil)
+	}
+
+	user.BaseCurrency = req.BaseCurrency
+	if _, err := u.userRepo.UpdateBaseCurrencyTx(tx, user); err != nil {
+		return nil, domain.InternalServerError("Failed to update base currency", err)
+	}
+	if err := u.balance.resync(tx, user.ID); err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
+
+	return u.FindById(id)
 }
 
 func (u *UserService) FindByUsername(username string) (*dto.ResUserDto, error) {
@@ -118,27 +215,69 @@ func (u *UserService) Register(req dto.RegisterDto) (*dto.ResUserDto, error) {
 	}
 
 	newUser := &domain.User{
-		Username: req.Username,
-		Password: hashedPassword,
-		Email:    req.Email,
+		Username:     req.Username,
+		Password:     hashedPassword,
+		Email:        req.Email,
+		BaseCurrency: req.BaseCurrency,
 	}
+	if newUser.BaseCurrency == "" {
+		newUser.BaseCurrency = defaultBaseCurrency
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to begin transaction", err)
+	}
+	defer tx.Rollback()
 
-	user, err = u.userRepo.Create(newUser)
+	user, err = u.userRepo.CreateTx(tx, newUser)
 	if err != nil {
 		return nil, domain.InternalServerError("Failed to create user", err)
 	}
+	if err := u.seedCategories(tx, u.defaultCategories.Categories, nil, user, req.Locale); err != nil {
+		return nil, domain.InternalServerError("Failed to create default categories", err)
+	}
 
+	err = tx.Commit()
+	if err != nil {
+		return nil, domain.InternalServerError("Failed to commit transaction", err)
+	}
 	return mapUserToResUserDto(user), nil
 }
 
+// seedCategories creates the default categories for a new user under parentID, named for locale.
+// A category without a name in any fallback locale is skipped along with its children.
+func (u *UserService) seedCategories(tx *sql.Tx, categories []domain.DefaultCategory, parentID *int, user *domain.User, locale string) error {
+	for _, category := range categories {
+		name := u.defaultCategories.Name(category, locale)
+		if name == "" {
+			continue
+		}
+		created, err := u.trnCategoryRepo.CreateTx(tx, &domain.TransactionCategory{
+			Name:      name,
+			UserID:    user.ID,
+			ParentID:  parentID,
+			Kind:      category.Kind,
+			CreatedBy: user.ID.String(),
+		})
+		if err != nil {
+			return err
+		}
+		if err := u.seedCategories(tx, category.Children, &created.ID, user, locale); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (u *UserService) Update(id string, req dto.ReqUpdateUserDto) (*dto.ResUserDto, error) {
 	user, err := u.userRepo.FindById(id)
 	if err != nil {
-		return nil, domain.InternalServerError(fmt.Sprintf("Failed to find user with id %d", id), err)
+		return nil, domain.InternalServerError(fmt.Sprintf("Failed to find user with id %s", id), err)
 	}
 
 	if user == nil {
-		return nil, domain.NotFoundError(fmt.Sprintf("User with id %d not found.", id), nil)
+		return nil, domain.NotFoundError(fmt.Sprintf("User with id %s not found.", id), nil)
 	}
 
 	user.Username = req.Username
@@ -155,11 +294,11 @@ func (u *UserService) Update(id string, req dto.ReqUpdateUserDto) (*dto.ResUserD
 func (u *UserService) UpdatePassword(id string, req dto.ReqUpdateUserPasswordDto) error {
 	user, err := u.userRepo.FindById(id)
 	if err != nil {
-		return domain.InternalServerError(fmt.Sprintf("Failed to find user with id %d", id), err)
+		return domain.InternalServerError(fmt.Sprintf("Failed to find user with id %s", id), err)
 	}
 
 	if user == nil {
-		return domain.NotFoundError(fmt.Sprintf("User with id %d not found.", id), nil)
+		return domain.NotFoundError(fmt.Sprintf("User with id %s not found.", id), nil)
 	}
 
 	if !helper.CheckPasswordHash(req.OldPassword, user.Password) {
@@ -182,9 +321,15 @@ func (u *UserService) UpdatePassword(id string, req dto.ReqUpdateUserPasswordDto
 	return nil
 }
 
-func NewUserService(userRepo domain.UserRepository) domain.UserUsecase {
+func NewUserService(userRepo domain.UserRepository, accountRepo domain.AccountRepository, ledgerRepo domain.LedgerRepository, exchangeRateRepo domain.ExchangeRateRepository, trnCategoryRepo domain.TransactionCategoryRepository, defaultCategories domain.DefaultCategorySet, db *sql.DB) domain.UserUsecase {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:          userRepo,
+		accountRepo:       accountRepo,
+		exchangeRateRepo:  exchangeRateRepo,
+		trnCategoryRepo:   trnCategoryRepo,
+		defaultCategories: defaultCategories,
+		balance:           balanceUpdater{userRepo: userRepo, accountRepo: accountRepo, ledgerRepo: ledgerRepo},
+		db:                db,
 	}
 }
 
@@ -193,7 +338,9 @@ func mapUserToResUserDto(user *domain.User) *dto.ResUserDto {
 		ID:        user.ID.String(),
 		Username:  user.Username,
 		Email:     user.Email,
-		Balance:  user.Balance,
+		Balance:  money.Money{Amount: user.Balance, Currency: user.BaseCurrency},
+		BaseCurrency: user.BaseCurrency,
+		TotalBalance: money.Money{Amount: user.Balance, Currency: user.BaseCurrency},
 		CreatedAt: *helper.TimeToString(&user.CreatedAt),
 		UpdatedAt: helper.TimeToString(user.UpdatedAt),
 		CreatedBy: user.CreatedBy,